@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the API response cache",
+	Long: `Inspect and clear the on-disk Codeforces API cache (~/.cf/cache).
+
+Responses are reused per endpoint: problemset for a day, contest list for an
+hour, submissions for a few minutes. Stale copies are served while refreshing
+in the background, and whenever the API is unreachable.`,
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache usage per endpoint",
+	Args:  cobra.NoArgs,
+	RunE:  runCacheStats,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear [endpoint]",
+	Short: "Clear cached responses",
+	Long: `Clear all cached responses, or only those of one API endpoint.
+
+Examples:
+  cf cache clear                       # Clear everything
+  cf cache clear problemset.problems   # Clear only the problemset`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runCacheClear,
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheStatsCmd)
+	cacheCmd.AddCommand(cacheClearCmd)
+}
+
+func runCacheStats(cmd *cobra.Command, args []string) error {
+	disk, err := getDiskCache()
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	stats, err := disk.Stats()
+	if err != nil {
+		return fmt.Errorf("failed to read cache: %w", err)
+	}
+
+	fmt.Printf("\n📦 API Cache: %s\n", disk.Dir())
+	fmt.Println(strings.Repeat("─", 80))
+
+	if stats.Entries == 0 {
+		fmt.Println("Cache is empty.")
+		return nil
+	}
+
+	methods := make([]string, 0, len(stats.Methods))
+	for method := range stats.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	fmt.Printf("%-22s %7s %10s %8s %14s  %s\n", "Endpoint", "Entries", "Size", "TTL", "Newest", "State")
+	fmt.Println(strings.Repeat("─", 80))
+	for _, method := range methods {
+		m := stats.Methods[method]
+		policy := cfapi.DefaultCachePolicies[method]
+		fmt.Printf("%-22s %7d %10s %8s %14s  %s\n",
+			method,
+			m.Entries,
+			formatBytes(m.SizeBytes),
+			formatTTL(policy.TTL),
+			formatTimeAgo(m.Newest),
+			cacheState(time.Since(m.Newest), policy),
+		)
+	}
+	fmt.Println(strings.Repeat("─", 80))
+	fmt.Printf("%-22s %7d %10s\n\n", "Total", stats.Entries, formatBytes(stats.SizeBytes))
+
+	return nil
+}
+
+func runCacheClear(cmd *cobra.Command, args []string) error {
+	disk, err := getDiskCache()
+	if err != nil {
+		return fmt.Errorf("failed to open cache: %w", err)
+	}
+
+	method := ""
+	if len(args) == 1 {
+		method = args[0]
+	}
+
+	removed, err := disk.Clear(method)
+	if err != nil {
+		return fmt.Errorf("failed to clear cache: %w", err)
+	}
+
+	if method != "" {
+		fmt.Printf("✓ Cleared %d cached %s response(s)\n", removed, method)
+	} else {
+		fmt.Printf("✓ Cleared %d cached response(s)\n", removed)
+	}
+	return nil
+}
+
+// cacheState describes whether an entry of the given age is fresh, stale
+// (served while revalidating) or expired (refetched, offline fallback only)
+func cacheState(age time.Duration, policy cfapi.CachePolicy) string {
+	switch {
+	case age < policy.TTL:
+		return "fresh"
+	case age < policy.TTL+policy.StaleWhileRevalidate:
+		return "stale"
+	default:
+		return "expired"
+	}
+}
+
+// formatTTL formats a cache TTL compactly, e.g. "5m", "1h", "24h"
+func formatTTL(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return d.String()
+	}
+}
+
+// formatBytes formats a byte count, e.g. "4.2 MB"
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGT"[exp])
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func TestCacheState(t *testing.T) {
+	policy := cfapi.CachePolicy{TTL: time.Hour, StaleWhileRevalidate: 24 * time.Hour}
+
+	tests := []struct {
+		age  time.Duration
+		want string
+	}{
+		{time.Minute, "fresh"},
+		{2 * time.Hour, "stale"},
+		{48 * time.Hour, "expired"},
+	}
+
+	for _, tt := range tests {
+		if got := cacheState(tt.age, policy); got != tt.want {
+			t.Errorf("cacheState(%v) = %q, want %q", tt.age, got, tt.want)
+		}
+	}
+
+	if got := cacheState(time.Minute, cfapi.CachePolicy{}); got != "expired" {
+		t.Errorf("cacheState() without policy = %q, want expired", got)
+	}
+}
+
+func TestFormatTTL(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "-"},
+		{5 * time.Minute, "5m"},
+		{24 * time.Hour, "24h"},
+		{90 * time.Second, "1m30s"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTTL(tt.d); got != tt.want {
+			t.Errorf("formatTTL(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		n    int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1536, "1.5 KB"},
+		{5 * 1024 * 1024, "5.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if got := formatBytes(tt.n); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.n, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// problemListSource lists the problems of a contest from one place
+type problemListSource struct {
+	method string // v1.FetchMethod* value recorded for problems listed here
+	list   func(ctx context.Context, contestID int) ([]cfapi.Problem, error)
+}
+
+// contestProblemResolver finds a contest's problem list by trying each
+// source in turn. Standings are missing for contests that are not yet
+// visible, the problemset has neither fresh nor gym contests, and the
+// contest page covers whatever is left.
+type contestProblemResolver struct {
+	sources []problemListSource
+}
+
+func newContestProblemResolver(client *cfapi.Client, parser *cfweb.Parser) *contestProblemResolver {
+	return &contestProblemResolver{sources: []problemListSource{
+		{
+			method: v1.FetchMethodStandings,
+			list: func(ctx context.Context, contestID int) ([]cfapi.Problem, error) {
+				standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
+				if err != nil {
+					return nil, err
+				}
+				return standings.Problems, nil
+			},
+		},
+		{
+			method: v1.FetchMethodProblemset,
+			list: func(ctx context.Context, contestID int) ([]cfapi.Problem, error) {
+				resp, err := client.GetProblems(ctx, nil)
+				if err != nil {
+					return nil, err
+				}
+				var problems []cfapi.Problem
+				for _, p := range resp.Problems {
+					if p.ContestID == contestID {
+						problems = append(problems, p)
+					}
+				}
+				return problems, nil
+			},
+		},
+		{
+			method: v1.FetchMethodWeb,
+			list: func(ctx context.Context, contestID int) ([]cfapi.Problem, error) {
+				parsed, err := parser.ParseContestProblems(contestID)
+				if err != nil {
+					return nil, err
+				}
+				problems := make([]cfapi.Problem, len(parsed))
+				for i, p := range parsed {
+					problems[i] = cfapi.Problem{ContestID: contestID, Index: p.Index, Name: p.Name}
+				}
+				return problems, nil
+			},
+		},
+	}}
+}
+
+// Resolve returns the contest's problems and the method of the first source
+// that listed any. The problemset lists problems newest first, so the
+// result is sorted by index.
+func (r *contestProblemResolver) Resolve(ctx context.Context, contestID int) ([]cfapi.Problem, string, error) {
+	var errs []error
+	for _, src := range r.sources {
+		if err := ctx.Err(); err != nil {
+			return nil, "", err
+		}
+
+		problems, err := src.list(ctx, contestID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", src.method, err))
+			continue
+		}
+		if len(problems) == 0 {
+			errs = append(errs, fmt.Errorf("%s: no problems listed", src.method))
+			continue
+		}
+
+		sort.Slice(problems, func(i, j int) bool {
+			return problems[i].Index < problems[j].Index
+		})
+		return problems, src.method, nil
+	}
+	return nil, "", errors.Join(errs...)
+}
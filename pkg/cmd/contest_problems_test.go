@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func stubSource(method string, problems []cfapi.Problem, err error, calls *[]string) problemListSource {
+	return problemListSource{
+		method: method,
+		list: func(ctx context.Context, contestID int) ([]cfapi.Problem, error) {
+			*calls = append(*calls, method)
+			return problems, err
+		},
+	}
+}
+
+func TestContestProblemResolver_Resolve(t *testing.T) {
+	listed := []cfapi.Problem{{Index: "B"}, {Index: "A"}, {Index: "C"}}
+	unavailable := errors.New("contest.standings: contestId: Contest with id 2000 has not started")
+
+	tests := []struct {
+		name       string
+		standings  []cfapi.Problem
+		standErr   error
+		problemset []cfapi.Problem
+		web        []cfapi.Problem
+		webErr     error
+		wantMethod string
+		wantCalls  string
+		wantErr    string
+	}{
+		{
+			name:       "standings",
+			standings:  listed,
+			wantMethod: v1.FetchMethodStandings,
+			wantCalls:  "standings",
+		},
+		{
+			name:       "problemset when standings fail",
+			standErr:   unavailable,
+			problemset: listed,
+			wantMethod: v1.FetchMethodProblemset,
+			wantCalls:  "standings,problemset",
+		},
+		{
+			name:       "web when both APIs list nothing",
+			standErr:   unavailable,
+			web:        listed,
+			wantMethod: v1.FetchMethodWeb,
+			wantCalls:  "standings,problemset,web",
+		},
+		{
+			name:      "all sources fail",
+			standErr:  unavailable,
+			webErr:    errors.New("contest page returned status 404"),
+			wantCalls: "standings,problemset,web",
+			wantErr:   "problemset: no problems listed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls []string
+			r := &contestProblemResolver{sources: []problemListSource{
+				stubSource(v1.FetchMethodStandings, tt.standings, tt.standErr, &calls),
+				stubSource(v1.FetchMethodProblemset, tt.problemset, nil, &calls),
+				stubSource(v1.FetchMethodWeb, tt.web, tt.webErr, &calls),
+			}}
+
+			problems, method, err := r.Resolve(context.Background(), 2000)
+
+			if got := strings.Join(calls, ","); got != tt.wantCalls {
+				t.Errorf("sources tried = %s, want %s", got, tt.wantCalls)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !errors.Is(err, unavailable) {
+					t.Errorf("Resolve() error = %v, want it to contain %q and wrap the standings error", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if method != tt.wantMethod {
+				t.Errorf("Resolve() method = %s, want %s", method, tt.wantMethod)
+			}
+			var indices []string
+			for _, p := range problems {
+				indices = append(indices, p.Index)
+			}
+			if got := strings.Join(indices, ""); got != "ABC" {
+				t.Errorf("Resolve() indices = %s, want ABC", got)
+			}
+		})
+	}
+}
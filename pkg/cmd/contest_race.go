@@ -0,0 +1,293 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+const (
+	// raceRecheckInterval is how often the start time is re-checked during the countdown
+	raceRecheckInterval = 5 * time.Minute
+	// raceStartPollInterval is how often the problem list is polled once the countdown ends
+	raceStartPollInterval = 2 * time.Second
+	// raceStartTimeout bounds how long we wait for problems after the start time
+	raceStartTimeout = 10 * time.Minute
+)
+
+var (
+	// contest race flags
+	raceWorkers      int
+	raceInterval     time.Duration
+	raceNoStandings  bool
+	raceFetchRateRPS float64
+)
+
+var contestRaceCmd = &cobra.Command{
+	Use:   "race <contest_id>",
+	Short: "Count down, fetch all problems at start, and track standings",
+	Long: `Live contest mode.
+
+Counts down to the contest start, fetches every problem into the workspace the
+moment the contest begins (in parallel, rate limited), creates solutions from
+your templates, then polls the standings for your handle until the contest ends.
+
+Examples:
+  cf contest race 1950                  # Race contest 1950
+  cf contest race 1950 --workers 8      # Fetch with more parallel workers
+  cf contest race 1950 --no-standings   # Only fetch problems`,
+	Args: cobra.ExactArgs(1),
+	RunE: runContestRace,
+}
+
+func init() {
+	contestCmd.AddCommand(contestRaceCmd)
+
+	contestRaceCmd.Flags().IntVar(&raceWorkers, "workers", 4, "Number of problems fetched in parallel")
+	contestRaceCmd.Flags().Float64Var(&raceFetchRateRPS, "rate", 2, "Maximum problem page requests per second")
+	contestRaceCmd.Flags().DurationVar(&raceInterval, "interval", 30*time.Second, "Standings refresh interval")
+	contestRaceCmd.Flags().BoolVar(&raceNoStandings, "no-standings", false, "Do not track standings after fetching")
+}
+
+func runContestRace(cmd *cobra.Command, args []string) error {
+	var contestID int
+	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
+		return fmt.Errorf("invalid contest ID: %s", args[0])
+	}
+
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// Bypass the disk cache: the schedule and phase must be live
+	client := cfapi.NewClient()
+
+	contest, err := client.GetContest(ctx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to get contest: %w", err)
+	}
+
+	fmt.Printf("\n🏁 %s\n", contest.Name)
+	fmt.Printf("Contest #%d | %s | Duration: %s\n", contest.ID, contest.Phase, formatDuration(contest.Duration()))
+	fmt.Println(strings.Repeat("─", 60))
+
+	if contest.Phase == cfapi.PhaseBefore {
+		contest, err = waitForContestStart(ctx, client, contest)
+		if err != nil {
+			return err
+		}
+	}
+
+	standings, err := waitForProblems(ctx, client, contestID)
+	if err != nil {
+		return err
+	}
+
+	parser := cfweb.NewParserWithClient(nil)
+	parser.SetRateLimit(rate.Limit(raceFetchRateRPS), 1)
+
+	fetcher := newProblemFetcher(ws, parser, fetchOptions{
+		Workers: raceWorkers,
+		Retries: 5,
+		Backoff: 500 * time.Millisecond,
+		Method:  v1.FetchMethodStandings,
+	})
+
+	fmt.Printf("\n🚀 Fetching %d problems...\n", len(standings.Problems))
+	printFetchSummary(fetcher.Run(ctx, contestRefs(contestID, standings.Problems), printFetchResult))
+	fmt.Printf("  %s\n", filepath.Dir(ws.ProblemPath("codeforces", contestID, standings.Problems[0].Index)))
+
+	if raceNoStandings {
+		return nil
+	}
+
+	handle := config.GetCFHandle()
+	if handle == "" {
+		fmt.Println("\n⚠️  No CF handle configured; skipping standings. Set with 'cf config set cf_handle <handle>'")
+		return nil
+	}
+
+	return trackStandings(ctx, client, contestID, handle, contestEnd(contest))
+}
+
+// waitForContestStart counts down to the contest start, re-checking the
+// schedule periodically in case the start time moves
+func waitForContestStart(ctx context.Context, client *cfapi.Client, contest *cfapi.Contest) (*cfapi.Contest, error) {
+	lastCheck := time.Now()
+
+	for {
+		remaining := time.Until(contest.StartTime())
+		if remaining <= 0 {
+			fmt.Printf("\r\033[K⏳ Starting...\n")
+			return contest, nil
+		}
+
+		fmt.Printf("\r\033[K⏳ Starts in %s", formatCountdown(remaining))
+
+		if time.Since(lastCheck) >= raceRecheckInterval && remaining > time.Minute {
+			client.ClearCache()
+			if updated, err := client.GetContest(ctx, contest.ID); err == nil {
+				contest = updated
+			}
+			lastCheck = time.Now()
+		}
+
+		wait := remaining % time.Second
+		if wait == 0 {
+			wait = time.Second
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// waitForProblems polls the standings until the problem list is published
+func waitForProblems(ctx context.Context, client *cfapi.Client, contestID int) (*cfapi.ContestStandings, error) {
+	deadline := time.Now().Add(raceStartTimeout)
+
+	for {
+		standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
+		if err == nil && len(standings.Problems) > 0 && standings.Contest.Phase != cfapi.PhaseBefore {
+			return standings, nil
+		}
+
+		if time.Now().After(deadline) {
+			if err != nil {
+				return nil, fmt.Errorf("failed to get contest problems: %w", err)
+			}
+			return nil, fmt.Errorf("contest problems not available after %v", raceStartTimeout)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(raceStartPollInterval):
+		}
+	}
+}
+
+// trackStandings polls standings for handle and redraws a status line until
+// the contest ends
+func trackStandings(ctx context.Context, client *cfapi.Client, contestID int, handle string, end time.Time) error {
+	fmt.Printf("\n📊 Tracking standings for %s (Ctrl+C to stop)\n", handle)
+
+	var last string
+	for {
+		standings, err := client.GetContestStandings(ctx, contestID, 0, 0, []string{handle}, true)
+		switch {
+		case err != nil:
+			last = fmt.Sprintf("⚠️  %v", err)
+		default:
+			last = formatRaceStatus(findStandingsRow(standings.Rows), standings.Problems, time.Until(end))
+			if standings.Contest.Phase != cfapi.PhaseBefore && standings.Contest.Phase != cfapi.PhaseCoding {
+				fmt.Printf("\r\033[K%s\n", last)
+				fmt.Println("✓ Contest over")
+				return nil
+			}
+		}
+		fmt.Printf("\r\033[K%s", last)
+
+		if time.Now().After(end) {
+			fmt.Println()
+			fmt.Println("✓ Contest over")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			fmt.Println()
+			return nil
+		case <-time.After(raceInterval):
+		}
+	}
+}
+
+// findStandingsRow picks the contestant row, falling back to any row
+func findStandingsRow(rows []cfapi.RanklistRow) *cfapi.RanklistRow {
+	for i := range rows {
+		if rows[i].Party.ParticipantType == "CONTESTANT" {
+			return &rows[i]
+		}
+	}
+	if len(rows) > 0 {
+		return &rows[0]
+	}
+	return nil
+}
+
+// formatRaceStatus renders rank, points, penalty and per-problem results.
+// Problems show "+" (solved), "-N" (N rejected attempts) or "." (untried).
+func formatRaceStatus(row *cfapi.RanklistRow, problems []cfapi.Problem, remaining time.Duration) string {
+	left := "ended"
+	if remaining > 0 {
+		left = formatCountdown(remaining) + " left"
+	}
+
+	if row == nil {
+		return fmt.Sprintf("Not in standings yet | %s", left)
+	}
+
+	results := make([]string, 0, len(problems))
+	for i, p := range problems {
+		mark := "."
+		if i < len(row.ProblemResults) {
+			r := row.ProblemResults[i]
+			switch {
+			case r.Points > 0:
+				mark = "\033[32m+\033[0m"
+				if r.RejectedAttemptCount > 0 {
+					mark = fmt.Sprintf("\033[32m+%d\033[0m", r.RejectedAttemptCount)
+				}
+			case r.RejectedAttemptCount > 0:
+				mark = fmt.Sprintf("\033[31m-%d\033[0m", r.RejectedAttemptCount)
+			}
+		}
+		results = append(results, p.Index+":"+mark)
+	}
+
+	return fmt.Sprintf("Rank %d | %g pts | penalty %d | %s | %s",
+		row.Rank, row.Points, row.Penalty, strings.Join(results, " "), left)
+}
+
+// formatCountdown formats a duration as [Nd ]HH:MM:SS
+func formatCountdown(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	d = d.Round(time.Second)
+
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(d.Minutes()) % 60
+	seconds := int(d.Seconds()) % 60
+
+	if days > 0 {
+		return fmt.Sprintf("%dd %02d:%02d:%02d", days, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+}
+
+// contestEnd returns when the contest finishes
+func contestEnd(contest *cfapi.Contest) time.Time {
+	return contest.StartTime().Add(contest.Duration())
+}
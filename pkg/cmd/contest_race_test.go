@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{0, "00:00:00"},
+		{-time.Second, "00:00:00"},
+		{90 * time.Second, "00:01:30"},
+		{2*time.Hour + 3*time.Minute + 4*time.Second, "02:03:04"},
+		{26 * time.Hour, "1d 02:00:00"},
+	}
+
+	for _, tt := range tests {
+		if got := formatCountdown(tt.d); got != tt.want {
+			t.Errorf("formatCountdown(%v) = %q, want %q", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestFindStandingsRow(t *testing.T) {
+	if findStandingsRow(nil) != nil {
+		t.Error("findStandingsRow(nil) should be nil")
+	}
+
+	rows := []cfapi.RanklistRow{
+		{Rank: 0, Party: cfapi.Party{ParticipantType: "PRACTICE"}},
+		{Rank: 42, Party: cfapi.Party{ParticipantType: "CONTESTANT"}},
+	}
+	if row := findStandingsRow(rows); row == nil || row.Rank != 42 {
+		t.Errorf("findStandingsRow() = %+v, want contestant row", row)
+	}
+	if row := findStandingsRow(rows[:1]); row == nil || row.Party.ParticipantType != "PRACTICE" {
+		t.Errorf("findStandingsRow() fallback = %+v", row)
+	}
+}
+
+func TestFormatRaceStatus(t *testing.T) {
+	problems := []cfapi.Problem{{Index: "A"}, {Index: "B"}, {Index: "C"}}
+	row := &cfapi.RanklistRow{
+		Rank:    123,
+		Points:  1500,
+		Penalty: 45,
+		ProblemResults: []cfapi.ProblemResult{
+			{Points: 500},
+			{RejectedAttemptCount: 2},
+			{},
+		},
+	}
+
+	got := formatRaceStatus(row, problems, time.Hour)
+	for _, want := range []string{"Rank 123", "1500 pts", "penalty 45", "A:\033[32m+", "B:\033[31m-2", "C:.", "01:00:00 left"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatRaceStatus() = %q, missing %q", got, want)
+		}
+	}
+
+	if got := formatRaceStatus(nil, problems, 0); !strings.Contains(got, "Not in standings") || !strings.Contains(got, "ended") {
+		t.Errorf("formatRaceStatus(nil) = %q", got)
+	}
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// daily flags
+	dailyHistory bool
+	dailySeed    string
+)
+
+var dailyCmd = &cobra.Command{
+	Use:   "daily",
+	Short: "Today's daily challenge",
+	Long: `Show today's problem of the day and fetch it into the workspace.
+
+The problem is picked deterministically from the date (UTC) and a team seed
+(practice.dailySeed in workspace.yaml, or --seed), within the workspace's
+practice.difficultyMin and difficultyMax. Teammates with the same seed and
+range get the same problem, unless they already solved it: problems you
+solved are skipped. The pick is recorded in stats/daily.yaml.
+
+Solving the daily counts towards your streak in stats/progress.yaml; run
+'cf daily' again after solving to record it.
+
+Examples:
+  cf daily              # Today's challenge
+  cf daily --seed team  # Use a team seed
+  cf daily --history    # Past dailies and whether they were solved`,
+	Args: cobra.NoArgs,
+	RunE: runDaily,
+}
+
+func init() {
+	dailyCmd.Flags().BoolVar(&dailyHistory, "history", false, "List past daily challenges")
+	dailyCmd.Flags().StringVar(&dailySeed, "seed", "", "Team seed (default: practice.dailySeed in workspace.yaml)")
+}
+
+func runDaily(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	log, err := ws.LoadDaily()
+	if err != nil {
+		return err
+	}
+
+	if dailyHistory {
+		printDailyHistory(ws, log)
+		return nil
+	}
+
+	opts := cfapi.DailyOptions{Seed: dailySeed}
+	if m := ws.Manifest(); m != nil {
+		opts.MinRating, opts.MaxRating = m.Practice.DifficultyMin, m.Practice.DifficultyMax
+		if opts.Seed == "" {
+			opts.Seed = m.Practice.DailySeed
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	client := getAPIClient()
+	handle := config.GetCFHandle()
+	date := cfapi.DailyKey(time.Now())
+
+	// Today's pick is kept once made, unless a different seed is asked for
+	entry := log.Find(date)
+	if entry == nil || entry.Seed != opts.Seed {
+		problem, err := client.PickDaily(ctx, date, handle, opts)
+		if err != nil {
+			return fmt.Errorf("failed to pick daily challenge: %w", err)
+		}
+		entry = log.Set(v1.DailyEntry{
+			Date:      date,
+			Seed:      opts.Seed,
+			ContestID: problem.ContestID,
+			Index:     problem.Index,
+			Name:      problem.Name,
+			Rating:    problem.Rating,
+			Tags:      problem.Tags,
+		})
+		if err := ws.SaveDaily(log); err != nil {
+			return err
+		}
+	}
+
+	if !ws.ProblemExists("codeforces", entry.ContestID, entry.Index) {
+		parser := cfweb.NewParserWithClient(nil)
+		parser.SetRateLimit(rate.Limit(2), 1)
+		ref := cfweb.ProblemRef{ContestID: entry.ContestID, Index: entry.Index}
+		var result fetchResult
+		newProblemFetcher(ws, parser, fetchOptions{Workers: 1, Retries: 3, Backoff: time.Second, Method: v1.FetchMethodWeb}).
+			Run(ctx, []cfweb.ProblemRef{ref}, func(r fetchResult) { result = r })
+		if result.Status == fetchFailed {
+			fmt.Printf("⚠️  Could not fetch %s: %v\n", ref, result.Err)
+		}
+	}
+
+	if handle != "" && entry.SolvedAt == nil {
+		if err := recordDailySolve(ctx, ws, client, handle, entry); err != nil {
+			fmt.Printf("⚠️  Could not check submissions: %v\n", err)
+		} else if err := ws.SaveDaily(log); err != nil {
+			return err
+		}
+	}
+
+	fmt.Printf("\n📅 Daily challenge for %s\n", date)
+	fmt.Println(strings.Repeat("═", 60))
+	fmt.Printf("\n%d%s. %s", entry.ContestID, entry.Index, entry.Name)
+	if entry.Rating > 0 {
+		fmt.Printf(" (%d)", entry.Rating)
+	}
+	fmt.Println()
+	if len(entry.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(entry.Tags, ", "))
+	}
+	fmt.Printf("https://codeforces.com/problemset/problem/%d/%s\n", entry.ContestID, entry.Index)
+	if ws.ProblemExists("codeforces", entry.ContestID, entry.Index) {
+		fmt.Printf("%s\n", ws.ProblemPath("codeforces", entry.ContestID, entry.Index))
+	}
+
+	if entry.SolvedAt != nil {
+		fmt.Printf("\n✓ Solved at %s\n", entry.SolvedAt.Local().Format("15:04"))
+	} else {
+		fmt.Printf("\n⏳ Not solved yet\n")
+	}
+
+	if progress, err := ws.LoadProgress(); err == nil {
+		fmt.Printf("🔥 Streak: %d days\n", progress.StreakAt(time.Now()))
+	}
+	fmt.Println()
+	return nil
+}
+
+// recordDailySolve looks for an accepted submission to the daily problem.
+// The problem's submissions are synced into the workspace, which rebuilds
+// progress so the solve counts towards the streak.
+func recordDailySolve(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, handle string, entry *v1.DailyEntry) error {
+	result, err := client.SyncSubmissions(ctx, handle)
+	if err != nil {
+		return err
+	}
+
+	id := fmt.Sprintf("%d%s", entry.ContestID, entry.Index)
+	var records []*v1.Submission
+	for i := range result.Log.Submissions {
+		sub := &result.Log.Submissions[i]
+		if sub.Problem.ProblemID() != id {
+			continue
+		}
+		records = append(records, sub.ToSchemaSubmission())
+		if at := sub.SubmissionTime(); sub.IsAccepted() && (entry.SolvedAt == nil || at.Before(*entry.SolvedAt)) {
+			entry.SolvedAt = &at
+		}
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	if _, err := ws.SyncPractice(records); err != nil {
+		return fmt.Errorf("failed to sync practice: %w", err)
+	}
+	return nil
+}
+
+// printDailyHistory lists past dailies, newest first. A daily counts as
+// solved once the workspace problem is solved, even on a later day.
+func printDailyHistory(ws *workspace.Workspace, log *v1.DailyLog) {
+	if len(log.Entries) == 0 {
+		fmt.Println("No daily challenges yet. Run 'cf daily' to get today's.")
+		return
+	}
+
+	solved := 0
+	lines := make([]string, 0, len(log.Entries))
+	for i := len(log.Entries) - 1; i >= 0; i-- {
+		e := log.Entries[i]
+		mark, when := "✗", ""
+		solvedAt := e.SolvedAt
+		if solvedAt == nil {
+			if p, err := ws.LoadProblem("codeforces", e.ContestID, e.Index); err == nil && p.Practice.Status == v1.StatusSolved {
+				solvedAt = p.Practice.SolvedAt
+				if solvedAt == nil {
+					mark = "✓"
+				}
+			}
+		}
+		if solvedAt != nil {
+			mark = "✓"
+			if day := cfapi.DailyKey(*solvedAt); day != e.Date {
+				when = "solved " + day
+			}
+		}
+		if mark == "✓" {
+			solved++
+		}
+
+		name := e.Name
+		if len(name) > 32 {
+			name = name[:29] + "..."
+		}
+		lines = append(lines, fmt.Sprintf("  %s  %s %-8s %-32s %5d  %s", e.Date, mark, fmt.Sprintf("%d%s", e.ContestID, e.Index), name, e.Rating, when))
+	}
+
+	fmt.Printf("\n📅 Daily challenges: %d/%d solved\n", solved, len(log.Entries))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, line := range lines {
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Println()
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+// fetchOptions configure a problemFetcher
+type fetchOptions struct {
+	Workers int           // problems fetched in parallel
+	Retries int           // extra attempts after a transient error
+	Backoff time.Duration // delay before the first retry, doubled after each
+	Force   bool          // refetch problems already in the workspace
+	Method  string        // source of the problem list, saved as FetchMethod
+}
+
+// fetchStatus is the outcome of fetching one problem
+type fetchStatus int
+
+const (
+	fetchOK fetchStatus = iota
+	fetchSkipped
+	fetchFailed
+)
+
+// fetchResult reports one problem of a fetch run
+type fetchResult struct {
+	Ref      cfweb.ProblemRef
+	Name     string
+	Solution string // solution file created, if any
+	Status   fetchStatus
+	Attempts int
+	Err      error
+}
+
+// fetchSummary totals a fetch run
+type fetchSummary struct {
+	Fetched int
+	Skipped int
+	Failed  int
+	Elapsed time.Duration
+}
+
+// problemFetcher parses problems concurrently and saves them to the
+// workspace, retrying transient errors with exponential backoff. Page
+// requests are paced by the parser's rate limiter, which all workers share.
+type problemFetcher struct {
+	opts   fetchOptions
+	exists func(ref cfweb.ProblemRef) bool
+	parse  func(ref cfweb.ProblemRef) (*cfweb.ParsedProblem, error)
+	save   func(problem *cfweb.ParsedProblem) (string, error)
+}
+
+func newProblemFetcher(ws *workspace.Workspace, parser *cfweb.Parser, opts fetchOptions) *problemFetcher {
+	return &problemFetcher{
+		opts: opts,
+		exists: func(ref cfweb.ProblemRef) bool {
+			return ws.ProblemExists("codeforces", ref.ContestID, ref.Index)
+		},
+		parse: parser.ParseRef,
+		save: func(problem *cfweb.ParsedProblem) (string, error) {
+			return saveToWorkspace(ws, parser, problem, opts.Method)
+		},
+	}
+}
+
+// Run fetches every ref and calls report, from one goroutine at a time, as
+// each problem completes. Problems already in the workspace are skipped
+// unless opts.Force is set.
+func (f *problemFetcher) Run(ctx context.Context, refs []cfweb.ProblemRef, report func(fetchResult)) fetchSummary {
+	start := time.Now()
+	workers := max(f.opts.Workers, 1)
+
+	jobs := make(chan cfweb.ProblemRef)
+	var (
+		mu      sync.Mutex
+		wg      sync.WaitGroup
+		summary fetchSummary
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range jobs {
+				result := f.fetch(ctx, ref)
+
+				mu.Lock()
+				switch result.Status {
+				case fetchOK:
+					summary.Fetched++
+				case fetchSkipped:
+					summary.Skipped++
+				default:
+					summary.Failed++
+				}
+				if report != nil {
+					report(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, ref := range refs {
+		jobs <- ref
+	}
+	close(jobs)
+	wg.Wait()
+
+	summary.Elapsed = time.Since(start)
+	return summary
+}
+
+// fetch parses and saves one problem, retrying transient parse errors
+func (f *problemFetcher) fetch(ctx context.Context, ref cfweb.ProblemRef) fetchResult {
+	result := fetchResult{Ref: ref}
+
+	if !f.opts.Force && f.exists(ref) {
+		result.Status = fetchSkipped
+		return result
+	}
+
+	backoff := f.opts.Backoff
+	for {
+		if err := ctx.Err(); err != nil {
+			result.Status, result.Err = fetchFailed, err
+			return result
+		}
+
+		result.Attempts++
+		problem, err := f.parse(ref)
+		if err == nil {
+			result.Name = problem.Name
+			result.Solution, err = f.save(problem)
+			if err != nil {
+				result.Status, result.Err = fetchFailed, err
+			}
+			return result
+		}
+
+		if !cfweb.IsTransient(err) || result.Attempts > f.opts.Retries {
+			result.Status, result.Err = fetchFailed, err
+			return result
+		}
+
+		select {
+		case <-ctx.Done():
+			result.Status, result.Err = fetchFailed, ctx.Err()
+			return result
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// contestRefs builds references to every problem of a contest
+func contestRefs(contestID int, problems []cfapi.Problem) []cfweb.ProblemRef {
+	refs := make([]cfweb.ProblemRef, 0, len(problems))
+	for _, p := range problems {
+		ref, err := cfweb.ParseProblemRef(strconv.Itoa(contestID), p.Index)
+		if err != nil {
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// printFetchResult prints one line per fetched problem
+func printFetchResult(r fetchResult) {
+	switch r.Status {
+	case fetchOK:
+		retried := ""
+		if r.Attempts > 1 {
+			retried = fmt.Sprintf(" (after %d attempts)", r.Attempts)
+		}
+		fmt.Printf("  ✓ %s. %s%s\n", r.Ref.Index, r.Name, retried)
+	case fetchSkipped:
+		fmt.Printf("  - %s already in workspace\n", r.Ref.Index)
+	default:
+		fmt.Printf("  ✗ %s: %v\n", r.Ref.Index, r.Err)
+	}
+}
+
+// printFetchSummary prints the totals of a fetch run
+func printFetchSummary(s fetchSummary) {
+	fmt.Printf("\n✓ %d fetched, %d skipped, %d failed in %s\n",
+		s.Fetched, s.Skipped, s.Failed, s.Elapsed.Round(10*time.Millisecond))
+	if s.Skipped > 0 {
+		fmt.Println("  Use --force to refetch skipped problems")
+	}
+}
@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+)
+
+// stubFetcher returns a problemFetcher whose parse results are scripted per
+// problem index; each call consumes the next error, then succeeds
+func stubFetcher(opts fetchOptions, existing map[string]bool, errs map[string][]error) (*problemFetcher, map[string]int) {
+	var mu sync.Mutex
+	calls := make(map[string]int)
+
+	return &problemFetcher{
+		opts: opts,
+		exists: func(ref cfweb.ProblemRef) bool {
+			return existing[ref.Index]
+		},
+		parse: func(ref cfweb.ProblemRef) (*cfweb.ParsedProblem, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			n := calls[ref.Index]
+			calls[ref.Index]++
+			if n < len(errs[ref.Index]) {
+				return nil, errs[ref.Index][n]
+			}
+			return &cfweb.ParsedProblem{ContestID: ref.ContestID, Index: ref.Index, Name: "Problem " + ref.Index}, nil
+		},
+		save: func(problem *cfweb.ParsedProblem) (string, error) {
+			return "solution.cpp", nil
+		},
+	}, calls
+}
+
+func TestProblemFetcher_Run(t *testing.T) {
+	unavailable := &cfweb.StatusError{Page: "problem", StatusCode: 503}
+	notFound := &cfweb.StatusError{Page: "problem", StatusCode: 404}
+
+	tests := []struct {
+		name     string
+		opts     fetchOptions
+		existing map[string]bool
+		errs     map[string][]error
+		want     fetchSummary
+		calls    map[string]int
+	}{
+		{
+			name:  "fetches all",
+			opts:  fetchOptions{Workers: 2},
+			want:  fetchSummary{Fetched: 3},
+			calls: map[string]int{"A": 1, "B": 1, "C": 1},
+		},
+		{
+			name:     "skips existing",
+			opts:     fetchOptions{Workers: 2},
+			existing: map[string]bool{"A": true, "C": true},
+			want:     fetchSummary{Fetched: 1, Skipped: 2},
+			calls:    map[string]int{"B": 1},
+		},
+		{
+			name:     "force refetches existing",
+			opts:     fetchOptions{Workers: 2, Force: true},
+			existing: map[string]bool{"A": true, "C": true},
+			want:     fetchSummary{Fetched: 3},
+			calls:    map[string]int{"A": 1, "B": 1, "C": 1},
+		},
+		{
+			name:  "retries transient errors",
+			opts:  fetchOptions{Workers: 1, Retries: 3},
+			errs:  map[string][]error{"B": {unavailable, unavailable}},
+			want:  fetchSummary{Fetched: 3},
+			calls: map[string]int{"A": 1, "B": 3, "C": 1},
+		},
+		{
+			name:  "gives up after retries",
+			opts:  fetchOptions{Workers: 1, Retries: 1},
+			errs:  map[string][]error{"B": {unavailable, unavailable, unavailable}},
+			want:  fetchSummary{Fetched: 2, Failed: 1},
+			calls: map[string]int{"A": 1, "B": 2, "C": 1},
+		},
+		{
+			name:  "does not retry permanent errors",
+			opts:  fetchOptions{Workers: 3, Retries: 3},
+			errs:  map[string][]error{"C": {notFound}},
+			want:  fetchSummary{Fetched: 2, Failed: 1},
+			calls: map[string]int{"A": 1, "B": 1, "C": 1},
+		},
+	}
+
+	problems := []cfapi.Problem{{Index: "A"}, {Index: "B"}, {Index: "C"}}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, calls := stubFetcher(tt.opts, tt.existing, tt.errs)
+
+			var reported int
+			got := f.Run(context.Background(), contestRefs(1325, problems), func(fetchResult) { reported++ })
+
+			if got.Fetched != tt.want.Fetched || got.Skipped != tt.want.Skipped || got.Failed != tt.want.Failed {
+				t.Errorf("Run() = %+v, want %+v", got, tt.want)
+			}
+			if reported != len(problems) {
+				t.Errorf("reported %d results, want %d", reported, len(problems))
+			}
+			for index, want := range tt.calls {
+				if calls[index] != want {
+					t.Errorf("parse(%s) called %d times, want %d", index, calls[index], want)
+				}
+			}
+			if len(calls) != len(tt.calls) {
+				t.Errorf("parse called for %v, want %v", calls, tt.calls)
+			}
+		})
+	}
+}
+
+func TestProblemFetcher_Cancelled(t *testing.T) {
+	f, calls := stubFetcher(fetchOptions{Workers: 2}, nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var results []fetchResult
+	got := f.Run(ctx, contestRefs(1325, []cfapi.Problem{{Index: "A"}, {Index: "B"}}), func(r fetchResult) {
+		results = append(results, r)
+	})
+
+	if got.Failed != 2 || len(calls) != 0 {
+		t.Errorf("Run() = %+v with %d parse calls, want 2 failed and none parsed", got, len(calls))
+	}
+	for _, r := range results {
+		if !errors.Is(r.Err, context.Canceled) {
+			t.Errorf("result %s error = %v, want context.Canceled", r.Ref, r.Err)
+		}
+	}
+}
+
+func TestContestRefs(t *testing.T) {
+	refs := contestRefs(102001, []cfapi.Problem{{Index: "a"}, {Index: ""}, {Index: "B1"}})
+	if len(refs) != 2 {
+		t.Fatalf("contestRefs() = %d refs, want 2", len(refs))
+	}
+	if refs[0].ID() != "102001A" || !refs[0].IsGym() || refs[1].Index != "B1" {
+		t.Errorf("contestRefs() = %+v", refs)
+	}
+}
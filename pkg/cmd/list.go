@@ -0,0 +1,500 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// list flags
+	listTitle       string
+	listDescription string
+	listSection     string
+	listNote        string
+	listLocal       bool
+	listForce       bool
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Manage problem lists and study plans",
+	Long: `Manage problem lists: ordered, sectioned study plans stored as
+lists/<name>.yaml in the workspace.
+
+A list is a single self-contained file. Share it by sending the file; a
+teammate can drop it into their own lists/ directory or run
+'cf list import <file>'.
+
+Without a subcommand, the workspace's lists are shown.
+
+Examples:
+  cf list create graphs --title "Graph basics"
+  cf list add graphs 1325A https://codeforces.com/contest/1324/problem/D --section BFS
+  cf list import problems.txt graphs   # One URL or ID per line
+  cf list show graphs
+  cf list remove graphs 1325A`,
+	Args: cobra.NoArgs,
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListLists,
+}
+
+var listCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty problem list",
+	Args:  cobra.ExactArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListCreate,
+}
+
+var listAddCmd = &cobra.Command{
+	Use:   "add <name> <problem>...",
+	Short: "Add problems to a list",
+	Long: `Add problems to a list, by ID (1325A) or URL. Problems go to the end of
+--section, which is created if needed. Problems already in the list are
+skipped. Names and ratings are filled in from the workspace or the
+Codeforces problemset when available.`,
+	Args: cobra.MinimumNArgs(2),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListAdd,
+}
+
+var listRemoveCmd = &cobra.Command{
+	Use:     "remove <name> <problem>...",
+	Aliases: []string{"rm"},
+	Short:   "Remove problems from a list",
+	Args:    cobra.MinimumNArgs(2),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListRemove,
+}
+
+var listShowCmd = &cobra.Command{
+	Use:   "show <name|file>",
+	Short: "Show a list with each problem's solved status",
+	Long: `Show a list section by section with each problem's status. The status
+comes from the workspace; problems not solved there are checked against your
+accepted submissions on Codeforces, unless --local is given.
+
+A path to a list file can be given instead of a name, to look at a shared
+list before importing it.`,
+	Args: cobra.ExactArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListShow,
+}
+
+var listImportCmd = &cobra.Command{
+	Use:   "import <file> [name]",
+	Short: "Import a list file or a file of problem URLs/IDs",
+	Long: `Import problems into a list.
+
+The file is either a list shared by someone else, which is copied into
+lists/, or a plain text file with one problem URL or ID per line. Blank
+lines and lines starting with # are ignored. Problems from a plain file are
+appended to the list (created if needed) under --section.
+
+The list name defaults to the file name without its extension.
+
+Examples:
+  cf list import graphs.yaml            # A shared list
+  cf list import todo.txt weekly        # URLs/IDs into the "weekly" list
+  cf list import dp.txt dp --section Knapsack`,
+	Args: cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runListImport,
+}
+
+func init() {
+	listCmd.AddCommand(listCreateCmd, listAddCmd, listRemoveCmd, listShowCmd, listImportCmd)
+
+	listCreateCmd.Flags().StringVar(&listTitle, "title", "", "List title")
+	listCreateCmd.Flags().StringVar(&listDescription, "description", "", "List description")
+	for _, c := range []*cobra.Command{listAddCmd, listImportCmd} {
+		c.Flags().StringVarP(&listSection, "section", "s", "", "Section to add the problems to")
+	}
+	listAddCmd.Flags().StringVar(&listNote, "note", "", "Note shown next to the problems")
+	listShowCmd.Flags().BoolVar(&listLocal, "local", false, "Use only the workspace for solved status")
+	listImportCmd.Flags().BoolVarP(&listForce, "force", "f", false, "Replace an existing list with a shared list file")
+}
+
+func runListLists(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	lists, err := ws.ListLists()
+	if err != nil {
+		return err
+	}
+	if len(lists) == 0 {
+		fmt.Println("No problem lists yet. Create one with 'cf list create <name>'.")
+		return nil
+	}
+
+	fmt.Printf("\n📋 Problem lists (%s)\n", ws.ListsPath())
+	fmt.Println(strings.Repeat("─", 60))
+	for _, list := range lists {
+		problems := list.Problems()
+		solved := 0
+		for _, p := range problems {
+			if localStatus(ws, p) == v1.StatusSolved {
+				solved++
+			}
+		}
+		fmt.Printf("  %-20s %3d/%-3d solved  %s\n", list.Name, solved, len(problems), list.Title)
+	}
+	fmt.Println()
+	return nil
+}
+
+func runListCreate(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	name := args[0]
+	if err := workspace.ValidateListName(name); err != nil {
+		return err
+	}
+	if ws.ListExists(name) {
+		return fmt.Errorf("list %s already exists", name)
+	}
+
+	list := v1.NewProblemList(name)
+	list.Title = listTitle
+	list.Description = listDescription
+	list.Author = config.GetCFHandle()
+	if err := ws.SaveList(list); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Created %s\n", ws.ListPath(name))
+	return nil
+}
+
+func runListAdd(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+	list, err := ws.LoadList(args[0])
+	if err != nil {
+		return err
+	}
+
+	var refs []cfweb.ProblemRef
+	for _, arg := range args[1:] {
+		ref, err := cfweb.ParseProblemRef(arg)
+		if err != nil {
+			return err
+		}
+		refs = append(refs, ref)
+	}
+
+	added := addToList(context.Background(), ws, list, listSection, refs, listNote)
+	if err := ws.SaveList(list); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Added %d problem(s) to %s\n", added, list.Name)
+	return nil
+}
+
+func runListRemove(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+	list, err := ws.LoadList(args[0])
+	if err != nil {
+		return err
+	}
+
+	removed := 0
+	for _, arg := range args[1:] {
+		ref, err := cfweb.ParseProblemRef(arg)
+		if err != nil {
+			return err
+		}
+		if !list.Remove(ref.ContestID, ref.Index) {
+			fmt.Printf("⚠️  %s is not in %s\n", ref, list.Name)
+			continue
+		}
+		removed++
+	}
+
+	if removed == 0 {
+		return nil
+	}
+	if err := ws.SaveList(list); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed %d problem(s) from %s\n", removed, list.Name)
+	return nil
+}
+
+func runListShow(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var list *v1.ProblemList
+	if isListFile(args[0]) {
+		list, err = workspace.ReadList(args[0])
+	} else {
+		list, err = ws.LoadList(args[0])
+	}
+	if err != nil {
+		return err
+	}
+
+	problems := list.Problems()
+	status := make(map[string]v1.PracticeStatus, len(problems))
+	needAPI := false
+	for _, p := range problems {
+		status[p.Label()] = localStatus(ws, p)
+		if status[p.Label()] != v1.StatusSolved {
+			needAPI = true
+		}
+	}
+
+	// Problems solved outside the workspace still count
+	if handle := config.GetCFHandle(); needAPI && !listLocal && handle != "" {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		solved, err := getAPIClient().GetSolvedProblems(ctx, handle)
+		if err != nil {
+			fmt.Printf("⚠️  Could not fetch solved problems: %v\n", err)
+		}
+		for _, p := range solved {
+			if _, ok := status[p.ProblemID()]; ok {
+				status[p.ProblemID()] = v1.StatusSolved
+			}
+		}
+	}
+
+	solved := 0
+	for _, s := range status {
+		if s == v1.StatusSolved {
+			solved++
+		}
+	}
+
+	title := list.Name
+	if list.Title != "" {
+		title = list.Title
+	}
+	fmt.Printf("\n📋 %s: %d/%d solved\n", title, solved, len(problems))
+	if list.Description != "" {
+		fmt.Println(list.Description)
+	}
+
+	for _, section := range list.Sections {
+		if section.Name != "" {
+			fmt.Printf("\n%s\n", section.Name)
+		}
+		fmt.Println(strings.Repeat("─", 60))
+		for _, p := range section.Problems {
+			mark := "·"
+			switch status[p.Label()] {
+			case v1.StatusSolved:
+				mark = "✓"
+			case v1.StatusAttempted:
+				mark = "~"
+			}
+			name := p.Name
+			if len(name) > 32 {
+				name = name[:29] + "..."
+			}
+			rating := ""
+			if p.Rating > 0 {
+				rating = fmt.Sprint(p.Rating)
+			}
+			line := fmt.Sprintf(" %s %-8s %-32s %5s  %s", mark, p.Label(), name, rating, p.Note)
+			fmt.Println(strings.TrimRight(line, " "))
+		}
+	}
+	fmt.Println()
+	return nil
+}
+
+func runListImport(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	path := args[0]
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	if len(args) > 1 {
+		name = args[1]
+	}
+	if err := workspace.ValidateListName(name); err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	// A shared list file is installed as is
+	if isListDocument(data) {
+		shared, err := workspace.ReadList(path)
+		if err != nil {
+			return err
+		}
+		if ws.ListExists(name) && !listForce {
+			return fmt.Errorf("list %s already exists (use --force to replace it)", name)
+		}
+		shared.Name = name
+		if err := ws.SaveList(shared); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Imported %s with %d problem(s) to %s\n", name, len(shared.Problems()), ws.ListPath(name))
+		return nil
+	}
+
+	refs, err := parseListFile(strings.NewReader(string(data)))
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	list, err := ws.LoadList(name)
+	if errors.Is(err, workspace.ErrListNotFound) {
+		list = v1.NewProblemList(name)
+		list.Author = config.GetCFHandle()
+	} else if err != nil {
+		return err
+	}
+
+	added := addToList(context.Background(), ws, list, listSection, refs, "")
+	if err := ws.SaveList(list); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Added %d of %d problem(s) to %s\n", added, len(refs), list.Name)
+	return nil
+}
+
+// addToList adds refs to a section of list, filling in names and ratings
+// from the workspace, or else from the problemset when it can be fetched.
+// Returns the number of problems added.
+func addToList(ctx context.Context, ws *workspace.Workspace, list *v1.ProblemList, section string, refs []cfweb.ProblemRef, note string) int {
+	var lookup map[string]v1.ListProblem
+	added := 0
+	for _, ref := range refs {
+		entry := v1.ListProblem{ContestID: ref.ContestID, Index: ref.Index, GroupID: ref.GroupID, Note: note}
+		if problem, err := ws.LoadProblem("codeforces", ref.ContestID, ref.Index); err == nil {
+			entry.Name, entry.Rating = problem.Name, problem.Metadata.Rating
+		} else if !ref.IsGym() && ref.Kind != cfweb.RefGroup {
+			if lookup == nil {
+				lookup = problemsetLookup(ctx)
+			}
+			if p, ok := lookup[ref.ID()]; ok {
+				entry.Name, entry.Rating = p.Name, p.Rating
+			}
+		}
+
+		if !list.Add(section, entry) {
+			fmt.Printf("  %s is already in %s\n", ref, list.Name)
+			continue
+		}
+		added++
+	}
+	return added
+}
+
+// problemsetLookup returns the problemset keyed by problem ID, or an empty
+// map if it cannot be fetched
+func problemsetLookup(ctx context.Context) map[string]v1.ListProblem {
+	lookup := map[string]v1.ListProblem{}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := getAPIClient().GetProblems(ctx, nil)
+	if err != nil {
+		return lookup
+	}
+	for _, p := range resp.Problems {
+		lookup[p.ProblemID()] = v1.ListProblem{Name: p.Name, Rating: p.Rating}
+	}
+	return lookup
+}
+
+// localStatus returns a problem's practice status in the workspace
+func localStatus(ws *workspace.Workspace, p v1.ListProblem) v1.PracticeStatus {
+	problem, err := ws.LoadProblem("codeforces", p.ContestID, p.Index)
+	if err != nil {
+		return v1.StatusUnseen
+	}
+	return problem.Practice.Status
+}
+
+// parseListFile reads problem references, one URL or ID per line. Blank
+// lines and # comments are skipped; every invalid line is reported.
+func parseListFile(r io.Reader) ([]cfweb.ProblemRef, error) {
+	var refs []cfweb.ProblemRef
+	var bad []string
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		ref, err := cfweb.ParseProblemRef(line)
+		if err != nil {
+			bad = append(bad, fmt.Sprintf("line %d: %v", n, err))
+			continue
+		}
+		refs = append(refs, ref)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(bad) > 0 {
+		return nil, errors.New(strings.Join(bad, "; "))
+	}
+	return refs, nil
+}
+
+// isListFile reports whether arg names a list file rather than a list
+func isListFile(arg string) bool {
+	return strings.HasSuffix(arg, ".yaml") || strings.HasSuffix(arg, ".yml") || strings.ContainsRune(arg, filepath.Separator)
+}
+
+// isListDocument reports whether data is a problem list YAML file
+func isListDocument(data []byte) bool {
+	var doc struct {
+		Schema schema.SchemaHeader `yaml:"_schema"`
+	}
+	return yaml.Unmarshal(data, &doc) == nil && doc.Schema.Type == schema.TypeProblemList
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseListFile(t *testing.T) {
+	input := `# Graphs week
+1325A
+https://codeforces.com/contest/1324/problem/D
+
+  codeforces.com/problemset/problem/1000/b
+1325 C
+`
+	refs, err := parseListFile(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseListFile() error = %v", err)
+	}
+
+	var got []string
+	for _, ref := range refs {
+		got = append(got, ref.ID())
+	}
+	if want := "1325A 1324D 1000B 1325C"; strings.Join(got, " ") != want {
+		t.Errorf("parseListFile() = %v, want %s", got, want)
+	}
+
+	_, err = parseListFile(strings.NewReader("1325A\nnope\nhttps://example.com/x\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 2") || !strings.Contains(err.Error(), "line 3") {
+		t.Errorf("parseListFile() error = %v, want lines 2 and 3 reported", err)
+	}
+}
+
+func TestIsListDocument(t *testing.T) {
+	tests := []struct {
+		data string
+		want bool
+	}{
+		{"_schema:\n  version: 1.0.0\n  type: problemList\n", true},
+		{"_schema:\n  version: 1.0.0\n  type: problem\n", false},
+		{"1325A\n1324D\n", false},
+		{"https://codeforces.com/contest/1324/problem/D\n", false},
+	}
+
+	for _, tt := range tests {
+		if got := isListDocument([]byte(tt.data)); got != tt.want {
+			t.Errorf("isListDocument(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/migrate"
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// migrate flags
+	migrateDryRun   bool
+	migrateNoBackup bool
+	migrateTo       string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade workspace files to the current schema version",
+	Long: `Migrate workspace.yaml, problem.yaml, submission, progress, problem list,
+practice session and daily challenge files to the current schema version.
+
+Every file that changes is first copied to .cf-backup/<timestamp>/, then
+replaced atomically. workspace.yaml is written last, so an interrupted run can
+simply be repeated.
+
+Examples:
+  cf migrate             # Migrate to the current version
+  cf migrate --dry-run   # Show what would change`,
+	Args: cobra.NoArgs,
+	RunE: runMigrate,
+}
+
+func init() {
+	migrateCmd.Flags().BoolVar(&migrateDryRun, "dry-run", false, "Show the migration plan without writing")
+	migrateCmd.Flags().BoolVar(&migrateNoBackup, "no-backup", false, "Do not back up files before rewriting them")
+	migrateCmd.Flags().StringVar(&migrateTo, "to", "", "Target schema version (default: current)")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) error {
+	// The workspace may not pass validation before it is migrated, so it is
+	// opened directly rather than through getWorkspace
+	wsPath := "."
+	if cfg := config.Get(); cfg != nil && cfg.WorkspacePath != "" {
+		wsPath = cfg.WorkspacePath
+	}
+	ws := workspace.New(wsPath)
+
+	opts := migrate.Options{
+		DryRun:   migrateDryRun,
+		NoBackup: migrateNoBackup,
+	}
+	if migrateTo != "" {
+		target, err := schema.ParseVersion(migrateTo)
+		if err != nil {
+			return err
+		}
+		opts.Target = target
+	}
+
+	result, err := migrate.Migrate(ws, opts)
+	if err != nil {
+		return fmt.Errorf("failed to migrate: %w", err)
+	}
+
+	plan := result.Plan
+	for _, path := range plan.Skipped {
+		fmt.Printf("⚠️  Skipped %s (no schema header)\n", path)
+	}
+
+	if len(plan.Changes) == 0 {
+		fmt.Printf("✓ Workspace is up to date (schema %s)\n", plan.Target)
+		return nil
+	}
+
+	fmt.Printf("\nMigration to schema %s:\n", plan.Target)
+	fmt.Println(strings.Repeat("─", 60))
+	for _, change := range plan.Changes {
+		fmt.Printf("  %-40s %s → %s\n", change.Path, change.From, change.To)
+	}
+	fmt.Println(strings.Repeat("─", 60))
+
+	steps := make(map[string]string)
+	for _, change := range plan.Changes {
+		for _, step := range change.Steps {
+			steps[step.From.String()+" → "+step.To.String()] = step.Description
+		}
+	}
+	names := make([]string, 0, len(steps))
+	for name := range steps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if steps[name] != "" {
+			fmt.Printf("  %s: %s\n", name, steps[name])
+		}
+	}
+
+	if migrateDryRun {
+		fmt.Printf("\nDry run: %d file(s) would be migrated\n", len(plan.Changes))
+		return nil
+	}
+
+	fmt.Printf("\n✓ Migrated %d file(s)\n", result.Written)
+	if result.BackupDir != "" {
+		fmt.Printf("  Backup: %s\n", result.BackupDir)
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+var (
+	// practice next flags
+	practiceCount  int
+	practiceRating string
+	practiceTags   []string
+)
+
+var practiceCmd = &cobra.Command{
+	Use:   "practice",
+	Short: "Targeted practice",
+	Long: `Commands for planning practice around your weaknesses.
+
+Examples:
+  cf practice next`,
+}
+
+var practiceNextCmd = &cobra.Command{
+	Use:   "next [handle]",
+	Short: "Recommend problems to solve next",
+	Long: `Recommend unsolved problems that target your weaknesses.
+
+A weakness profile is built from your submissions and rating history: tags
+where your acceptance rate is below your overall rate, and a rating band of
+your current rating +100 to +300. Unsolved problems in the band are ranked by
+weak tags, the workspace's preferred tags (codeforces.preferredTags in
+workspace.yaml), how many people solved them, and closeness to the middle of
+the band. Problems you attempted but never solved get a boost.
+
+Examples:
+  cf practice next                    # Top 5 picks
+  cf practice next -n 10              # Top 10 picks
+  cf practice next --rating 1400-1600 # Override the rating band
+  cf practice next --tag dp           # Only dp problems`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runPracticeNext,
+}
+
+func init() {
+	practiceCmd.AddCommand(practiceNextCmd)
+
+	practiceNextCmd.Flags().IntVarP(&practiceCount, "count", "n", cfapi.DefaultRecommendCount, "Number of problems to recommend")
+	practiceNextCmd.Flags().StringVar(&practiceRating, "rating", "", "Rating band, e.g. 1400-1600 (default: your rating +100..+300)")
+	practiceNextCmd.Flags().StringArrayVar(&practiceTags, "tag", nil, "Only recommend problems with this tag (can be specified multiple times)")
+}
+
+func runPracticeNext(cmd *cobra.Command, args []string) error {
+	handle, err := getHandle(args)
+	if err != nil {
+		return err
+	}
+
+	opts := cfapi.RecommendOptions{Count: practiceCount, Tags: practiceTags}
+	if practiceRating != "" {
+		opts.MinRating, opts.MaxRating, err = parseRatingRange(practiceRating)
+		if err != nil {
+			return err
+		}
+	}
+	// Preferred tags are optional, so a missing workspace is not an error
+	if ws, err := getWorkspace(); err == nil && ws.Manifest() != nil {
+		opts.PreferredTags = ws.Manifest().Codeforces.PreferredTags
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	profile, picks, err := getAPIClient().Recommend(ctx, handle, opts)
+	if err != nil {
+		return fmt.Errorf("failed to build recommendations: %w", err)
+	}
+
+	minRating, maxRating := profile.MinRating, profile.MaxRating
+	if opts.MinRating > 0 {
+		minRating, maxRating = opts.MinRating, opts.MaxRating
+	}
+	rating := fmt.Sprintf("rating %d", profile.Rating)
+	if !profile.Rated {
+		rating = fmt.Sprintf("unrated, solving around %d", profile.Rating)
+	}
+
+	fmt.Printf("\n🎯 Practice picks for %s (%s, target %d-%d)\n", handle, rating, minRating, maxRating)
+	fmt.Println(strings.Repeat("═", 60))
+
+	if len(profile.WeakTags) > 0 {
+		weak := make([]string, len(profile.WeakTags))
+		for i, tag := range profile.WeakTags {
+			weak[i] = fmt.Sprintf("%s (%.0f%%)", tag, profile.Tags[tag].AcceptanceRate()*100)
+		}
+		fmt.Printf("Weak tags: %s — overall %.0f%% accepted\n", strings.Join(weak, ", "), profile.Acceptance*100)
+	} else {
+		fmt.Println("Weak tags: not enough submissions yet")
+	}
+
+	if len(picks) == 0 {
+		fmt.Println("\nNo unsolved problems match. Try a wider --rating band or fewer --tag filters.")
+		return nil
+	}
+
+	fmt.Println()
+	for i, pick := range picks {
+		p := pick.Problem
+		name := p.Name
+		if len(name) > 40 {
+			name = name[:37] + "..."
+		}
+		fmt.Printf("%2d. %-8s %-40s %5d\n", i+1, p.ProblemID(), name, p.Rating)
+		fmt.Printf("    %s\n", pick.Reason)
+		fmt.Printf("    %s\n", p.URL())
+	}
+
+	fmt.Printf("\nFetch one with: cf problem fetch %s\n\n", picks[0].Problem.ProblemID())
+	return nil
+}
+
+// parseRatingRange parses a rating band such as "1400-1800" or a single
+// rating such as "1500"
+func parseRatingRange(s string) (int, int, error) {
+	lo, hi, found := strings.Cut(s, "-")
+	minRating, err := strconv.Atoi(strings.TrimSpace(lo))
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid rating range %q: use e.g. 1400-1800", s)
+	}
+	if !found {
+		return minRating, minRating, nil
+	}
+	maxRating, err := strconv.Atoi(strings.TrimSpace(hi))
+	if err != nil || maxRating < minRating {
+		return 0, 0, fmt.Errorf("invalid rating range %q: use e.g. 1400-1800", s)
+	}
+	return minRating, maxRating, nil
+}
@@ -0,0 +1,424 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+// sessionPollInterval is how often a watched session checks for accepted submissions
+const sessionPollInterval = 30 * time.Second
+
+var (
+	// practice start flags
+	sessionCount    int
+	sessionRating   string
+	sessionDuration time.Duration
+	sessionTags     []string
+	sessionDetach   bool
+
+	// practice status flags
+	sessionWatch bool
+)
+
+var practiceStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a timed practice session",
+	Long: `Start a timed practice session, like a virtual contest.
+
+Problems are picked by the recommender (see 'cf practice next') within the
+rating band, fetched into the workspace, and the session timer starts. The
+session is saved in stats/session.yaml, so it keeps running after cf exits:
+'cf practice status' and 'cf practice stop' work from any terminal.
+
+While watching, accepted submissions are detected by polling Codeforces every
+30 seconds. The session finishes when every problem is solved or time runs
+out. Time is split between problems in solve order (the time since the
+previous solve), with the rest shared by unsolved problems, and added to each
+problem's practice.timeSpent. A summary is written to stats/sessions/.
+
+Examples:
+  cf practice start                                       # 4 problems, 2 hours
+  cf practice start --count 4 --rating 1400-1800 --duration 2h
+  cf practice start --tag graphs --duration 90m
+  cf practice start --detach                              # Start and return`,
+	Args: cobra.NoArgs,
+	RunE: runPracticeStart,
+}
+
+var practiceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the active practice session",
+	Long: `Show the active practice session: time left and solved problems.
+
+New accepted submissions are picked up first. A session whose time ran out,
+or whose problems are all solved, is finished and summarized.
+
+Examples:
+  cf practice status
+  cf practice status --watch   # Keep watching until the session ends`,
+	Args: cobra.NoArgs,
+	RunE: runPracticeStatus,
+}
+
+var practiceStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "End the active practice session",
+	Long: `End the active practice session early and write its summary to
+stats/sessions/.
+
+Examples:
+  cf practice stop`,
+	Args: cobra.NoArgs,
+	RunE: runPracticeStop,
+}
+
+func init() {
+	practiceCmd.AddCommand(practiceStartCmd)
+	practiceCmd.AddCommand(practiceStatusCmd)
+	practiceCmd.AddCommand(practiceStopCmd)
+
+	practiceStartCmd.Flags().IntVar(&sessionCount, "count", 4, "Number of problems")
+	practiceStartCmd.Flags().StringVar(&sessionRating, "rating", "", "Rating band, e.g. 1400-1800 (default: workspace difficulty range)")
+	practiceStartCmd.Flags().DurationVar(&sessionDuration, "duration", 2*time.Hour, "Session length")
+	practiceStartCmd.Flags().StringArrayVar(&sessionTags, "tag", nil, "Only pick problems with this tag (can be specified multiple times)")
+	practiceStartCmd.Flags().BoolVar(&sessionDetach, "detach", false, "Start the session without watching it")
+
+	practiceStatusCmd.Flags().BoolVar(&sessionWatch, "watch", false, "Keep watching until the session ends")
+}
+
+func runPracticeStart(cmd *cobra.Command, args []string) error {
+	if sessionDuration <= 0 {
+		return fmt.Errorf("--duration must be positive")
+	}
+
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+	handle := config.GetCFHandle()
+	if handle == "" {
+		return fmt.Errorf("no CF handle configured. Set with 'cf config set cf_handle <handle>'")
+	}
+
+	if active, err := ws.LoadSession(); err == nil {
+		return fmt.Errorf("a practice session is already active (%s left); see 'cf practice status' or end it with 'cf practice stop'",
+			formatCountdown(active.Remaining(time.Now())))
+	}
+
+	opts := cfapi.RecommendOptions{Count: sessionCount, Tags: sessionTags}
+	if m := ws.Manifest(); m != nil {
+		opts.MinRating, opts.MaxRating = m.Practice.DifficultyMin, m.Practice.DifficultyMax
+		opts.PreferredTags = m.Codeforces.PreferredTags
+	}
+	if sessionRating != "" {
+		if opts.MinRating, opts.MaxRating, err = parseRatingRange(sessionRating); err != nil {
+			return err
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client := getAPIClient()
+
+	pickCtx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	profile, picks, err := client.Recommend(pickCtx, handle, opts)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to pick problems: %w", err)
+	}
+	if len(picks) == 0 {
+		return fmt.Errorf("no unsolved problems match; try a wider --rating band or fewer --tag filters")
+	}
+
+	refs := make([]cfweb.ProblemRef, len(picks))
+	for i, pick := range picks {
+		refs[i] = cfweb.ProblemRef{ContestID: pick.Problem.ContestID, Index: pick.Problem.Index}
+	}
+
+	parser := cfweb.NewParserWithClient(nil)
+	parser.SetRateLimit(rate.Limit(2), 1)
+	fetcher := newProblemFetcher(ws, parser, fetchOptions{
+		Workers: 4,
+		Retries: 3,
+		Backoff: time.Second,
+		Method:  v1.FetchMethodWeb,
+	})
+
+	fmt.Printf("Fetching %d problems...\n", len(refs))
+	summary := fetcher.Run(ctx, refs, printFetchResult)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	if summary.Failed > 0 {
+		fmt.Println("  Problems that failed to fetch stay in the session; open them on Codeforces")
+	}
+
+	// The clock starts once the problems are in the workspace
+	session := v1.NewSession(time.Now(), sessionDuration)
+	session.MinRating, session.MaxRating = opts.MinRating, opts.MaxRating
+	if session.MinRating == 0 {
+		session.MinRating, session.MaxRating = profile.MinRating, profile.MaxRating
+	}
+	session.Tags = sessionTags
+	for _, pick := range picks {
+		session.Problems = append(session.Problems, v1.SessionProblem{
+			ContestID: pick.Problem.ContestID,
+			Index:     pick.Problem.Index,
+			Name:      pick.Problem.Name,
+			Rating:    pick.Problem.Rating,
+		})
+	}
+
+	if err := ws.StartSession(session); err != nil {
+		if errors.Is(err, workspace.ErrSessionActive) {
+			return fmt.Errorf("%w; see 'cf practice status'", err)
+		}
+		return fmt.Errorf("failed to start session: %w", err)
+	}
+
+	fmt.Printf("\n🏁 Practice session started: %d problems, %s\n", len(session.Problems), formatDuration(sessionDuration))
+	printSession(ws, session, time.Now())
+
+	if sessionDetach {
+		fmt.Println("\nCheck in with 'cf practice status'; end early with 'cf practice stop'.")
+		return nil
+	}
+	return watchSession(ctx, ws, client, handle, session)
+}
+
+func runPracticeStatus(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	session, err := ws.LoadSession()
+	if errors.Is(err, workspace.ErrNoSession) {
+		fmt.Println("No active practice session. Start one with 'cf practice start'.")
+		if sessions, _ := ws.ListSessions(); len(sessions) > 0 {
+			last := sessions[len(sessions)-1]
+			fmt.Printf("Last session: %s, %d/%d solved in %s (%s)\n", last.StartedAt.Local().Format("Mon Jan 02 15:04"),
+				last.Solved(), len(last.Problems), formatDuration(last.Elapsed(time.Now())), last.Status)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	client := getAPIClient()
+	handle := config.GetCFHandle()
+
+	if _, err := syncSession(ctx, ws, client, handle, session); err != nil {
+		fmt.Printf("⚠️  Could not check submissions: %v\n", err)
+	}
+
+	now := time.Now()
+	if session.Expired(now) || session.Solved() == len(session.Problems) {
+		return finishSession(ctx, ws, client, handle, session)
+	}
+
+	printSession(ws, session, now)
+	if sessionWatch {
+		return watchSession(ctx, ws, client, handle, session)
+	}
+	return nil
+}
+
+func runPracticeStop(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	session, err := ws.LoadSession()
+	if errors.Is(err, workspace.ErrNoSession) {
+		return fmt.Errorf("no active practice session")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	return finishSession(ctx, ws, getAPIClient(), config.GetCFHandle(), session)
+}
+
+// watchSession redraws the session clock every second and polls for accepted
+// submissions until the session ends. Ctrl+C stops watching but leaves the
+// session running.
+func watchSession(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, handle string, session *v1.Session) error {
+	fmt.Println("\n⏱  Watching for accepted submissions (Ctrl+C to detach; the session keeps running)")
+
+	lastPoll := time.Now()
+	for {
+		now := time.Now()
+		if session.Expired(now) || session.Solved() == len(session.Problems) {
+			fmt.Println()
+			return finishSession(context.WithoutCancel(ctx), ws, client, handle, session)
+		}
+
+		if now.Sub(lastPoll) >= sessionPollInterval {
+			lastPoll = now
+			// Pick up changes from other terminals before polling
+			active, err := ws.LoadSession()
+			if errors.Is(err, workspace.ErrNoSession) || (err == nil && active.ID != session.ID) {
+				fmt.Printf("\r\033[K✓ Session ended from another terminal\n")
+				return nil
+			}
+			if err == nil {
+				session = active
+			}
+
+			before := session.Solved()
+			if _, err := syncSession(ctx, ws, client, handle, session); err != nil && ctx.Err() == nil {
+				fmt.Printf("\r\033[K⚠️  %v\n", err)
+			}
+			if session.Solved() > before {
+				fmt.Printf("\r\033[K🎉 Solved %d/%d\n", session.Solved(), len(session.Problems))
+			}
+		}
+
+		fmt.Printf("\r\033[K⏱  %s left | %d/%d solved", formatCountdown(session.Remaining(now)), session.Solved(), len(session.Problems))
+
+		select {
+		case <-ctx.Done():
+			fmt.Printf("\n\nDetached. Check in with 'cf practice status'; end early with 'cf practice stop'.\n")
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// syncSession records accepted submissions made during the session, saving
+// it if anything changed, and returns the user's submission history
+func syncSession(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, handle string, session *v1.Session) ([]cfapi.Submission, error) {
+	if handle == "" {
+		return nil, fmt.Errorf("no CF handle configured")
+	}
+
+	result, err := client.SyncSubmissions(ctx, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	for _, sub := range result.Log.Submissions {
+		if sub.IsAccepted() && session.RecordSolve(sub.Problem.ContestID, sub.Problem.Index, sub.ID, sub.SubmissionTime()) {
+			changed = true
+		}
+	}
+	if changed {
+		if err := ws.SaveSession(session); err != nil {
+			return nil, err
+		}
+	}
+	return result.Log.Submissions, nil
+}
+
+// finishSession picks up the last solves, ends the session and prints its
+// summary. The session counts as finished if time ran out or everything was
+// solved, and as stopped otherwise. The session's submissions are recorded in
+// the workspace so practice status is up to date.
+func finishSession(ctx context.Context, ws *workspace.Workspace, client *cfapi.Client, handle string, session *v1.Session) error {
+	subs, err := syncSession(ctx, ws, client, handle, session)
+	if errors.Is(err, workspace.ErrNoSession) {
+		return fmt.Errorf("the session was already ended from another terminal")
+	}
+	if err != nil {
+		fmt.Printf("⚠️  Could not check submissions: %v\n", err)
+	}
+
+	inSession := make(map[string]bool, len(session.Problems))
+	for _, p := range session.Problems {
+		inSession[p.Label()] = true
+	}
+	var records []*v1.Submission
+	for i := range subs {
+		if inSession[subs[i].Problem.ProblemID()] {
+			records = append(records, subs[i].ToSchemaSubmission())
+		}
+	}
+	if len(records) > 0 {
+		if _, err := ws.SyncPractice(records); err != nil {
+			return fmt.Errorf("failed to sync practice: %w", err)
+		}
+	}
+
+	now := time.Now()
+	status := v1.SessionStopped
+	if session.Expired(now) || session.Solved() == len(session.Problems) {
+		status = v1.SessionFinished
+	}
+
+	path, err := ws.EndSession(session, status, now)
+	if errors.Is(err, workspace.ErrNoSession) {
+		return fmt.Errorf("the session was already ended from another terminal")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to end session: %w", err)
+	}
+
+	title := "⏰ Time's up"
+	switch {
+	case session.Solved() == len(session.Problems):
+		title = "🏆 All problems solved"
+	case status == v1.SessionStopped:
+		title = "⏹  Session stopped"
+	}
+	fmt.Printf("%s: %d/%d solved in %s\n", title, session.Solved(), len(session.Problems), formatDuration(session.Elapsed(now)))
+	printSession(ws, session, now)
+	fmt.Printf("\n✓ Summary saved to %s\n", path)
+	return nil
+}
+
+// printSession lists the session's problems with their solve times, or time
+// spent once the session has ended
+func printSession(ws *workspace.Workspace, session *v1.Session, now time.Time) {
+	if session.Status == v1.SessionActive {
+		fmt.Printf("\n⏱  %s left of %s | %d/%d solved | rating %d-%d\n",
+			formatCountdown(session.Remaining(now)), formatDuration(time.Duration(session.Duration)*time.Second),
+			session.Solved(), len(session.Problems), session.MinRating, session.MaxRating)
+	}
+	fmt.Println(strings.Repeat("─", 60))
+
+	for _, p := range session.Problems {
+		mark := "·"
+		detail := ""
+		if p.SolvedAt != nil {
+			mark = "✓"
+			detail = "solved at " + formatCountdown(p.SolvedAt.Sub(session.StartedAt))
+		}
+		if session.Status != v1.SessionActive {
+			detail = strings.TrimSpace(detail + "  " + formatCountdown(time.Duration(p.TimeSpent)*time.Second) + " spent")
+		}
+
+		name := p.Name
+		if len(name) > 32 {
+			name = name[:29] + "..."
+		}
+		fmt.Printf(" %s %-8s %-32s %5d  %s\n", mark, p.Label(), name, p.Rating, detail)
+		if session.Status == v1.SessionActive {
+			fmt.Printf("   %s\n", ws.ProblemPath("codeforces", p.ContestID, p.Index))
+		}
+	}
+}
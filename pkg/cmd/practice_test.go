@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestParseRatingRange(t *testing.T) {
+	tests := []struct {
+		in       string
+		min, max int
+		wantErr  bool
+	}{
+		{"1400-1800", 1400, 1800, false},
+		{"1500", 1500, 1500, false},
+		{" 1200 - 1300 ", 1200, 1300, false},
+		{"1800-1400", 0, 0, true},
+		{"hard", 0, 0, true},
+		{"1400-", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		min, max, err := parseRatingRange(tt.in)
+		if (err != nil) != tt.wantErr || min != tt.min || max != tt.max {
+			t.Errorf("parseRatingRange(%q) = %d, %d, %v; want %d, %d, err %v",
+				tt.in, min, max, err, tt.min, tt.max, tt.wantErr)
+		}
+	}
+}
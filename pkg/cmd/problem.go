@@ -3,13 +3,18 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
 
 	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 )
 
@@ -20,6 +25,15 @@ var (
 	problemMaxRating int
 	problemLimit     int
 	excludeSolved    bool
+
+	// problem parse/fetch flags
+	problemTemplate string
+
+	// problem fetch flags
+	fetchWorkers int
+	fetchRate    float64
+	fetchRetries int
+	fetchForce   bool
 )
 
 var problemCmd = &cobra.Command{
@@ -32,16 +46,19 @@ Parse problems from contests, list available problems, and fetch them to your wo
 }
 
 var problemParseCmd = &cobra.Command{
-	Use:   "parse <contest_id> <problem_index>",
+	Use:   "parse <problem>",
 	Short: "Parse a problem from Codeforces",
 	Long: `Parse a problem from Codeforces and display its details.
 
-The problem will be saved to your workspace if one is configured.
+The problem may be given as 1234B, 1234 B or a problem URL from a contest,
+the problemset, the gym or a group. The problem will be saved to your
+workspace if one is configured.
 
 Examples:
   cf problem parse 1 A       # Parse problem A from contest 1
-  cf problem parse 1234 B    # Parse problem B from contest 1234`,
-	Args: cobra.ExactArgs(2),
+  cf problem parse 1234B     # Parse problem B from contest 1234
+  cf problem parse https://codeforces.com/gym/102001/problem/C`,
+	Args: cobra.RangeArgs(1, 2),
 	RunE: runProblemParse,
 }
 
@@ -61,16 +78,20 @@ Examples:
 }
 
 var problemFetchCmd = &cobra.Command{
-	Use:   "fetch <contest_id> [problem_index]",
+	Use:   "fetch <contest_id> | <problem>",
 	Short: "Fetch problem(s) to workspace",
 	Long: `Fetch a problem or all problems from a contest to your workspace.
 
-If problem_index is provided, fetches only that problem.
-Otherwise, fetches all problems from the contest.
+A problem may be given as 1234A, 1234 A or a problem URL; a bare contest ID
+fetches every problem of the contest. Each problem gets a problem.yaml, a
+statement.md with the full statement and a solution file. Gym problems are
+stored under problems/codeforces/gym/<id>/<index>.
 
 Examples:
   cf problem fetch 1 A      # Fetch problem A from contest 1
-  cf problem fetch 1234     # Fetch all problems from contest 1234`,
+  cf problem fetch 1234     # Fetch all problems from contest 1234
+  cf problem fetch 102001   # Fetch all problems from gym 102001
+  cf problem fetch https://codeforces.com/problemset/problem/1325/A`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runProblemFetch,
 }
@@ -87,17 +108,27 @@ func init() {
 	problemListCmd.Flags().IntVar(&problemMaxRating, "max-rating", 0, "Maximum problem rating")
 	problemListCmd.Flags().IntVar(&problemLimit, "limit", 25, "Maximum number of problems to display")
 	problemListCmd.Flags().BoolVar(&excludeSolved, "unsolved", false, "Exclude already solved problems")
+
+	// problem fetch flags
+	problemFetchCmd.Flags().IntVar(&fetchWorkers, "workers", 4, "Number of problems fetched in parallel")
+	problemFetchCmd.Flags().Float64Var(&fetchRate, "rate", 2, "Maximum problem page requests per second")
+	problemFetchCmd.Flags().IntVar(&fetchRetries, "retries", 3, "Retries for rate-limited or failed requests")
+	problemFetchCmd.Flags().BoolVar(&fetchForce, "force", false, "Refetch problems already in the workspace")
+
+	// problem parse/fetch flags
+	for _, c := range []*cobra.Command{problemParseCmd, problemFetchCmd} {
+		c.Flags().StringVar(&problemTemplate, "template", "", "Solution template name (default: \"default\")")
+	}
 }
 
 func runProblemParse(cmd *cobra.Command, args []string) error {
-	var contestID int
-	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
-		return fmt.Errorf("invalid contest ID: %s", args[0])
+	ref, err := cfweb.ParseProblemRef(args...)
+	if err != nil {
+		return err
 	}
-	problemIndex := strings.ToUpper(args[1])
 
 	parser := cfweb.NewParserWithClient(nil)
-	problem, err := parser.ParseProblem(contestID, problemIndex)
+	problem, err := parser.ParseRef(ref)
 	if err != nil {
 		return fmt.Errorf("failed to parse problem: %w", err)
 	}
@@ -109,15 +140,14 @@ func runProblemParse(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Samples: %d\n", len(problem.Samples))
 
 	// Save to workspace if available
-	cfg := config.Get()
-	if cfg != nil && cfg.WorkspacePath != "" {
-		ws := workspace.New(cfg.WorkspacePath)
-		if ws.Exists() {
-			schemaProblem := problem.ToSchemaProblem()
-			if err := ws.SaveProblem(schemaProblem); err != nil {
-				return fmt.Errorf("failed to save problem: %w", err)
-			}
-			fmt.Printf("✓ Saved to workspace\n")
+	if ws, err := getWorkspace(); err == nil {
+		solution, err := saveToWorkspace(ws, parser, problem, v1.FetchMethodWeb)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("✓ Saved to workspace\n")
+		if solution != "" {
+			fmt.Printf("✓ Created %s\n", solution)
 		}
 	}
 
@@ -182,68 +212,119 @@ func runProblemList(cmd *cobra.Command, args []string) error {
 }
 
 func runProblemFetch(cmd *cobra.Command, args []string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
-	defer cancel()
-
-	var contestID int
-	if _, err := fmt.Sscanf(args[0], "%d", &contestID); err != nil {
-		return fmt.Errorf("invalid contest ID: %s", args[0])
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// A single numeric argument is a contest; anything else names a problem
+	contestID, contestErr := strconv.Atoi(args[0])
+	var ref cfweb.ProblemRef
+	if len(args) == 2 || contestErr != nil {
+		var err error
+		if ref, err = cfweb.ParseProblemRef(args...); err != nil {
+			return err
+		}
+		contestID = ref.ContestID
 	}
 
 	// Check workspace
-	cfg := config.Get()
-	if cfg == nil || cfg.WorkspacePath == "" {
-		return fmt.Errorf("no workspace configured. Run 'cf init' first")
-	}
-	ws := workspace.New(cfg.WorkspacePath)
-	if !ws.Exists() {
-		return fmt.Errorf("workspace not found at %s. Run 'cf init' first", cfg.WorkspacePath)
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
 	}
 
 	parser := cfweb.NewParserWithClient(nil)
+	parser.SetRateLimit(rate.Limit(fetchRate), 1)
+
+	opts := fetchOptions{
+		Workers: fetchWorkers,
+		Retries: fetchRetries,
+		Backoff: time.Second,
+		Force:   fetchForce,
+		Method:  v1.FetchMethodWeb,
+	}
 
-	if len(args) == 2 {
+	if ref.Index != "" {
 		// Fetch single problem
-		problemIndex := strings.ToUpper(args[1])
-		problem, err := parser.ParseProblem(contestID, problemIndex)
-		if err != nil {
-			return fmt.Errorf("failed to parse problem: %w", err)
+		var result fetchResult
+		newProblemFetcher(ws, parser, opts).Run(ctx, []cfweb.ProblemRef{ref}, func(r fetchResult) { result = r })
+
+		switch result.Status {
+		case fetchSkipped:
+			fmt.Printf("✓ %s is already in the workspace (use --force to refetch)\n", ref)
+		case fetchFailed:
+			return fmt.Errorf("failed to fetch %s: %w", ref, result.Err)
+		default:
+			fmt.Printf("✓ Fetched %s. %s to workspace\n", result.Ref.Index, result.Name)
+			if result.Solution != "" {
+				fmt.Printf("✓ Created %s\n", result.Solution)
+			}
 		}
+		return nil
+	}
 
-		schemaProblem := problem.ToSchemaProblem()
-		if err := ws.SaveProblem(schemaProblem); err != nil {
-			return fmt.Errorf("failed to save problem: %w", err)
-		}
+	// Fetch all problems from contest
+	listCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-		fmt.Printf("✓ Fetched %s. %s to workspace\n", problem.Index, problem.Name)
-	} else {
-		// Fetch all problems from contest
-		client := getAPIClient()
-		standings, err := client.GetContestStandings(ctx, contestID, 1, 1, nil, false)
-		if err != nil {
-			return fmt.Errorf("failed to get contest problems: %w", err)
-		}
+	resolver := newContestProblemResolver(getAPIClient(), parser)
+	problems, method, err := resolver.Resolve(listCtx, contestID)
+	if err != nil {
+		return fmt.Errorf("failed to get contest problems: %w", err)
+	}
 
-		fmt.Printf("Fetching %d problems from contest %d...\n", len(standings.Problems), contestID)
+	fmt.Printf("Fetching %d problems from contest %d (problem list from %s)...\n", len(problems), contestID, method)
 
-		for _, p := range standings.Problems {
-			problem, err := parser.ParseProblem(contestID, p.Index)
-			if err != nil {
-				fmt.Printf("  ✗ Failed to fetch %s: %v\n", p.Index, err)
-				continue
-			}
+	opts.Method = method
+	summary := newProblemFetcher(ws, parser, opts).Run(ctx, contestRefs(contestID, problems), printFetchResult)
+	printFetchSummary(summary)
 
-			schemaProblem := problem.ToSchemaProblem()
-			if err := ws.SaveProblem(schemaProblem); err != nil {
-				fmt.Printf("  ✗ Failed to save %s: %v\n", p.Index, err)
-				continue
-			}
+	if summary.Failed > 0 {
+		return fmt.Errorf("failed to fetch %d problem(s); run again to retry them", summary.Failed)
+	}
+	return nil
+}
 
-			fmt.Printf("  ✓ %s. %s\n", problem.Index, problem.Name)
-		}
+// saveToWorkspace saves a problem with its statement.md and creates
+// solutions/main.<ext> from the default language's template. method records
+// where the problem came from (a v1.FetchMethod* value). Returns the created
+// solution path, if any.
+func saveToWorkspace(ws *workspace.Workspace, parser *cfweb.Parser, parsed *cfweb.ParsedProblem, method string) (string, error) {
+	problem := parsed.ToSchemaProblem()
+	if method != "" {
+		problem.FetchMethod = method
+	}
 
-		fmt.Printf("✓ Fetched contest %d to workspace\n", contestID)
+	// Refetching keeps the practice record, notes, review schedule, checker
+	// and interactor
+	if existing, err := ws.LoadProblem(problem.Platform, problem.ContestID, problem.Index); err == nil {
+		problem.Practice = existing.Practice
+		problem.Notes = existing.Notes
+		problem.Review = existing.Review
+		problem.Checker = existing.Checker
+		problem.Interactor = existing.Interactor
+		problem.Tests = existing.Tests
 	}
 
-	return nil
+	if err := ws.SaveProblem(problem); err != nil {
+		return "", fmt.Errorf("failed to save problem: %w", err)
+	}
+
+	// Images that cannot be downloaded stay linked to Codeforces
+	problemDir := ws.ProblemPath(problem.Platform, problem.ContestID, problem.Index)
+	parser.DownloadImages(parsed, problemDir)
+
+	if err := ws.SaveStatement(problem, parsed.Markdown()); err != nil {
+		return "", err
+	}
+
+	language := "cpp"
+	if m := ws.Manifest(); m != nil && m.Codeforces.DefaultLanguage != "" {
+		language = m.Codeforces.DefaultLanguage
+	}
+
+	solution, err := ws.CreateSolution(problem, language, problemTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to create solution: %w", err)
+	}
+	return solution, nil
 }
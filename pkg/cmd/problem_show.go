@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/tui/views"
+)
+
+var (
+	// problem show flags
+	showNoPager bool
+	showWidth   int
+)
+
+var problemShowCmd = &cobra.Command{
+	Use:   "show <problem>",
+	Short: "Show a problem statement in the terminal",
+	Long: `Render a problem's statement.md from the workspace.
+
+Works offline once the problem has been fetched. Problems missing from the
+workspace are fetched first. Long statements open in a pager (q to quit).
+
+Examples:
+  cf problem show 1325A      # Show problem A from contest 1325
+  cf problem show 1325 A     # Same
+  cf problem show https://codeforces.com/gym/102001/problem/C
+  cf problem show 1325A --no-pager > statement.txt`,
+	Args: cobra.RangeArgs(1, 2),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runProblemShow,
+}
+
+func init() {
+	problemCmd.AddCommand(problemShowCmd)
+
+	problemShowCmd.Flags().BoolVar(&showNoPager, "no-pager", false, "Print the statement instead of paging it")
+	problemShowCmd.Flags().IntVar(&showWidth, "width", 0, "Render width (default: terminal width)")
+}
+
+func runProblemShow(cmd *cobra.Command, args []string) error {
+	ref, err := cfweb.ParseProblemRef(args...)
+	if err != nil {
+		return err
+	}
+	contestID, index := ref.ContestID, ref.Index
+
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	statement, err := ws.LoadStatement("codeforces", contestID, index)
+	if err != nil {
+		fmt.Printf("Problem %s is not in the workspace, fetching...\n", ref)
+
+		parser := cfweb.NewParserWithClient(nil)
+		problem, err := parser.ParseRef(ref)
+		if err != nil {
+			return fmt.Errorf("failed to parse problem: %w", err)
+		}
+		if _, err := saveToWorkspace(ws, parser, problem, v1.FetchMethodWeb); err != nil {
+			return err
+		}
+
+		statement, err = ws.LoadStatement("codeforces", contestID, index)
+		if err != nil {
+			return err
+		}
+	}
+
+	fd := os.Stdout.Fd()
+	interactive := term.IsTerminal(fd)
+
+	width, height := 80, 0
+	if interactive {
+		if w, h, err := term.GetSize(fd); err == nil {
+			width, height = w, h
+		}
+	}
+	if showWidth > 0 {
+		width = showWidth
+	}
+
+	rendered := views.RenderStatement(statement, width-2)
+	if !interactive || showNoPager || lipgloss.Height(rendered) < height {
+		fmt.Println(rendered)
+		return nil
+	}
+
+	title := fmt.Sprintf(" %s ", ref)
+	model := views.NewStatementModel(title, statement)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithMouseCellMotion()).Run(); err != nil {
+		return fmt.Errorf("failed to run pager: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+var progressRebuild bool
+
+var progressCmd = &cobra.Command{
+	Use:   "progress",
+	Short: "Show practice progress against your goals",
+	Long: `Display workspace practice progress from stats/progress.yaml.
+
+Shows today's and this week's solves against the workspace daily and weekly
+goals, current and longest streak, and solved problems by rating and tag.
+Progress is updated by 'cf sync' and 'cf submit'.
+
+Examples:
+  cf progress             # Show progress
+  cf progress --rebuild   # Recompute from workspace problems first`,
+	Args: cobra.NoArgs,
+	RunE: runProgress,
+}
+
+func init() {
+	progressCmd.Flags().BoolVar(&progressRebuild, "rebuild", false, "Recompute progress from workspace problems")
+}
+
+func runProgress(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	var progress *v1.Progress
+	if _, statErr := os.Stat(ws.ProgressPath()); progressRebuild || os.IsNotExist(statErr) {
+		progress, err = ws.RebuildProgress()
+	} else {
+		progress, err = ws.LoadProgress()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load progress: %w", err)
+	}
+
+	dailyGoal, weeklyGoal := 0, 0
+	if m := ws.Manifest(); m != nil {
+		dailyGoal, weeklyGoal = m.Practice.DailyGoal, m.Practice.WeeklyGoal
+	}
+	if dailyGoal == 0 {
+		if cfg := config.Get(); cfg != nil {
+			dailyGoal = cfg.DailyGoal
+		}
+	}
+
+	now := time.Now()
+	today := progress.Day(now.Format("2006-01-02"))
+	weekSolved := progress.SolvedSince(weekStart(now).Format("2006-01-02"))
+
+	fmt.Printf("\n🎯 Practice Progress\n")
+	fmt.Println(strings.Repeat("═", 60))
+
+	fmt.Printf("\n📅 Goals:\n")
+	fmt.Printf("   Today:     %s\n", formatGoal(today.Solved, dailyGoal))
+	if weeklyGoal > 0 {
+		fmt.Printf("   This week: %s\n", formatGoal(weekSolved, weeklyGoal))
+	} else {
+		fmt.Printf("   This week: %d solved\n", weekSolved)
+	}
+
+	fmt.Printf("\n🔥 Streak:\n")
+	fmt.Printf("   Current: %d days\n", progress.StreakAt(now))
+	fmt.Printf("   Longest: %d days\n", progress.LongestStreak)
+
+	fmt.Printf("\n📈 Overall:\n")
+	fmt.Printf("   Solved:    %d\n", progress.TotalSolved)
+	fmt.Printf("   Attempted: %d (unsolved)\n", progress.TotalAttempted)
+
+	fmt.Printf("\n📆 Last 7 days:\n")
+	for i := 6; i >= 0; i-- {
+		day := now.AddDate(0, 0, -i)
+		entry := progress.Day(day.Format("2006-01-02"))
+		mark := ""
+		if dailyGoal > 0 && entry.Solved >= dailyGoal {
+			mark = " ✓"
+		}
+		fmt.Printf("   %s %2d %s%s\n", day.Format("Mon Jan 02"), entry.Solved, strings.Repeat("█", min(entry.Solved, 30)), mark)
+	}
+
+	if len(progress.RatingDistribution) > 0 {
+		fmt.Printf("\n⭐ By Rating:\n")
+		buckets := make([]string, 0, len(progress.RatingDistribution))
+		for b := range progress.RatingDistribution {
+			buckets = append(buckets, b)
+		}
+		sort.Slice(buckets, func(i, j int) bool {
+			return bucketStart(buckets[i]) < bucketStart(buckets[j])
+		})
+		for _, b := range buckets {
+			count := progress.RatingDistribution[b]
+			fmt.Printf("   %-10s %3d %s\n", b, count, strings.Repeat("█", min(count, 30)))
+		}
+	}
+
+	if len(progress.TagDistribution) > 0 {
+		fmt.Printf("\n🏷️  Top Tags:\n")
+		tags := make([]string, 0, len(progress.TagDistribution))
+		for t := range progress.TagDistribution {
+			tags = append(tags, t)
+		}
+		sort.Slice(tags, func(i, j int) bool {
+			ci, cj := progress.TagDistribution[tags[i]], progress.TagDistribution[tags[j]]
+			if ci != cj {
+				return ci > cj
+			}
+			return tags[i] < tags[j]
+		})
+		for i, t := range tags {
+			if i >= 10 {
+				break
+			}
+			count := progress.TagDistribution[t]
+			fmt.Printf("   %-20s %3d %s\n", t, count, strings.Repeat("█", min(count, 20)))
+		}
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// formatGoal renders progress towards a goal, e.g. "2/3 [██████░░░]"
+func formatGoal(done, goal int) string {
+	if goal <= 0 {
+		return fmt.Sprintf("%d solved (no goal set)", done)
+	}
+	s := fmt.Sprintf("%d/%d %s", done, goal, progressBar(min(done, goal), goal, 12))
+	if done >= goal {
+		s += " ✓"
+	}
+	return s
+}
+
+// weekStart returns midnight of the Monday starting t's week
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	y, m, d := t.AddDate(0, 0, -offset).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// bucketStart returns the lower bound of a rating bucket such as "1200-1399"
+func bucketStart(bucket string) int {
+	var start int
+	fmt.Sscanf(bucket, "%d", &start)
+	return start
+}
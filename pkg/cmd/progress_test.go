@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeekStart(t *testing.T) {
+	tests := []struct {
+		day  time.Time
+		want string
+	}{
+		{time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC), "2024-03-11"},  // Monday
+		{time.Date(2024, 3, 14, 9, 0, 0, 0, time.UTC), "2024-03-11"},  // Thursday
+		{time.Date(2024, 3, 17, 23, 0, 0, 0, time.UTC), "2024-03-11"}, // Sunday
+	}
+
+	for _, tt := range tests {
+		if got := weekStart(tt.day).Format("2006-01-02"); got != tt.want {
+			t.Errorf("weekStart(%s) = %s, want %s", tt.day.Weekday(), got, tt.want)
+		}
+	}
+}
+
+func TestFormatGoal(t *testing.T) {
+	tests := []struct {
+		done, goal int
+		want       string
+	}{
+		{1, 3, "1/3 [████░░░░░░░░]"},
+		{4, 3, "4/3 [████████████] ✓"},
+		{2, 0, "2 solved (no goal set)"},
+	}
+
+	for _, tt := range tests {
+		if got := formatGoal(tt.done, tt.goal); got != tt.want {
+			t.Errorf("formatGoal(%d, %d) = %q, want %q", tt.done, tt.goal, got, tt.want)
+		}
+	}
+}
+
+func TestBucketStart(t *testing.T) {
+	if got := bucketStart("1200-1399"); got != 1200 {
+		t.Errorf("bucketStart() = %d, want 1200", got)
+	}
+	if got := bucketStart("2400+"); got != 2400 {
+		t.Errorf("bucketStart() = %d, want 2400", got)
+	}
+}
@@ -0,0 +1,387 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// review flags
+	reviewLimit      int
+	reviewApproach   string
+	reviewReminder   string
+	reviewDifficulty string
+	reviewEdit       bool
+)
+
+// reviewGrades describes the SM-2 recall grades
+var reviewGrades = []string{
+	"forgot completely",
+	"wrong, but familiar once shown",
+	"wrong, but it seemed easy once shown",
+	"recalled with serious difficulty",
+	"recalled after some hesitation",
+	"recalled perfectly",
+}
+
+var reviewCmd = &cobra.Command{
+	Use:   "review",
+	Short: "Review problems with spaced repetition",
+	Long: `Serve the problems due for review, one at a time.
+
+For each problem, try to recall how to solve it (or re-solve it), then
+press Enter to see the approach you saved. Grade your recall from 0 to 5;
+the next review is scheduled with the SM-2 algorithm, sooner for poor
+recall and further out each time you remember it. The schedule (due date,
+ease and interval) is stored in problem.yaml.
+
+Mark problems for review with 'cf review add'.
+
+Examples:
+  cf review                                   # Review everything due
+  cf review -n 5                              # At most 5 problems
+  cf review add 1325A --approach "gcd(1, x-1) = 1"
+  cf review list                              # Queue with due dates
+  cf review remove 1325A`,
+	Args: cobra.NoArgs,
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runReview,
+}
+
+var reviewAddCmd = &cobra.Command{
+	Use:   "add [problem]",
+	Short: "Mark a problem for review and save its approach",
+	Long: `Mark a workspace problem for review. The approach is what you are
+shown after trying to recall it: pass it with --approach, or write it in
+$EDITOR (opened when the problem has no approach yet, or with --edit).
+--reminder adds a short note shown with the approach, such as a pitfall.
+--difficulty (easy, medium or hard) sets how often it comes back.
+
+Without a problem, the problem in the current directory is used.`,
+	Args: cobra.MaximumNArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runReviewAdd,
+}
+
+var reviewRemoveCmd = &cobra.Command{
+	Use:     "remove [problem]",
+	Aliases: []string{"rm"},
+	Short:   "Stop reviewing a problem",
+	Long: `Take a problem out of the review queue. Its approach and schedule are
+kept, so 'cf review add' picks up where it left off.`,
+	Args: cobra.MaximumNArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runReviewRemove,
+}
+
+var reviewListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List problems marked for review with their due dates",
+	Args:    cobra.NoArgs,
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runReviewList,
+}
+
+func init() {
+	reviewCmd.AddCommand(reviewAddCmd, reviewRemoveCmd, reviewListCmd)
+
+	reviewCmd.Flags().IntVarP(&reviewLimit, "limit", "n", 0, "Review at most this many problems (0 = all due)")
+	reviewAddCmd.Flags().StringVar(&reviewApproach, "approach", "", "The approach to recall")
+	reviewAddCmd.Flags().StringVar(&reviewReminder, "reminder", "", "Short note shown with the approach")
+	reviewAddCmd.Flags().StringVar(&reviewDifficulty, "difficulty", "", "How hard it felt: easy, medium or hard")
+	reviewAddCmd.Flags().BoolVar(&reviewEdit, "edit", false, "Edit the approach in $EDITOR")
+}
+
+func runReview(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	due, err := ws.DueReviews(now)
+	if err != nil {
+		return err
+	}
+	if len(due) == 0 {
+		fmt.Println("✓ Nothing due for review.")
+		if next := nextReview(ws); !next.IsZero() {
+			fmt.Printf("Next review: %s\n", next.Format("Mon Jan 2"))
+		}
+		return nil
+	}
+	if reviewLimit > 0 && len(due) > reviewLimit {
+		due = due[:reviewLimit]
+	}
+
+	in := bufio.NewReader(os.Stdin)
+	reviewed := 0
+	for i, problem := range due {
+		fmt.Printf("\n[%d/%d] ", i+1, len(due))
+		graded, err := reviewProblem(in, ws, problem, time.Now())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if graded {
+			reviewed++
+		}
+	}
+
+	fmt.Printf("\n✓ Reviewed %d problem(s)\n", reviewed)
+	return nil
+}
+
+// reviewProblem runs one review: it shows the problem, reveals the saved
+// approach once the user is ready and records the grade. It returns false
+// if the problem was skipped, and io.EOF when the user quits.
+func reviewProblem(in *bufio.Reader, ws *workspace.Workspace, problem *v1.Problem, now time.Time) (bool, error) {
+	fmt.Printf("%d%s. %s", problem.ContestID, problem.Index, problem.Name)
+	if problem.Metadata.Rating > 0 {
+		fmt.Printf(" (%d)", problem.Metadata.Rating)
+	}
+	fmt.Println()
+	if r := problem.Review; r != nil && r.LastReviewed != nil {
+		fmt.Printf("Last reviewed %s, recalled %d time(s) in a row\n", r.LastReviewed.Format("Jan 2"), r.Repetitions)
+	}
+	fmt.Println(ws.ProblemPath(problem.Platform, problem.ContestID, problem.Index))
+
+	fmt.Print("\nRecall the approach, then press Enter to reveal it (s = skip, q = quit): ")
+	answer, err := readAnswer(in)
+	if err != nil {
+		return false, err
+	}
+	switch answer {
+	case "q":
+		return false, io.EOF
+	case "s":
+		return false, nil
+	}
+
+	fmt.Println(strings.Repeat("─", 60))
+	if problem.Notes.Approach != "" {
+		fmt.Println(strings.TrimSpace(problem.Notes.Approach))
+	} else {
+		fmt.Println("(no approach saved; add one with 'cf review add --edit')")
+	}
+	if problem.Notes.Reminder != "" {
+		fmt.Printf("\n💡 %s\n", problem.Notes.Reminder)
+	}
+	fmt.Println(strings.Repeat("─", 60))
+
+	for grade, desc := range reviewGrades {
+		fmt.Printf("  %d  %s\n", grade, desc)
+	}
+	var grade int
+	for {
+		fmt.Print("How well did you recall it? [0-5, s = skip, q = quit]: ")
+		answer, err := readAnswer(in)
+		if err != nil {
+			return false, err
+		}
+		switch answer {
+		case "q":
+			return false, io.EOF
+		case "s":
+			return false, nil
+		}
+		if grade, err = strconv.Atoi(answer); err == nil && grade >= 0 && grade <= v1.MaxReviewGrade {
+			break
+		}
+	}
+
+	if err := problem.GradeReview(grade, now); err != nil {
+		return false, err
+	}
+	if err := ws.SaveProblem(problem); err != nil {
+		return false, err
+	}
+	fmt.Printf("Next review in %d day(s), on %s\n", problem.Review.Interval, problem.Review.Due.Format("Mon Jan 2"))
+	return true, nil
+}
+
+// readAnswer reads one trimmed, lowercased line. A final line without a
+// newline is accepted; io.EOF is returned once input is exhausted.
+func readAnswer(in *bufio.Reader) (string, error) {
+	line, err := in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.ToLower(strings.TrimSpace(line)), nil
+}
+
+func runReviewAdd(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadReviewProblem(args)
+	if err != nil {
+		return err
+	}
+
+	notes := &problem.Notes
+	switch d := strings.ToLower(reviewDifficulty); d {
+	case "":
+	case "easy", "medium", "hard":
+		notes.Difficulty = d
+	default:
+		return fmt.Errorf("invalid difficulty %q (expected easy, medium or hard)", reviewDifficulty)
+	}
+	if reviewReminder != "" {
+		notes.Reminder = reviewReminder
+	}
+
+	switch {
+	case reviewApproach != "":
+		notes.Approach = reviewApproach
+	case reviewEdit || notes.Approach == "":
+		data, err := editText("approach", "md", []byte(notes.Approach))
+		if err != nil {
+			return err
+		}
+		notes.Approach = strings.TrimSpace(string(data))
+	}
+
+	wasQueued := notes.Review
+	notes.Review = true
+	if err := ws.SaveProblem(problem); err != nil {
+		return err
+	}
+
+	label := fmt.Sprintf("%d%s", problem.ContestID, problem.Index)
+	if wasQueued {
+		fmt.Printf("✓ Updated review notes for %s\n", label)
+	} else if problem.Review != nil && !problem.ReviewDue(time.Now()) {
+		fmt.Printf("✓ Added %s back to reviews, due %s\n", label, problem.Review.Due.Format("Mon Jan 2"))
+	} else {
+		fmt.Printf("✓ Added %s to reviews, due now\n", label)
+	}
+	if notes.Approach == "" {
+		fmt.Println("⚠️  No approach saved; there will be nothing to check your recall against")
+	}
+	return nil
+}
+
+func runReviewRemove(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadReviewProblem(args)
+	if err != nil {
+		return err
+	}
+
+	label := fmt.Sprintf("%d%s", problem.ContestID, problem.Index)
+	if !problem.Notes.Review {
+		fmt.Printf("%s is not marked for review\n", label)
+		return nil
+	}
+	problem.Notes.Review = false
+	if err := ws.SaveProblem(problem); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Removed %s from reviews\n", label)
+	return nil
+}
+
+func runReviewList(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	problems, err := ws.ReviewProblems()
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Println("No problems marked for review. Add one with 'cf review add <problem>'.")
+		return nil
+	}
+
+	now := time.Now()
+	due := 0
+	for _, p := range problems {
+		if p.ReviewDue(now) {
+			due++
+		}
+	}
+
+	fmt.Printf("\n🔁 Reviews: %d due of %d\n", due, len(problems))
+	fmt.Println(strings.Repeat("─", 60))
+	for _, p := range problems {
+		name := p.Name
+		if len(name) > 32 {
+			name = name[:29] + "..."
+		}
+		when, detail := "new", ""
+		if r := p.Review; r != nil {
+			when = r.Due.Format("Jan 2")
+			if r.IsDue(now) {
+				when = "due"
+			}
+			detail = fmt.Sprintf("every %dd, ease %.2f", r.Interval, r.Ease)
+		}
+		line := fmt.Sprintf("  %-8s %-32s %-6s  %s", fmt.Sprintf("%d%s", p.ContestID, p.Index), name, when, detail)
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+	fmt.Println()
+	return nil
+}
+
+// loadReviewProblem loads the problem named by args, or else the problem in
+// the current directory
+func loadReviewProblem(args []string) (*workspace.Workspace, *v1.Problem, error) {
+	ws, err := getWorkspace()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contestID int
+	var index string
+	if len(args) > 0 {
+		ref, err := cfweb.ParseProblemRef(args...)
+		if err != nil {
+			return nil, nil, err
+		}
+		contestID, index = ref.ContestID, ref.Index
+	} else {
+		loc, err := ws.LocateProblem(".")
+		if err != nil {
+			return nil, nil, fmt.Errorf("not in a problem directory; name a problem")
+		}
+		contestID, index = loc.ContestID, loc.Index
+	}
+
+	problem, err := ws.LoadProblem("codeforces", contestID, index)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%d%s is not in the workspace (fetch it with 'cf problem fetch'): %w", contestID, index, err)
+	}
+	return ws, problem, nil
+}
+
+// nextReview returns when the next scheduled review is due, or the zero
+// time if none is scheduled
+func nextReview(ws *workspace.Workspace) time.Time {
+	problems, err := ws.ReviewProblems()
+	if err != nil || len(problems) == 0 || problems[0].Review == nil {
+		return time.Time{}
+	}
+	return problems[0].Review.Due
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+func TestReviewProblem(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	problem := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	problem.Notes = v1.UserNotes{Approach: "gcd(1, x-1) = 1", Review: true}
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	now := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		input      string
+		wantGraded bool
+		wantErr    error
+		wantGrade  int
+	}{
+		{"skip before reveal", "s\n", false, nil, 0},
+		{"quit at grade", "\nq\n", false, io.EOF, 0},
+		{"input ends", "", false, io.EOF, 0},
+		{"invalid grade is asked again", "\n9\nok\n4\n", true, nil, 4},
+		{"grade without newline", "\n5", true, nil, 5},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := ws.LoadProblem("codeforces", 1325, "A")
+			p.Review = nil
+
+			graded, err := reviewProblem(bufio.NewReader(strings.NewReader(tt.input)), ws, p, now)
+			if graded != tt.wantGraded || err != tt.wantErr {
+				t.Fatalf("reviewProblem() = %v, %v; want %v, %v", graded, err, tt.wantGraded, tt.wantErr)
+			}
+			if !graded {
+				return
+			}
+
+			saved, _ := ws.LoadProblem("codeforces", 1325, "A")
+			if saved.Review == nil || saved.Review.LastGrade != tt.wantGrade || saved.ReviewDue(now) {
+				t.Errorf("saved review = %+v, want grade %d and not due", saved.Review, tt.wantGrade)
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ var (
 	verbose    bool
 )
 
+// annotationOffline marks commands that work without network access; their
+// startup checks skip Codeforces connectivity
+const annotationOffline = "offline"
+
 var rootCmd = &cobra.Command{
 	Use:   "cf",
 	Short: "Codeforces CLI - Your competitive programming companion",
@@ -44,21 +48,27 @@ Run 'cf' without arguments to launch the interactive TUI.`,
 	PersistentPreRunE: runPreChecks,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Launch TUI
-		return tui.Run()
+		return tui.Run(apiClientOptions()...)
 	},
 }
 
 func runPreChecks(cmd *cobra.Command, args []string) error {
-	// Skip health checks for version and help commands
-	if cmd.Name() == "version" || cmd.Name() == "help" {
+	// Skip health checks for version and help commands, and for migrate,
+	// which must run on workspaces the schema check rejects
+	if cmd.Name() == "version" || cmd.Name() == "help" || cmd.Name() == "migrate" {
 		return nil
 	}
+	if cmd.Annotations[annotationOffline] == "true" {
+		return runChecks(false)
+	}
 	return runStartupChecks()
 }
 
 // Execute runs the root command
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	flushAPIClient()
+	if err != nil {
 		os.Exit(1)
 	}
 }
@@ -83,6 +93,18 @@ func init() {
 	rootCmd.AddCommand(contestCmd)
 	rootCmd.AddCommand(statsCmd)
 	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(submitCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(stressCmd)
+	rootCmd.AddCommand(templateCmd)
+	rootCmd.AddCommand(cacheCmd)
+	rootCmd.AddCommand(syncCmd)
+	rootCmd.AddCommand(progressCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(practiceCmd)
+	rootCmd.AddCommand(dailyCmd)
+	rootCmd.AddCommand(listCmd)
+	rootCmd.AddCommand(reviewCmd)
 
 	// Legacy parse command (deprecated, redirects to problem parse)
 	rootCmd.AddCommand(parseCmd)
@@ -94,7 +116,7 @@ var tuiCmd = &cobra.Command{
 	Short: "Launch the interactive TUI",
 	Long:  `Launch the interactive terminal user interface for browsing problems, viewing stats, and more.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return tui.Run()
+		return tui.Run(apiClientOptions()...)
 	},
 }
 
@@ -107,6 +129,12 @@ func initConfig() {
 }
 
 func runStartupChecks() error {
+	return runChecks(true)
+}
+
+// runChecks runs the startup health checks. Network checks are skipped for
+// commands that work offline.
+func runChecks(network bool) error {
 	if skipChecks {
 		return nil
 	}
@@ -131,12 +159,14 @@ func runStartupChecks() error {
 	checker.AddCheck(health.NewSchemaVersionCheck(ws))
 
 	// External checks
-	apiClient := cfapi.NewClient()
-	parser := cfweb.NewParserWithClient(nil)
+	if network {
+		apiClient := cfapi.NewClient()
+		parser := cfweb.NewParserWithClient(nil)
 
-	checker.AddCheck(exthealth.NewCFAPICheck(apiClient))
-	checker.AddCheck(exthealth.NewCFWebCheck(parser))
-	checker.AddCheck(exthealth.NewCFHandleCheck(apiClient))
+		checker.AddCheck(exthealth.NewCFAPICheck(apiClient))
+		checker.AddCheck(exthealth.NewCFWebCheck(parser))
+		checker.AddCheck(exthealth.NewCFHandleCheck(apiClient))
+	}
 
 	// Run checks
 	report := checker.Run(ctx)
@@ -222,6 +252,22 @@ var initCmd = &cobra.Command{
 	},
 }
 
+// getWorkspace returns the configured workspace, failing if it is not initialized
+func getWorkspace() (*workspace.Workspace, error) {
+	cfg := config.Get()
+	if cfg == nil || cfg.WorkspacePath == "" {
+		return nil, fmt.Errorf("no workspace configured. Run 'cf init' first")
+	}
+	ws := workspace.New(cfg.WorkspacePath)
+	if !ws.Exists() {
+		return nil, fmt.Errorf("workspace not found at %s. Run 'cf init' first", cfg.WorkspacePath)
+	}
+	if err := ws.Load(); err != nil {
+		return nil, fmt.Errorf("failed to load workspace: %w", err)
+	}
+	return ws, nil
+}
+
 // healthCmd shows health status
 var healthCmd = &cobra.Command{
 	Use:   "health",
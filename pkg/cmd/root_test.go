@@ -436,6 +436,11 @@ func TestRunStartupChecks_WithChecks(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
 
+	// The workspace check creates a workspace in the current directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
 
@@ -457,6 +462,11 @@ func TestRunStartupChecks_Verbose(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
 
+	// The workspace check creates a workspace in the current directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
 	skipChecks = false
 	verbose = true
 	defer func() { verbose = false }()
@@ -499,6 +509,11 @@ func TestHealthCommand_RunE(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
 
+	// The workspace check creates a workspace in the current directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
 
@@ -576,6 +591,11 @@ func TestRunStartupChecks_WithCookie(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
 
+	// The workspace check creates a workspace in the current directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
 	// Set up config with test handle and cookie
 	config.SetGlobalConfig(&config.Config{
 		CFHandle: "testuser",
@@ -599,6 +619,11 @@ func TestRunStartupChecks_WithWorkspace(t *testing.T) {
 	tmpDir := t.TempDir()
 	os.Setenv("HOME", tmpDir)
 
+	// The workspace check creates a workspace in the current directory
+	oldWd, _ := os.Getwd()
+	defer os.Chdir(oldWd)
+	os.Chdir(tmpDir)
+
 	// Set up config with test handle
 	config.SetGlobalConfig(&config.Config{CFHandle: "testuser"})
 
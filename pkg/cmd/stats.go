@@ -52,7 +52,7 @@ func runStats(cmd *cobra.Command, args []string) error {
 	user := users[0]
 
 	// Get submissions
-	submissions, err := client.GetUserSubmissions(ctx, handle, 1, 10000)
+	submissions, err := client.GetAllSubmissions(ctx, handle)
 	if err != nil {
 		return fmt.Errorf("failed to get submissions: %w", err)
 	}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/judge"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// stress flags
+	stressGen        string
+	stressBrute      string
+	stressSol        string
+	stressIterations int
+	stressSeed       int64
+	stressTimeLimit  time.Duration
+	stressTestsDir   string
+	stressNoSave     bool
+)
+
+var stressCmd = &cobra.Command{
+	Use:   "stress",
+	Short: "Stress test a solution against a brute force",
+	Long: `Run a generator, a brute-force solution and your solution in a loop.
+
+Each iteration runs the generator as "gen <seed>" with a new seed, feeds its
+output to both programs and compares the results with the problem's checker.
+The first mismatch is printed and saved to the problem's tests/ directory as
+a new numbered test (test_N.in/.out) and registered in problem.yaml with its
+seed; see "cf test list".
+
+Examples:
+  cf stress --gen gen.cpp --brute brute.cpp                # Uses solutions/main.*
+  cf stress --gen gen.py --brute brute.cpp --sol main.cpp -n 1000
+  cf stress --gen gen.cpp --brute brute.cpp --seed 500     # Start from seed 500`,
+	Args: cobra.NoArgs,
+	RunE: runStress,
+}
+
+func init() {
+	stressCmd.Flags().StringVar(&stressGen, "gen", "", "Test generator, run as: gen <seed> (required)")
+	stressCmd.Flags().StringVar(&stressBrute, "brute", "", "Brute-force reference solution (required)")
+	stressCmd.Flags().StringVar(&stressSol, "sol", "", "Solution to test (default: solutions/main.*)")
+	stressCmd.Flags().IntVarP(&stressIterations, "iterations", "n", 1000, "Number of iterations")
+	stressCmd.Flags().Int64Var(&stressSeed, "seed", 1, "First seed passed to the generator")
+	stressCmd.Flags().DurationVar(&stressTimeLimit, "time-limit", 0, "Time limit for the solution (default: from problem.yaml)")
+	stressCmd.Flags().StringVar(&stressTestsDir, "tests", "", "Directory to save the failing test (default: <problem>/tests)")
+	stressCmd.Flags().BoolVar(&stressNoSave, "no-save", false, "Do not save the failing test")
+	stressCmd.MarkFlagRequired("gen")
+	stressCmd.MarkFlagRequired("brute")
+}
+
+func runStress(cmd *cobra.Command, args []string) error {
+	if stressIterations <= 0 {
+		return fmt.Errorf("iterations must be positive")
+	}
+
+	solPath := stressSol
+	if solPath == "" {
+		found, err := findSolution(".")
+		if err != nil {
+			return err
+		}
+		solPath = found
+	}
+
+	// Workspace is optional: used to find the tests dir, time limit and checker
+	ws, _ := getWorkspace()
+
+	setup := resolveTestSetup(ws, solPath)
+	if stressTestsDir != "" {
+		setup.TestsPath = stressTestsDir
+	}
+	if stressTimeLimit > 0 {
+		setup.TimeLimit = stressTimeLimit
+	}
+	if setup.TestsPath == "" {
+		setup.TestsPath = "tests"
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	checker, err := judge.NewChecker(ctx, setup.Checker, setup.ProblemDir)
+	if err != nil {
+		return fmt.Errorf("failed to create checker: %w", err)
+	}
+	if c, ok := checker.(*judge.ExternalChecker); ok {
+		defer c.Cleanup()
+	}
+
+	var programs []*judge.Program
+	defer func() {
+		for _, p := range programs {
+			p.Cleanup()
+		}
+	}()
+	for _, path := range []string{stressGen, stressBrute, solPath} {
+		prog, err := compileProgram(ctx, cmd, path)
+		if err != nil {
+			return err
+		}
+		programs = append(programs, prog)
+	}
+
+	fmt.Printf("Stress testing %d iteration(s) from seed %d, time limit %v\n", stressIterations, stressSeed, setup.TimeLimit)
+	fmt.Println(strings.Repeat("─", 50))
+
+	opts := setup.options()
+	opts.Checker = checker
+
+	var lastPrint time.Time
+	report, err := judge.Stress(ctx, judge.StressConfig{
+		Generator:  programs[0],
+		Brute:      programs[1],
+		Solution:   programs[2],
+		Iterations: stressIterations,
+		StartSeed:  stressSeed,
+		Options:    opts,
+		OnProgress: func(done int, elapsed time.Duration) {
+			if done < stressIterations && time.Since(lastPrint) < 100*time.Millisecond {
+				return
+			}
+			lastPrint = time.Now()
+			fmt.Printf("\r\033[K%s %d/%d  %.1f tests/s", progressBar(done, stressIterations, 20), done, stressIterations, float64(done)/elapsed.Seconds())
+		},
+	})
+	fmt.Println()
+	if err != nil {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("stress test stopped after %d iteration(s): %w", report.Iterations, err)
+	}
+
+	fmt.Println(strings.Repeat("─", 50))
+	if report.Failure == nil {
+		fmt.Printf("✓ All %d iterations passed (%.1f tests/s)\n", report.Iterations, report.Throughput())
+		return nil
+	}
+
+	failure := report.Failure
+	fmt.Printf("✗ %s on seed %d (iteration %d)\n", failure.Result.Verdict, failure.Seed, report.Iterations)
+	fmt.Println("\nInput:")
+	fmt.Println(truncateOutput(failure.Input, 20))
+	fmt.Println("\nExpected (brute):")
+	fmt.Println(truncateOutput(failure.Answer, 20))
+	fmt.Println("\nGot:")
+	fmt.Println(truncateOutput(failure.Result.Output, 20))
+	fmt.Println()
+	printResultDetails(failure.Result)
+
+	if !stressNoSave {
+		path, err := saveStressFailure(ws, setup, failure)
+		if err != nil {
+			return fmt.Errorf("failed to save test: %w", err)
+		}
+		fmt.Printf("\n✓ Saved as %s\n", path)
+	}
+
+	cmd.SilenceUsage = true
+	return fmt.Errorf("solution failed on seed %d", failure.Seed)
+}
+
+// saveStressFailure saves a failing case as the next test_N, registered in
+// problem.yaml when the tests directory is the problem's own
+func saveStressFailure(ws *workspace.Workspace, setup *testSetup, failure *judge.StressFailure) (string, error) {
+	if setup.Problem != nil && stressTestsDir == "" {
+		entry, err := ws.AddTest(setup.Problem, v1.TestEntry{
+			Source: v1.TestSourceStress,
+			Note:   fmt.Sprintf("seed %d, %s", failure.Seed, failure.Result.Verdict),
+		}, failure.Input, failure.Answer)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(ws.TestsPath(setup.Problem), entry.Name+".in"), nil
+	}
+
+	test, err := judge.AddTest(setup.TestsPath, failure.Input, failure.Answer)
+	if err != nil {
+		return "", err
+	}
+	return test.InputPath, nil
+}
+
+// progressBar renders a fixed-width progress bar
+func progressBar(done, total, width int) string {
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	return "[" + strings.Repeat("█", filled) + strings.Repeat("░", width-filled) + "]"
+}
+
+// truncateOutput limits program output to maxLines for display
+func truncateOutput(data []byte, maxLines int) string {
+	text := strings.TrimRight(string(data), "\n")
+	if text == "" {
+		return "(empty)"
+	}
+	lines := strings.Split(text, "\n")
+	if len(lines) <= maxLines {
+		return text
+	}
+	return strings.Join(lines[:maxLines], "\n") + fmt.Sprintf("\n... (%d more lines)", len(lines)-maxLines)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProgressBar(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{0, 10, "[░░░░░░░░░░]"},
+		{5, 10, "[█████░░░░░]"},
+		{10, 10, "[██████████]"},
+		{3, 0, "[░░░░░░░░░░]"},
+	}
+
+	for _, tt := range tests {
+		if got := progressBar(tt.done, tt.total, 10); got != tt.want {
+			t.Errorf("progressBar(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateOutput(t *testing.T) {
+	if got := truncateOutput(nil, 5); got != "(empty)" {
+		t.Errorf("truncateOutput(nil) = %q", got)
+	}
+	if got := truncateOutput([]byte("a\nb\n"), 5); got != "a\nb" {
+		t.Errorf("truncateOutput() = %q", got)
+	}
+
+	got := truncateOutput([]byte(strings.Repeat("x\n", 10)), 3)
+	if !strings.HasSuffix(got, "(7 more lines)") {
+		t.Errorf("truncateOutput() = %q, want truncation note", got)
+	}
+}
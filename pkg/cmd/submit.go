@@ -0,0 +1,252 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// submit flags
+	submitLanguage string
+	submitNoWait   bool
+	submitTimeout  time.Duration
+)
+
+var submitCmd = &cobra.Command{
+	Use:   "submit <file> [problem]",
+	Short: "Submit a solution to Codeforces",
+	Long: `Submit a solution file to Codeforces and wait for the verdict.
+
+The problem may be given as 1325A, 1325 A or a problem URL (contest,
+problemset, gym). If omitted, it is detected from the file's location in the
+workspace (problems/codeforces/{contest,gym}/<id>/<index>/solutions).
+The language is picked from the file extension unless --lang is given.
+
+While waiting, judging progress such as "Running on test 17" is shown. The
+submission page is polled, falling back to the API if it cannot be read.
+Ctrl+C stops waiting; the submission is still recorded.
+
+Requires a browser cookie: cf config set cookie '...'
+
+Examples:
+  cf submit solutions/main.cpp             # Submit from inside a problem directory
+  cf submit a.cpp 1325 A                   # Submit to problem 1325A
+  cf submit a.cpp https://codeforces.com/gym/102001/problem/B
+  cf submit main.py 1325 A --lang pypy3    # Submit with a specific language`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) < 1 || len(args) > 3 {
+			return fmt.Errorf("accepts <file> or <file> <problem>, received %d arg(s)", len(args))
+		}
+		if len(args) > 1 {
+			if _, err := cfweb.ParseProblemRef(args[1:]...); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	RunE: runSubmit,
+}
+
+func init() {
+	submitCmd.Flags().StringVar(&submitLanguage, "lang", "", "Language ID (e.g. cpp20, python3, pypy3); detected from extension by default")
+	submitCmd.Flags().BoolVar(&submitNoWait, "no-wait", false, "Do not wait for the verdict")
+	submitCmd.Flags().DurationVar(&submitTimeout, "timeout", 5*time.Minute, "Maximum time to wait for the verdict")
+}
+
+func runSubmit(cmd *cobra.Command, args []string) error {
+	sourcePath := args[0]
+	source, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to read solution: %w", err)
+	}
+
+	// Workspace is optional: used to detect the problem and record the submission
+	ws, _ := getWorkspace()
+
+	ref, err := resolveSubmitTarget(ws, sourcePath, args[1:])
+	if err != nil {
+		return err
+	}
+	if ref.Kind == cfweb.RefGroup {
+		return fmt.Errorf("submitting to group contests is not supported; submit from the browser")
+	}
+	contestID, problemIndex := ref.ContestID, ref.Index
+
+	lang, err := resolveLanguage(sourcePath, submitLanguage)
+	if err != nil {
+		return err
+	}
+
+	cookie := config.GetCookie()
+	if cookie == "" {
+		return fmt.Errorf("no cookie configured. Set with 'cf config set cookie <cookie>'")
+	}
+	handle := config.GetCFHandle()
+	if handle == "" {
+		return fmt.Errorf("no CF handle configured. Set with 'cf config set cf_handle <handle>'")
+	}
+
+	session, err := cfweb.NewSessionWithCookie(cookie)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	session.SetHandle(handle)
+
+	submitter, err := cfweb.NewSubmitter(session)
+	if err != nil {
+		return fmt.Errorf("failed to create submitter: %w", err)
+	}
+
+	fmt.Printf("Submitting %s to %d%s (%s)...\n", filepath.Base(sourcePath), contestID, problemIndex, lang.Name)
+
+	var result *cfweb.SubmissionResult
+	if ref.IsGym() {
+		result, err = submitter.SubmitToGym(contestID, problemIndex, lang.CompilerID, string(source))
+	} else {
+		result, err = submitter.Submit(contestID, problemIndex, lang.CompilerID, string(source))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to submit: %w", err)
+	}
+
+	fmt.Printf("✓ Submitted #%d\n", result.SubmissionID)
+
+	if !submitNoWait {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		ctx, cancel := context.WithTimeout(ctx, submitTimeout)
+		defer cancel()
+
+		final, err := watchVerdict(ctx, submitter, result.SubmissionID, contestID, handle)
+		if err != nil {
+			fmt.Printf("⚠️  %v\n", err)
+		} else {
+			result = final
+		}
+	}
+
+	if ws != nil {
+		record := newSubmissionRecord(result, contestID, problemIndex, lang, sourcePath, source)
+		if err := ws.SaveSubmission(record); err != nil {
+			return fmt.Errorf("failed to save submission: %w", err)
+		}
+		if _, err := ws.SyncPractice([]*v1.Submission{record}); err != nil {
+			return fmt.Errorf("failed to update practice: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// resolveSubmitTarget determines the problem from args or from the
+// solution's location in the workspace
+func resolveSubmitTarget(ws *workspace.Workspace, sourcePath string, args []string) (cfweb.ProblemRef, error) {
+	if len(args) > 0 {
+		return cfweb.ParseProblemRef(args...)
+	}
+
+	if ws == nil {
+		return cfweb.ProblemRef{}, fmt.Errorf("cannot detect problem without a workspace; pass <problem>")
+	}
+
+	loc, err := ws.LocateProblem(sourcePath)
+	if err != nil {
+		return cfweb.ProblemRef{}, fmt.Errorf("cannot detect problem: %w", err)
+	}
+	return cfweb.ParseProblemRef(strconv.Itoa(loc.ContestID), loc.Index)
+}
+
+// resolveLanguage picks the submission language by ID or file extension
+func resolveLanguage(sourcePath, langID string) (*cfweb.Language, error) {
+	if langID != "" {
+		lang := cfweb.GetLanguageByID(langID)
+		if lang == nil {
+			return nil, fmt.Errorf("unknown language: %s", langID)
+		}
+		return lang, nil
+	}
+
+	ext := filepath.Ext(sourcePath)
+	lang := cfweb.GetLanguageByExtension(ext)
+	if lang == nil {
+		return nil, fmt.Errorf("no language for extension %q; use --lang", ext)
+	}
+	return lang, nil
+}
+
+// watchVerdict follows the submission until it is judged, showing progress
+// such as "Running on test 17" on one line. The submission page is polled,
+// with the API as a fallback.
+func watchVerdict(ctx context.Context, submitter *cfweb.Submitter, submissionID int64, contestID int, handle string) (*cfweb.SubmissionResult, error) {
+	tracker := cfweb.NewVerdictTracker(submitter, submissionID, contestID)
+	tracker.SetAPIFallback(getAPIClient(), handle)
+
+	result, err := tracker.Track(ctx, func(r *cfweb.SubmissionResult) {
+		if r.Pending() {
+			fmt.Printf("\r\033[K⏳ %s", r.Progress())
+		}
+	})
+	if err != nil {
+		fmt.Println()
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			return nil, fmt.Errorf("timeout waiting for verdict")
+		case errors.Is(err, context.Canceled):
+			return nil, fmt.Errorf("stopped waiting for verdict")
+		}
+		return nil, fmt.Errorf("failed to get verdict: %w", err)
+	}
+
+	fmt.Printf("\r\033[K%s%s\033[0m", getVerdictColor(result.Verdict), result.Progress())
+	if result.PassedTests > 0 {
+		fmt.Printf("  %d tests passed", result.PassedTests)
+	}
+	if result.Time > 0 || result.Memory > 0 {
+		fmt.Printf("  %d ms, %d KB", result.Time.Milliseconds(), result.Memory/1024)
+	}
+	fmt.Println()
+	return result, nil
+}
+
+// newSubmissionRecord converts a submission result to a workspace record
+func newSubmissionRecord(result *cfweb.SubmissionResult, contestID int, problemIndex string, lang *cfweb.Language, sourcePath string, source []byte) *v1.Submission {
+	record := v1.NewSubmission(result.SubmissionID, fmt.Sprintf("%d%s", contestID, problemIndex), contestID, lang.ID, lang.CompilerID)
+
+	switch {
+	case result.Pending() || result.Verdict == "":
+		record.Verdict = v1.VerdictTesting
+	default:
+		record.Verdict = v1.Verdict(result.Verdict)
+	}
+
+	if result.Time > 0 {
+		record.TimeUsed = fmt.Sprintf("%d ms", result.Time.Milliseconds())
+	}
+	if result.Memory > 0 {
+		record.MemoryUsed = fmt.Sprintf("%d KB", result.Memory/1024)
+	}
+
+	record.SourceFile = sourcePath
+	if abs, err := filepath.Abs(sourcePath); err == nil {
+		record.SourceFile = abs
+	}
+	sum := sha256.Sum256(source)
+	record.SourceHash = hex.EncodeToString(sum[:])
+
+	return record
+}
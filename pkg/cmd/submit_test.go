@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+func TestSubmitCommand_ArgsValidation(t *testing.T) {
+	tests := []struct {
+		args    []string
+		wantErr bool
+	}{
+		{[]string{"main.cpp"}, false},
+		{[]string{"main.cpp", "1325", "A"}, false},
+		{[]string{"main.cpp", "1325A"}, false},
+		{[]string{"main.cpp", "https://codeforces.com/gym/102001/problem/B"}, false},
+		{[]string{"main.cpp", "1325"}, true},
+		{[]string{}, true},
+	}
+
+	for _, tt := range tests {
+		err := submitCmd.Args(submitCmd, tt.args)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Args(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+		}
+	}
+}
+
+func TestResolveSubmitTarget_Args(t *testing.T) {
+	ref, err := resolveSubmitTarget(nil, "main.cpp", []string{"1325", "a"})
+	if err != nil {
+		t.Fatalf("resolveSubmitTarget() error = %v", err)
+	}
+	if ref.ContestID != 1325 || ref.Index != "A" {
+		t.Errorf("resolveSubmitTarget() = %s, want 1325A", ref)
+	}
+
+	if _, err := resolveSubmitTarget(nil, "main.cpp", []string{"abc", "A"}); err == nil {
+		t.Error("resolveSubmitTarget() should reject invalid contest ID")
+	}
+}
+
+func TestResolveSubmitTarget_FromPath(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	source := filepath.Join(ws.ProblemPath("codeforces", 4, "A"), "solutions", "main.cpp")
+
+	ref, err := resolveSubmitTarget(ws, source, nil)
+	if err != nil {
+		t.Fatalf("resolveSubmitTarget() error = %v", err)
+	}
+	if ref.ContestID != 4 || ref.Index != "A" || ref.IsGym() {
+		t.Errorf("resolveSubmitTarget() = %+v, want contest problem 4A", ref)
+	}
+
+	gymSource := filepath.Join(ws.ProblemPath("codeforces", 102001, "B"), "solutions", "main.cpp")
+	ref, err = resolveSubmitTarget(ws, gymSource, nil)
+	if err != nil {
+		t.Fatalf("resolveSubmitTarget() error = %v", err)
+	}
+	if !ref.IsGym() || ref.ID() != "102001B" {
+		t.Errorf("resolveSubmitTarget() = %+v, want gym problem 102001B", ref)
+	}
+
+	if _, err := resolveSubmitTarget(nil, source, nil); err == nil {
+		t.Error("resolveSubmitTarget() should fail without workspace or args")
+	}
+}
+
+func TestResolveLanguage(t *testing.T) {
+	lang, err := resolveLanguage("main.py", "")
+	if err != nil {
+		t.Fatalf("resolveLanguage() error = %v", err)
+	}
+	if lang.Extension != ".py" {
+		t.Errorf("resolveLanguage() extension = %v, want .py", lang.Extension)
+	}
+
+	lang, err = resolveLanguage("main.py", "pypy3")
+	if err != nil {
+		t.Fatalf("resolveLanguage() error = %v", err)
+	}
+	if lang.ID != "pypy3" {
+		t.Errorf("resolveLanguage() ID = %v, want pypy3", lang.ID)
+	}
+
+	if _, err := resolveLanguage("main.xyz", ""); err == nil {
+		t.Error("resolveLanguage() should fail for unknown extension")
+	}
+	if _, err := resolveLanguage("main.cpp", "nope"); err == nil {
+		t.Error("resolveLanguage() should fail for unknown language ID")
+	}
+}
+
+func TestNewSubmissionRecord(t *testing.T) {
+	lang := cfweb.GetLanguageByID("cpp20")
+	result := &cfweb.SubmissionResult{
+		SubmissionID: 42,
+		Verdict:      "WRONG_ANSWER",
+		Status:       "Judged",
+		Time:         46 * time.Millisecond,
+		Memory:       2048 * 1024,
+	}
+
+	record := newSubmissionRecord(result, 1325, "A", lang, "main.cpp", []byte("int main(){}"))
+
+	if record.ID != 42 || record.ProblemID != "1325A" || record.ContestID != 1325 {
+		t.Errorf("record identity = %d %s %d", record.ID, record.ProblemID, record.ContestID)
+	}
+	if record.Verdict != v1.VerdictWrongAnswer {
+		t.Errorf("Verdict = %v, want WRONG_ANSWER", record.Verdict)
+	}
+	if record.LanguageID != lang.CompilerID {
+		t.Errorf("LanguageID = %d, want %d", record.LanguageID, lang.CompilerID)
+	}
+	if record.TimeUsed != "46 ms" || record.MemoryUsed != "2048 KB" {
+		t.Errorf("usage = %s / %s", record.TimeUsed, record.MemoryUsed)
+	}
+	if len(record.SourceHash) != 64 {
+		t.Errorf("SourceHash length = %d, want 64", len(record.SourceHash))
+	}
+
+	pending := newSubmissionRecord(&cfweb.SubmissionResult{SubmissionID: 1, Status: "In queue"}, 1, "A", lang, "a.cpp", nil)
+	if pending.Verdict != v1.VerdictTesting {
+		t.Errorf("pending Verdict = %v, want TESTING", pending.Verdict)
+	}
+}
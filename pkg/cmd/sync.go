@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/config"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync Codeforces verdicts into the workspace",
+	Long: `Fetch your new submissions and reconcile workspace practice data.
+
+Every new submission to a workspace problem is recorded under submissions/;
+submissions to problems outside the workspace are skipped. Then each
+problem's practice status, attempt count, first attempt, solve time and best
+submission are recomputed from the recorded submissions.
+
+Examples:
+  cf sync`,
+	Args: cobra.NoArgs,
+	RunE: runSync,
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	handle := config.GetCFHandle()
+	if handle == "" {
+		return fmt.Errorf("no CF handle configured. Set with 'cf config set cf_handle <handle>'")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	client := getAPIClient()
+
+	fmt.Printf("Syncing submissions for %s...\n", handle)
+	result, err := client.SyncSubmissions(ctx, handle)
+	if err != nil {
+		return fmt.Errorf("failed to sync submissions: %w", err)
+	}
+	fmt.Printf("✓ %d new submission(s) from Codeforces (%d total)\n", len(result.New), len(result.Log.Submissions))
+
+	records := make([]*v1.Submission, 0, len(result.Log.Submissions))
+	for i := range result.Log.Submissions {
+		records = append(records, syncRecord(&result.Log.Submissions[i]))
+	}
+
+	practice, err := ws.SyncPractice(records)
+	if err != nil {
+		return fmt.Errorf("failed to sync practice: %w", err)
+	}
+
+	if practice.NewSubmissions > 0 {
+		fmt.Printf("✓ Recorded %d submission(s) in the workspace\n", practice.NewSubmissions)
+	}
+	if practice.Skipped > 0 {
+		fmt.Printf("  Skipped %d submission(s) to problems not in the workspace\n", practice.Skipped)
+	}
+
+	if len(practice.Updated) == 0 {
+		fmt.Println("✓ Practice data is up to date")
+		return nil
+	}
+
+	fmt.Printf("✓ Updated %d problem(s):\n", len(practice.Updated))
+	for _, u := range practice.Updated {
+		p := u.Problem
+		status := string(p.Practice.Status)
+		if u.OldStatus != p.Practice.Status {
+			status = fmt.Sprintf("%s → %s", u.OldStatus, p.Practice.Status)
+		}
+		name := p.Name
+		if len(name) > 32 {
+			name = name[:29] + "..."
+		}
+		fmt.Printf("  %-8s %-32s %-22s %d attempt(s)\n", p.ID, name, status, p.Practice.AttemptCount)
+	}
+
+	return nil
+}
+
+// syncRecord converts an API submission to a workspace record, naming its
+// language by cfweb ID the way cf submit does
+func syncRecord(sub *cfapi.Submission) *v1.Submission {
+	record := sub.ToSchemaSubmission()
+	if lang := cfweb.GetLanguageByAPIName(record.Language); lang != nil {
+		record.Language = lang.ID
+		record.LanguageID = lang.CompilerID
+	}
+	return record
+}
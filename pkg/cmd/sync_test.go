@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func TestSyncRecord(t *testing.T) {
+	sub := &cfapi.Submission{
+		ID:                  42,
+		Problem:             cfapi.Problem{ContestID: 1325, Index: "A"},
+		ProgrammingLanguage: "GNU C++17",
+		Verdict:             cfapi.VerdictOK,
+	}
+
+	record := syncRecord(sub)
+	if record.Language != "cpp17" || record.LanguageID != 54 {
+		t.Errorf("language = %s (%d), want cpp17 (54)", record.Language, record.LanguageID)
+	}
+
+	// Languages cf cannot submit in keep the API's name
+	sub.ProgrammingLanguage = "Befunge"
+	if record := syncRecord(sub); record.Language != "Befunge" {
+		t.Errorf("language = %s, want Befunge", record.Language)
+	}
+}
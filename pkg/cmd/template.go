@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// template add flags
+	templateName string
+)
+
+var templateCmd = &cobra.Command{
+	Use:     "template",
+	Aliases: []string{"tmpl"},
+	Short:   "Manage solution templates",
+	Long: `Manage solution templates stored in the workspace templates/ directory.
+
+Templates live at templates/<language>/<name>.<ext>. When a problem is fetched
+or parsed, the "default" template for the workspace's default language is
+copied to solutions/main.<ext> with placeholders filled in:
+
+  {{.Name}}  {{.ID}}  {{.URL}}  {{.ContestID}}  {{.Index}}
+  {{.TimeLimit}}  {{.MemoryLimit}}  {{.Rating}}  {{.Tags}}
+  {{.Handle}}  {{.Date}}`,
+}
+
+var templateListCmd = &cobra.Command{
+	Use:   "list [language]",
+	Short: "List templates",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTemplateList,
+}
+
+var templateAddCmd = &cobra.Command{
+	Use:   "add <language> <file>",
+	Short: "Add or replace a template",
+	Long: `Copy a file into the workspace as a template.
+
+Examples:
+  cf template add cpp ~/cp/template.cpp            # Default C++ template
+  cf template add cpp ~/cp/fast.cpp --name fast    # Named template
+  cf template add python main.py`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTemplateAdd,
+}
+
+var templateRemoveCmd = &cobra.Command{
+	Use:     "remove <language> [name]",
+	Aliases: []string{"rm"},
+	Short:   "Remove a template",
+	Args:    cobra.RangeArgs(1, 2),
+	RunE:    runTemplateRemove,
+}
+
+func init() {
+	templateCmd.AddCommand(templateListCmd)
+	templateCmd.AddCommand(templateAddCmd)
+	templateCmd.AddCommand(templateRemoveCmd)
+
+	templateAddCmd.Flags().StringVar(&templateName, "name", workspace.DefaultTemplateName, "Template name")
+}
+
+func runTemplateList(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	templates, err := ws.ListTemplates()
+	if err != nil {
+		return err
+	}
+
+	defaultLang := ""
+	if m := ws.Manifest(); m != nil {
+		defaultLang = m.Codeforces.DefaultLanguage
+	}
+
+	var shown int
+	fmt.Printf("%-12s %-16s %s\n", "Language", "Name", "File")
+	fmt.Println(strings.Repeat("─", 60))
+	for _, t := range templates {
+		if len(args) == 1 && t.Language != args[0] {
+			continue
+		}
+		marker := ""
+		if t.Language == defaultLang && t.Name == workspace.DefaultTemplateName {
+			marker = "  ★"
+		}
+		fmt.Printf("%-12s %-16s %s%s\n", t.Language, t.Name, filepath.Base(t.Path), marker)
+		shown++
+	}
+
+	if shown == 0 {
+		fmt.Println("No templates. Add one with 'cf template add <language> <file>'")
+	}
+	return nil
+}
+
+func runTemplateAdd(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := ws.AddTemplate(args[0], templateName, args[1])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added template %s/%s (%s)\n", tmpl.Language, tmpl.Name, tmpl.Path)
+	return nil
+}
+
+func runTemplateRemove(cmd *cobra.Command, args []string) error {
+	ws, err := getWorkspace()
+	if err != nil {
+		return err
+	}
+
+	name := ""
+	if len(args) == 2 {
+		name = args[1]
+	}
+
+	if err := ws.RemoveTemplate(args[0], name); err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = workspace.DefaultTemplateName
+	}
+	fmt.Printf("✓ Removed template %s/%s\n", args[0], name)
+	return nil
+}
@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/internal/judge"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// test flags
+	testDir        string
+	testTimeLimit  time.Duration
+	testMemLimit   string
+	testChecker    string
+	testInteractor string
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test [solution-file]",
+	Short: "Run a solution against local tests",
+	Long: `Compile a solution and run it against the problem's local tests.
+
+Each tests/*.in file is fed to the solution and its output is compared with
+the matching .out file, ignoring trailing whitespace. The time and memory
+limits come from problem.yaml unless --time-limit or --memory-limit is given.
+
+On Linux, runs are sandboxed: memory (cgroups v2 when available, rlimits
+otherwise), CPU time and output size are limited and forking is forbidden.
+Each test reports CPU time and peak memory, like Codeforces does.
+
+The checker comes from the problem's "checker" setting in problem.yaml:
+  lines   line by line, ignoring trailing whitespace (default)
+  tokens  whitespace-insensitive token compare
+  float   tokens, numbers within "epsilon" (absolute or relative)
+  yesno   tokens, case-insensitive
+  custom  testlib-style checker at "path", run as: checker input output answer
+
+Interactive problems (marked "interactive: true" in problem.yaml when fetched)
+are judged by an interactor instead: the "interactor" path in problem.yaml, or
+interactor.* in the problem directory. It runs testlib-style as
+"interactor input output [answer]" with its stdin and stdout wired to the
+solution's; exit code 0 accepts. The transcript is shown for failed tests.
+
+If no file is given, solutions/main.* in the current problem directory is used.
+
+Tests besides the samples are managed with "cf test add/edit/list/rm". Tests
+registered without an expected output are run but not compared.
+
+Examples:
+  cf test                          # Test solutions/main.* in this problem
+  cf test solutions/main.cpp       # Test a specific file
+  cf test a.py --tests ./tests     # Use a custom tests directory
+  cf test --time-limit 500ms       # Override the time limit
+  cf test --memory-limit 64MB      # Override the memory limit
+  cf test --checker float          # Override the checker
+  cf test --checker ./check.cpp    # Use a custom checker
+  cf test --interactor ./int.cpp   # Judge interactively`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runTest,
+}
+
+func init() {
+	testCmd.Flags().StringVar(&testDir, "tests", "", "Directory with .in/.out files (default: <problem>/tests)")
+	testCmd.Flags().DurationVar(&testTimeLimit, "time-limit", 0, "Time limit per test (default: from problem.yaml)")
+	testCmd.Flags().StringVar(&testMemLimit, "memory-limit", "", "Memory limit per test, e.g. 256MB (default: from problem.yaml)")
+	testCmd.Flags().StringVar(&testChecker, "checker", "", "Checker type (lines, tokens, float, yesno) or custom checker path")
+	testCmd.Flags().StringVar(&testInteractor, "interactor", "", "Interactor source or executable (enables interactive mode)")
+}
+
+func runTest(cmd *cobra.Command, args []string) error {
+	sourcePath := ""
+	if len(args) == 1 {
+		sourcePath = args[0]
+	} else {
+		found, err := findSolution(".")
+		if err != nil {
+			return err
+		}
+		sourcePath = found
+	}
+
+	if _, err := os.Stat(sourcePath); err != nil {
+		return fmt.Errorf("failed to read solution: %w", err)
+	}
+
+	// Workspace is optional: used to locate tests and the time limit
+	ws, _ := getWorkspace()
+
+	setup := resolveTestSetup(ws, sourcePath)
+	if testDir != "" {
+		setup.TestsPath = testDir
+	}
+	if testTimeLimit > 0 {
+		setup.TimeLimit = testTimeLimit
+	}
+	if testMemLimit != "" {
+		limit, err := judge.ParseMemoryLimit(testMemLimit)
+		if err != nil {
+			return err
+		}
+		setup.MemoryLimit = limit
+	}
+	if testChecker != "" {
+		setup.Checker = parseCheckerFlag(testChecker)
+	}
+	if testInteractor != "" {
+		setup.Interactive = true
+		setup.Interactor = testInteractor
+	}
+	if setup.TestsPath == "" {
+		return fmt.Errorf("cannot find tests for %s; use --tests", sourcePath)
+	}
+
+	tests, err := judge.LoadTests(setup.TestsPath)
+	if err != nil {
+		return fmt.Errorf("failed to load tests: %w", err)
+	}
+	if len(tests) == 0 {
+		return fmt.Errorf("no tests found in %s", setup.TestsPath)
+	}
+	if setup.Problem != nil && testDir == "" {
+		tests = applyTestRegistry(tests, setup.Problem.Tests)
+	}
+
+	ctx := context.Background()
+	opts := setup.options()
+
+	judgedBy := ""
+	if setup.Interactive {
+		if setup.Interactor == "" {
+			return fmt.Errorf("problem is interactive but has no interactor; add interactor.<ext> to the problem directory or use --interactor")
+		}
+		interactor, err := judge.NewInteractor(ctx, setup.Interactor)
+		if err != nil {
+			return fmt.Errorf("failed to create interactor: %w", err)
+		}
+		defer interactor.Cleanup()
+		opts.Interactor = interactor
+		judgedBy = "interactor " + filepath.Base(setup.Interactor)
+	} else {
+		checker, err := judge.NewChecker(ctx, setup.Checker, setup.ProblemDir)
+		if err != nil {
+			return fmt.Errorf("failed to create checker: %w", err)
+		}
+		if c, ok := checker.(*judge.ExternalChecker); ok {
+			defer c.Cleanup()
+		}
+		opts.Checker = checker
+
+		checkerName := string(setup.Checker.Type)
+		if checkerName == "" {
+			checkerName = string(v1.CheckerLines)
+		}
+		judgedBy = "checker " + checkerName
+	}
+
+	prog, err := compileProgram(ctx, cmd, sourcePath)
+	if err != nil {
+		return err
+	}
+	defer prog.Cleanup()
+
+	fmt.Printf("Running %d test(s), time limit %v, memory limit %d MB (%s), %s\n",
+		len(tests), setup.TimeLimit, setup.MemoryLimit>>20, judge.SandboxMode(), judgedBy)
+	fmt.Println(strings.Repeat("─", 50))
+
+	passed := 0
+	for _, test := range tests {
+		result := judge.RunTest(ctx, prog, test, opts)
+		printTestResult(result)
+		if result.Verdict == judge.VerdictOK {
+			passed++
+		}
+	}
+
+	fmt.Println(strings.Repeat("─", 50))
+	if passed == len(tests) {
+		fmt.Printf("✓ All %d tests passed\n", len(tests))
+		return nil
+	}
+
+	fmt.Printf("✗ %d/%d tests passed\n", passed, len(tests))
+	cmd.SilenceUsage = true
+	return fmt.Errorf("%d test(s) failed", len(tests)-passed)
+}
+
+// compileProgram compiles a source file, printing compiler output on failure
+func compileProgram(ctx context.Context, cmd *cobra.Command, sourcePath string) (*judge.Program, error) {
+	fmt.Printf("Compiling %s...\n", filepath.Base(sourcePath))
+	prog, err := judge.Compile(ctx, sourcePath)
+	if err != nil {
+		var compileErr *judge.CompileError
+		if errors.As(err, &compileErr) {
+			fmt.Printf("✗ %s: %s\n", judge.VerdictCompilationError, filepath.Base(sourcePath))
+			fmt.Println(strings.TrimRight(compileErr.Output, "\n"))
+			cmd.SilenceUsage = true
+		}
+		return nil, fmt.Errorf("failed to compile %s: %w", sourcePath, err)
+	}
+	return prog, nil
+}
+
+// findSolution looks for solutions/main.* (or main.*) under dir
+func findSolution(dir string) (string, error) {
+	for _, pattern := range []string{
+		filepath.Join(dir, "solutions", "main.*"),
+		filepath.Join(dir, "main.*"),
+	} {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			if judge.LanguageForFile(match) != nil {
+				return match, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no solution found; pass a solution file")
+}
+
+// testSetup describes where a solution's tests live and how to judge them
+type testSetup struct {
+	ProblemDir  string      // empty outside a workspace
+	Problem     *v1.Problem // nil outside a workspace
+	TestsPath   string
+	TimeLimit   time.Duration
+	MemoryLimit int64 // bytes
+	Checker     v1.CheckerConfig
+
+	Interactive bool
+	Interactor  string // path to the interactor, empty if none was found
+}
+
+// resolveTestSetup finds the tests directory, time limit and checker for a
+// solution. Falls back to a tests directory beside the solution and defaults.
+func resolveTestSetup(ws *workspace.Workspace, sourcePath string) *testSetup {
+	setup := &testSetup{TimeLimit: judge.DefaultTimeLimit, MemoryLimit: judge.DefaultMemoryLimit}
+
+	if ws != nil {
+		if loc, err := ws.LocateProblem(sourcePath); err == nil {
+			setup.ProblemDir = ws.ProblemPath(loc.Platform, loc.ContestID, loc.Index)
+			setup.TestsPath = filepath.Join(setup.ProblemDir, "tests")
+			if problem, err := ws.LoadProblem(loc.Platform, loc.ContestID, loc.Index); err == nil {
+				setup.Problem = problem
+				if limit, err := judge.ParseTimeLimit(problem.Limits.TimeLimit); err == nil {
+					setup.TimeLimit = limit
+				}
+				if limit, err := judge.ParseMemoryLimit(problem.Limits.MemoryLimit); err == nil {
+					setup.MemoryLimit = limit
+				}
+				setup.Checker = problem.Checker
+				setup.Interactive = problem.Interactive
+				setup.Interactor = findInteractor(setup.ProblemDir, problem.Interactor)
+			}
+			return setup
+		}
+	}
+
+	// Outside a workspace, try <dir>/tests and <dir>/../tests
+	dir := filepath.Dir(sourcePath)
+	for _, candidate := range []string{
+		filepath.Join(dir, "tests"),
+		filepath.Join(dir, "..", "tests"),
+	} {
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			setup.TestsPath = candidate
+			break
+		}
+	}
+
+	return setup
+}
+
+// options returns the judge options for running the solution sandboxed
+func (s *testSetup) options() judge.Options {
+	return judge.Options{
+		TimeLimit:   s.TimeLimit,
+		MemoryLimit: s.MemoryLimit,
+		OutputLimit: judge.DefaultOutputLimit,
+		NoFork:      true,
+	}
+}
+
+// findInteractor resolves a problem's interactor: the configured path,
+// relative to the problem directory, or else interactor.* in it
+func findInteractor(problemDir, configured string) string {
+	if configured != "" {
+		if !filepath.IsAbs(configured) {
+			configured = filepath.Join(problemDir, configured)
+		}
+		return configured
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(problemDir, "interactor.*"))
+	for _, match := range matches {
+		if judge.LanguageForFile(match) != nil {
+			return match
+		}
+	}
+	if info, err := os.Stat(filepath.Join(problemDir, "interactor")); err == nil && !info.IsDir() {
+		return filepath.Join(problemDir, "interactor")
+	}
+	return ""
+}
+
+// parseCheckerFlag maps --checker to a checker config; anything that is not
+// a built-in checker type is treated as a custom checker path relative to cwd
+func parseCheckerFlag(value string) v1.CheckerConfig {
+	switch t := v1.CheckerType(value); t {
+	case v1.CheckerLines, v1.CheckerTokens, v1.CheckerFloat, v1.CheckerYesNo:
+		return v1.CheckerConfig{Type: t}
+	}
+	if abs, err := filepath.Abs(value); err == nil {
+		value = abs
+	}
+	return v1.CheckerConfig{Type: v1.CheckerCustom, Path: value}
+}
+
+// printTestResult prints one line per test, plus its details
+func printTestResult(result *judge.TestResult) {
+	icon := "✓"
+	if result.Verdict != judge.VerdictOK {
+		icon = "✗"
+	}
+
+	elapsed := result.CPUTime
+	if elapsed == 0 {
+		elapsed = result.Duration
+	}
+	fmt.Printf("%s %-12s %-4s %6d ms", icon, result.Test.Name, result.Verdict, elapsed.Milliseconds())
+	if result.Memory > 0 {
+		fmt.Printf(" %9s", judge.FormatMemory(result.Memory))
+	}
+	if result.Verdict == judge.VerdictRuntimeError && result.ExitCode != 0 {
+		fmt.Printf("  (exit code %d)", result.ExitCode)
+	}
+	if result.Verdict == judge.VerdictOK && result.Test.AnswerPath == "" {
+		fmt.Print("  (no expected output)")
+	}
+	fmt.Println()
+
+	printResultDetails(result)
+}
+
+// printResultDetails prints the mismatch or the first lines of stderr
+func printResultDetails(result *judge.TestResult) {
+	switch {
+	case result.Mismatch != nil:
+		m := result.Mismatch
+		if m.Line > 0 {
+			fmt.Printf("    line %d:\n", m.Line)
+		}
+		if m.Message != "" {
+			fmt.Printf("    %s\n", m.Message)
+		}
+		if m.Expected != "" || m.Got != "" {
+			fmt.Printf("    expected: %q\n", m.Expected)
+			fmt.Printf("    got:      %q\n", m.Got)
+		}
+	case result.Verdict == judge.VerdictRuntimeError && len(result.Stderr) > 0:
+		stderr := strings.TrimRight(string(result.Stderr), "\n")
+		lines := strings.Split(stderr, "\n")
+		if len(lines) > 5 {
+			lines = lines[:5]
+		}
+		for _, line := range lines {
+			fmt.Printf("    %s\n", line)
+		}
+	}
+
+	if result.Verdict != judge.VerdictOK && len(result.Transcript) > 0 {
+		printTranscript(result.Transcript, 20)
+	}
+}
+
+// printTranscript prints the last lines of an interaction, marking what
+// the solution sent with ">" and what the interactor replied with "<"
+func printTranscript(transcript []judge.Message, limit int) {
+	var lines []string
+	for _, m := range transcript {
+		prefix := "<"
+		if m.From == judge.SideSolution {
+			prefix = ">"
+		}
+		for _, line := range strings.Split(strings.TrimRight(string(m.Data), "\n"), "\n") {
+			lines = append(lines, prefix+" "+line)
+		}
+	}
+
+	fmt.Println("    transcript:")
+	if len(lines) > limit {
+		fmt.Printf("      ... %d earlier lines\n", len(lines)-limit)
+		lines = lines[len(lines)-limit:]
+	}
+	for _, line := range lines {
+		fmt.Printf("      %s\n", line)
+	}
+}
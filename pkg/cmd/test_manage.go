@@ -0,0 +1,408 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+	"github.com/harshit-vibes/cf/pkg/internal/judge"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	// test add/edit/list/rm flags
+	testProblem  string
+	testInput    string
+	testAnswer   string
+	testNoAnswer bool
+	testNote     string
+)
+
+var testAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a custom test",
+	Long: `Add a custom test to the problem's tests/ directory as test_N.in/.out.
+
+The input is read from --input (a file, or - for stdin), from stdin when it
+is piped, or else typed in $EDITOR. The expected output comes from --answer,
+or from $EDITOR after the input; leave it empty to keep it unknown. Tests
+without an expected output are run but not compared. Use --no-answer to skip it.
+
+Tests are registered in problem.yaml together with the samples and the
+failing cases saved by "cf stress".
+
+Examples:
+  cf test add                              # Type input and answer in $EDITOR
+  echo "3 4" | cf test add --answer ans.txt
+  ./gen 42 | cf test add --no-answer       # Run-only test
+  cf test add --input big.txt --note "max n"
+  cf test add -p 1325A --input in.txt      # Outside the problem directory`,
+	Args: cobra.NoArgs,
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runTestAdd,
+}
+
+var testEditCmd = &cobra.Command{
+	Use:   "edit <name>",
+	Short: "Edit a custom test",
+	Long: `Edit a custom or saved stress test in $EDITOR, or replace it with --input
+and --answer. An empty expected output, or --no-answer, makes the test run-only.
+Samples come from the statement and cannot be edited.
+
+Examples:
+  cf test edit test_1
+  cf test edit test_2 --no-answer          # Stop comparing its output
+  cf test edit test_2 --answer fixed.txt`,
+	Args: cobra.ExactArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runTestEdit,
+}
+
+var testListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List a problem's tests",
+	Args:    cobra.NoArgs,
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runTestList,
+}
+
+var testRmCmd = &cobra.Command{
+	Use:     "rm <name>...",
+	Aliases: []string{"remove"},
+	Short:   "Delete custom tests",
+	Args:    cobra.MinimumNArgs(1),
+	Annotations: map[string]string{
+		annotationOffline: "true",
+	},
+	RunE: runTestRm,
+}
+
+func init() {
+	testCmd.AddCommand(testAddCmd, testEditCmd, testListCmd, testRmCmd)
+
+	for _, c := range []*cobra.Command{testAddCmd, testEditCmd, testListCmd, testRmCmd} {
+		c.Flags().StringVarP(&testProblem, "problem", "p", "", "Problem, e.g. 1325A (default: the problem in the current directory)")
+	}
+	for _, c := range []*cobra.Command{testAddCmd, testEditCmd} {
+		c.Flags().StringVar(&testInput, "input", "", "Read the input from a file (- for stdin)")
+		c.Flags().StringVar(&testAnswer, "answer", "", "Read the expected output from a file (- for stdin)")
+		c.Flags().BoolVar(&testNoAnswer, "no-answer", false, "Expected output is unknown: run without comparing")
+	}
+	testAddCmd.Flags().StringVar(&testNote, "note", "", "Short description shown by cf test list")
+}
+
+func runTestAdd(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadTestProblem()
+	if err != nil {
+		return err
+	}
+	if testAnswer != "" && testNoAnswer {
+		return fmt.Errorf("--answer and --no-answer cannot be used together")
+	}
+
+	input, from, err := readTestInput(testInput, nil)
+	if err != nil {
+		return err
+	}
+	answer, err := readTestAnswer(from, nil)
+	if err != nil {
+		return err
+	}
+
+	entry, err := ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceCustom, Note: testNote}, input, answer)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Added %s (%s input", entry.Name, formatBytes(int64(len(input))))
+	if entry.NoAnswer {
+		fmt.Print(", run only")
+	}
+	fmt.Println(")")
+	return nil
+}
+
+func runTestEdit(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadTestProblem()
+	if err != nil {
+		return err
+	}
+	if testAnswer != "" && testNoAnswer {
+		return fmt.Errorf("--answer and --no-answer cannot be used together")
+	}
+
+	name := args[0]
+	if err := workspace.ValidateTestName(name); err != nil {
+		return err
+	}
+	if entry := problem.FindTest(name); entry != nil && entry.Source == v1.TestSourceSample {
+		return fmt.Errorf("test %s is a sample from the statement; add a custom test instead", name)
+	}
+
+	dir := ws.TestsPath(problem)
+	oldInput, err := os.ReadFile(filepath.Join(dir, name+".in"))
+	if err != nil {
+		return fmt.Errorf("test %s not found", name)
+	}
+	oldAnswer, err := os.ReadFile(filepath.Join(dir, name+".out"))
+	if err != nil {
+		oldAnswer = nil
+	}
+
+	// Only the answer changes when just --answer or --no-answer is given
+	input, from := oldInput, ""
+	if testInput != "" || (testAnswer == "" && !testNoAnswer) {
+		if input, from, err = readTestInput(testInput, oldInput); err != nil {
+			return err
+		}
+	}
+	answer := oldAnswer
+	if testAnswer != "" || testNoAnswer || from == inputFromEditor {
+		if answer, err = readTestAnswer(from, oldAnswer); err != nil {
+			return err
+		}
+	}
+
+	if err := ws.UpdateTest(problem, name, input, answer); err != nil {
+		return err
+	}
+
+	if answer == nil {
+		fmt.Printf("✓ Updated %s (run only)\n", name)
+	} else {
+		fmt.Printf("✓ Updated %s\n", name)
+	}
+	return nil
+}
+
+func runTestList(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadTestProblem()
+	if err != nil {
+		return err
+	}
+
+	tests, err := judge.LoadTests(ws.TestsPath(problem))
+	if err != nil {
+		return fmt.Errorf("failed to load tests: %w", err)
+	}
+	tests = applyTestRegistry(tests, problem.Tests)
+	if len(tests) == 0 {
+		fmt.Println("No tests. Add one with: cf test add")
+		return nil
+	}
+
+	fmt.Printf("Tests for %d%s\n\n", problem.ContestID, problem.Index)
+	fmt.Printf("%-12s %-8s %10s %10s  %s\n", "Name", "Source", "Input", "Answer", "Note")
+	fmt.Println(strings.Repeat("─", 60))
+	for _, test := range tests {
+		source, note := "-", ""
+		if entry := problem.FindTest(test.Name); entry != nil {
+			source, note = string(entry.Source), entry.Note
+		}
+
+		answer := "unknown"
+		if test.AnswerPath != "" {
+			answer = fileSize(test.AnswerPath)
+		}
+		fmt.Printf("%-12s %-8s %10s %10s  %s\n", test.Name, source, fileSize(test.InputPath), answer, note)
+	}
+	return nil
+}
+
+func runTestRm(cmd *cobra.Command, args []string) error {
+	ws, problem, err := loadTestProblem()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		if err := ws.RemoveTest(problem, name); err != nil {
+			return err
+		}
+		fmt.Printf("✓ Removed %s\n", name)
+	}
+	return nil
+}
+
+// loadTestProblem loads the problem named by --problem, or the one
+// containing the current directory
+func loadTestProblem() (*workspace.Workspace, *v1.Problem, error) {
+	ws, err := getWorkspace()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var contestID int
+	var index string
+	if testProblem != "" {
+		ref, err := cfweb.ParseProblemRef(testProblem)
+		if err != nil {
+			return nil, nil, err
+		}
+		contestID, index = ref.ContestID, ref.Index
+	} else {
+		loc, err := ws.LocateProblem(".")
+		if err != nil {
+			return nil, nil, fmt.Errorf("not in a problem directory; use --problem")
+		}
+		contestID, index = loc.ContestID, loc.Index
+	}
+
+	problem, err := ws.LoadProblem("codeforces", contestID, index)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ws, problem, nil
+}
+
+// applyTestRegistry drops the expected output of tests registered as
+// run-only, so they are not compared even if an answer file exists
+func applyTestRegistry(tests []judge.TestCase, registry []v1.TestEntry) []judge.TestCase {
+	noAnswer := make(map[string]bool)
+	for _, entry := range registry {
+		if entry.NoAnswer {
+			noAnswer[entry.Name] = true
+		}
+	}
+	for i := range tests {
+		if noAnswer[tests[i].Name] {
+			tests[i].AnswerPath = ""
+		}
+	}
+	return tests
+}
+
+// Where readTestInput got a test input from
+const (
+	inputFromFile   = "file"
+	inputFromStdin  = "stdin"
+	inputFromEditor = "editor"
+)
+
+// readTestInput reads a test input from path (- for stdin), from piped
+// stdin, or else from $EDITOR starting with initial. It also returns
+// where the input came from.
+func readTestInput(path string, initial []byte) ([]byte, string, error) {
+	switch {
+	case path == "-" || (path == "" && !term.IsTerminal(os.Stdin.Fd())):
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return data, inputFromStdin, nil
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return data, inputFromFile, nil
+	}
+
+	data, err := editText("test input", "input", initial)
+	if err != nil {
+		return nil, "", err
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return nil, "", fmt.Errorf("empty input, test not saved")
+	}
+	return data, inputFromEditor, nil
+}
+
+// readTestAnswer reads the expected output per --answer and --no-answer.
+// Without either, it is typed in $EDITOR when the input was, and unknown
+// otherwise. A nil answer means unknown.
+func readTestAnswer(inputFrom string, initial []byte) ([]byte, error) {
+	switch {
+	case testNoAnswer:
+		return nil, nil
+	case testAnswer == "-":
+		if inputFrom == inputFromStdin {
+			return nil, fmt.Errorf("input and answer cannot both come from stdin")
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read stdin: %w", err)
+		}
+		return data, nil
+	case testAnswer != "":
+		data, err := os.ReadFile(testAnswer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read answer: %w", err)
+		}
+		return data, nil
+	case inputFrom != inputFromEditor:
+		return nil, nil
+	}
+
+	data, err := editText("test answer", "answer", initial)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(string(data)) == "" {
+		return nil, nil
+	}
+	return data, nil
+}
+
+// editText opens $VISUAL or $EDITOR (vi by default) on a temporary file
+// with extension ext holding initial and returns what was saved. label
+// names what is being edited.
+func editText(label, ext string, initial []byte) ([]byte, error) {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	f, err := os.CreateTemp("", "cf-*."+ext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(initial); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	f.Close()
+
+	// $EDITOR may carry arguments, e.g. "code --wait"
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], f.Name())...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	fmt.Printf("Editing %s in %s...\n", label, fields[0])
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run editor: %w", err)
+	}
+
+	data, err := os.ReadFile(f.Name())
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return data, nil
+}
+
+// fileSize formats the size of a file, or "-" if it cannot be read
+func fileSize(path string) string {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "-"
+	}
+	return formatBytes(info.Size())
+}
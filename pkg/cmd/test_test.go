@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/judge"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+func TestFindSolution(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := findSolution(dir); err == nil {
+		t.Error("findSolution() should fail without a solution")
+	}
+
+	os.MkdirAll(filepath.Join(dir, "solutions"), 0755)
+	os.WriteFile(filepath.Join(dir, "solutions", "main.txt"), nil, 0644)
+	os.WriteFile(filepath.Join(dir, "solutions", "main.py"), nil, 0644)
+
+	got, err := findSolution(dir)
+	if err != nil {
+		t.Fatalf("findSolution() error = %v", err)
+	}
+	if filepath.Base(got) != "main.py" {
+		t.Errorf("findSolution() = %v, want main.py", got)
+	}
+}
+
+func TestResolveTestSetup(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "Test")
+	problem.Limits.TimeLimit = "1 second"
+	problem.Limits.MemoryLimit = "64 megabytes"
+	problem.Checker = v1.CheckerConfig{Type: v1.CheckerFloat, Epsilon: 1e-9}
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	problemDir := ws.ProblemPath("codeforces", 1325, "A")
+	setup := resolveTestSetup(ws, filepath.Join(problemDir, "solutions", "main.cpp"))
+	if setup.TestsPath != filepath.Join(problemDir, "tests") {
+		t.Errorf("tests path = %v", setup.TestsPath)
+	}
+	if setup.TimeLimit != time.Second {
+		t.Errorf("time limit = %v, want 1s", setup.TimeLimit)
+	}
+	if setup.MemoryLimit != 64<<20 {
+		t.Errorf("memory limit = %d, want 64 MB", setup.MemoryLimit)
+	}
+	if setup.Checker.Type != v1.CheckerFloat || setup.Checker.Epsilon != 1e-9 {
+		t.Errorf("checker = %+v, want float 1e-9", setup.Checker)
+	}
+
+	setup = resolveTestSetup(nil, filepath.Join(t.TempDir(), "a.cpp"))
+	if setup.TestsPath != "" || setup.TimeLimit != judge.DefaultTimeLimit || setup.MemoryLimit != judge.DefaultMemoryLimit {
+		t.Errorf("resolveTestSetup() = %+v; want no tests and default limit", setup)
+	}
+}
+
+func TestResolveTestSetup_Interactive(t *testing.T) {
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1520, "F1", "Guess the K-th Zero")
+	problem.Interactive = true
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	problemDir := ws.ProblemPath("codeforces", 1520, "F1")
+	solution := filepath.Join(problemDir, "solutions", "main.cpp")
+
+	setup := resolveTestSetup(ws, solution)
+	if !setup.Interactive || setup.Interactor != "" {
+		t.Errorf("without interactor: %+v", setup)
+	}
+
+	os.WriteFile(filepath.Join(problemDir, "interactor.txt"), nil, 0644)
+	os.WriteFile(filepath.Join(problemDir, "interactor.cpp"), nil, 0644)
+	setup = resolveTestSetup(ws, solution)
+	if setup.Interactor != filepath.Join(problemDir, "interactor.cpp") {
+		t.Errorf("interactor = %q, want interactor.cpp", setup.Interactor)
+	}
+
+	problem.Interactor = "tools/int.py"
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	setup = resolveTestSetup(ws, solution)
+	if setup.Interactor != filepath.Join(problemDir, "tools", "int.py") {
+		t.Errorf("configured interactor = %q", setup.Interactor)
+	}
+}
+
+func TestParseCheckerFlag(t *testing.T) {
+	if got := parseCheckerFlag("tokens"); got.Type != v1.CheckerTokens {
+		t.Errorf("parseCheckerFlag(tokens) = %+v", got)
+	}
+	got := parseCheckerFlag("./check.cpp")
+	if got.Type != v1.CheckerCustom || !filepath.IsAbs(got.Path) || filepath.Base(got.Path) != "check.cpp" {
+		t.Errorf("parseCheckerFlag(./check.cpp) = %+v", got)
+	}
+}
+
+func TestApplyTestRegistry(t *testing.T) {
+	tests := []judge.TestCase{
+		{Name: "sample_1", InputPath: "sample_1.in", AnswerPath: "sample_1.out"},
+		{Name: "test_1", InputPath: "test_1.in", AnswerPath: "test_1.out"},
+		{Name: "test_2", InputPath: "test_2.in", AnswerPath: "test_2.out"},
+	}
+	registry := []v1.TestEntry{
+		{Name: "sample_1", Source: v1.TestSourceSample},
+		{Name: "test_1", Source: v1.TestSourceCustom, NoAnswer: true},
+	}
+
+	got := applyTestRegistry(tests, registry)
+	want := []string{"sample_1.out", "", "test_2.out"}
+	for i, test := range got {
+		if test.AnswerPath != want[i] {
+			t.Errorf("%s AnswerPath = %q, want %q", test.Name, test.AnswerPath, want[i])
+		}
+	}
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -95,8 +97,58 @@ func getHandle(args []string) (string, error) {
 	return handle, nil
 }
 
+var (
+	apiClient     *cfapi.Client
+	apiClientOnce sync.Once
+)
+
+// getAPIClient returns the shared API client, backed by the disk cache
+// under ~/.cf/cache when it is available
 func getAPIClient() *cfapi.Client {
-	return cfapi.NewClient()
+	apiClientOnce.Do(func() {
+		apiClient = cfapi.NewClient(apiClientOptions()...)
+	})
+	return apiClient
+}
+
+// apiClientOptions returns the persistent stores available to API clients
+func apiClientOptions() []cfapi.ClientOption {
+	var opts []cfapi.ClientOption
+	if disk, err := getDiskCache(); err == nil {
+		opts = append(opts, cfapi.WithDiskCache(disk))
+	}
+	if store, err := getSubmissionStore(); err == nil {
+		opts = append(opts, cfapi.WithSubmissionStore(store))
+	}
+	return opts
+}
+
+// getSubmissionStore opens the local submission history (~/.cf/data/submissions)
+func getSubmissionStore() (*cfapi.SubmissionStore, error) {
+	dir, err := config.DataDir()
+	if err != nil {
+		return nil, err
+	}
+	return cfapi.NewSubmissionStore(filepath.Join(dir, "submissions"))
+}
+
+// getDiskCache opens the API response cache
+func getDiskCache() (*cfapi.DiskCache, error) {
+	dir, err := config.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return cfapi.NewDiskCache(filepath.Join(dir, "api"))
+}
+
+// flushAPIClient gives background cache refreshes a moment to finish
+func flushAPIClient() {
+	if apiClient == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	apiClient.Flush(ctx)
 }
 
 func runUserInfo(cmd *cobra.Command, args []string) error {
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"golang.org/x/time/rate"
@@ -27,6 +28,16 @@ type Client struct {
 	httpClient *http.Client
 	limiter    *rate.Limiter
 	cache      *Cache
+
+	// Optional persistent tier for raw responses
+	disk         ResponseCache
+	policies     map[string]CachePolicy
+	revalidating map[string]bool
+	revalidateMu sync.Mutex
+	revalidateWg sync.WaitGroup
+
+	// Optional local submission history for incremental sync
+	store *SubmissionStore
 }
 
 // ClientOption configures the client
@@ -46,12 +57,38 @@ func WithCacheTTL(ttl time.Duration) ClientOption {
 	}
 }
 
+// WithDiskCache adds a persistent response cache using DefaultCachePolicies
+func WithDiskCache(cache ResponseCache) ClientOption {
+	return func(c *Client) {
+		c.disk = cache
+	}
+}
+
+// WithCachePolicy overrides the disk cache policy for one API method.
+// A zero TTL disables disk caching for that method.
+func WithCachePolicy(method string, policy CachePolicy) ClientOption {
+	return func(c *Client) {
+		c.policies[method] = policy
+	}
+}
+
+// WithSubmissionStore enables incremental submission sync backed by store
+func WithSubmissionStore(store *SubmissionStore) ClientOption {
+	return func(c *Client) {
+		c.store = store
+	}
+}
+
 // NewClient creates a new Codeforces API client
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
 		httpClient: &http.Client{Timeout: DefaultTimeout},
 		limiter:    rate.NewLimiter(rate.Limit(RateLimit), 1),
 		cache:      NewCache(DefaultTTL),
+		policies:   make(map[string]CachePolicy, len(DefaultCachePolicies)),
+	}
+	for method, policy := range DefaultCachePolicies {
+		c.policies[method] = policy
 	}
 
 	for _, opt := range opts {
@@ -61,8 +98,113 @@ func NewClient(opts ...ClientOption) *Client {
 	return c
 }
 
-// request makes an API request with rate limiting
+// request makes an API request, served from the disk cache when possible
 func (c *Client) request(ctx context.Context, method string, params url.Values) ([]byte, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+
+	policy, cacheable := c.policies[method]
+	if c.disk == nil || !cacheable || policy.TTL <= 0 {
+		return c.fetch(ctx, method, params)
+	}
+
+	key := method + "?" + params.Encode()
+	cached, ok := c.disk.Get(key)
+	if ok {
+		age := cached.Age()
+		if age < policy.TTL {
+			return cached.Body, nil
+		}
+		if age < policy.TTL+policy.StaleWhileRevalidate {
+			c.revalidate(key, method, params)
+			return cached.Body, nil
+		}
+	}
+
+	body, err := c.fetchAndStore(ctx, key, method, params)
+	if err != nil {
+		// Stale-if-error: the last good copy beats no answer (e.g. offline)
+		if ok {
+			return cached.Body, nil
+		}
+		return nil, err
+	}
+	return body, nil
+}
+
+// fetchAndStore fetches a response and saves it to the disk cache if the
+// API reported success
+func (c *Client) fetchAndStore(ctx context.Context, key, method string, params url.Values) ([]byte, error) {
+	body, err := c.fetch(ctx, method, params)
+	if err != nil {
+		return nil, err
+	}
+
+	var status struct {
+		Status string `json:"status"`
+	}
+	if json.Unmarshal(body, &status) == nil && status.Status == "OK" {
+		// A failed write only costs a refetch next time
+		_ = c.disk.Set(&CachedResponse{
+			Key:      key,
+			Method:   method,
+			StoredAt: time.Now(),
+			Body:     body,
+		})
+	}
+
+	return body, nil
+}
+
+// revalidate refreshes a stale disk cache entry in the background.
+// Concurrent revalidations of the same key are collapsed.
+func (c *Client) revalidate(key, method string, params url.Values) {
+	c.revalidateMu.Lock()
+	if c.revalidating == nil {
+		c.revalidating = make(map[string]bool)
+	}
+	if c.revalidating[key] {
+		c.revalidateMu.Unlock()
+		return
+	}
+	c.revalidating[key] = true
+	c.revalidateMu.Unlock()
+
+	c.revalidateWg.Add(1)
+	go func() {
+		defer c.revalidateWg.Done()
+		defer func() {
+			c.revalidateMu.Lock()
+			delete(c.revalidating, key)
+			c.revalidateMu.Unlock()
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultTimeout)
+		defer cancel()
+		c.fetchAndStore(ctx, key, method, params)
+	}()
+}
+
+// Flush waits for background cache revalidations to finish, or for ctx
+// to be done. Call it before exiting so refreshed responses are saved.
+func (c *Client) Flush(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		c.revalidateWg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetch makes an API request over the network with rate limiting
+func (c *Client) fetch(ctx context.Context, method string, params url.Values) ([]byte, error) {
 	// Wait for rate limiter
 	if err := c.limiter.Wait(ctx); err != nil {
 		return nil, fmt.Errorf("rate limit: %w", err)
@@ -71,10 +213,6 @@ func (c *Client) request(ctx context.Context, method string, params url.Values)
 	// Build URL
 	u := fmt.Sprintf("%s/%s", BaseURL, method)
 
-	if params == nil {
-		params = url.Values{}
-	}
-
 	fullURL := u + "?" + params.Encode()
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fullURL, nil)
@@ -203,6 +341,43 @@ func (c *Client) GetUserSubmissions(ctx context.Context, handle string, from, co
 	return resp.Result, nil
 }
 
+// GetSubmissionStatus retrieves the current state of one of a user's
+// submissions, bypassing all caches since it is polled while judging.
+// The latest submission is checked first (count=1); a few more are
+// searched only if the user has submitted again since.
+func (c *Client) GetSubmissionStatus(ctx context.Context, handle string, submissionID int64) (*Submission, error) {
+	for _, count := range []int{1, 20} {
+		params := url.Values{}
+		params.Set("handle", handle)
+		params.Set("from", "1")
+		params.Set("count", strconv.Itoa(count))
+
+		body, err := c.fetch(ctx, "user.status", params)
+		if err != nil {
+			return nil, err
+		}
+
+		var resp Response[[]Submission]
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("parse response: %w", err)
+		}
+		if resp.Status != "OK" {
+			return nil, fmt.Errorf("api error: %s", resp.Comment)
+		}
+
+		for i := range resp.Result {
+			if resp.Result[i].ID == submissionID {
+				return &resp.Result[i], nil
+			}
+		}
+		if len(resp.Result) < count {
+			break
+		}
+	}
+
+	return nil, fmt.Errorf("submission %d not found in recent submissions of %s", submissionID, handle)
+}
+
 // GetUserRating retrieves rating history for a user
 func (c *Client) GetUserRating(ctx context.Context, handle string) ([]RatingChange, error) {
 	cacheKey := "rating:" + handle
@@ -343,7 +518,7 @@ func (c *Client) GetProblem(ctx context.Context, contestID int, index string) (*
 
 // GetSolvedProblems returns all problems solved by a user
 func (c *Client) GetSolvedProblems(ctx context.Context, handle string) ([]Problem, error) {
-	submissions, err := c.GetUserSubmissions(ctx, handle, 1, 10000)
+	submissions, err := c.GetAllSubmissions(ctx, handle)
 	if err != nil {
 		return nil, err
 	}
@@ -414,7 +589,8 @@ func (c *Client) Ping(ctx context.Context) error {
 	return err
 }
 
-// ClearCache clears the API cache
+// ClearCache clears the in-memory API cache. The disk cache is left intact;
+// use a client without WithDiskCache when fresh data is required.
 func (c *Client) ClearCache() {
 	c.cache.Clear()
 }
@@ -0,0 +1,100 @@
+package cfapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"time"
+)
+
+// DailyPoolLag keeps recent contests out of the daily pool: their problems
+// are still being rated, which would change the pool during the day
+const DailyPoolLag = 30 * 24 * time.Hour
+
+// DailyOptions controls the daily challenge pick
+type DailyOptions struct {
+	Seed      string // team seed; everyone using the same seed gets the same problem
+	MinRating int
+	MaxRating int
+}
+
+// DailyKey returns the daily date key (UTC) for a time, e.g. "2024-03-11"
+func DailyKey(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// PickDaily deterministically picks the problem of the day. Problems in the
+// rating range from contests that started at least DailyPoolLag before date
+// are ordered by a hash of seed, date and problem ID; the first one not in
+// solved is picked, so teammates get the same problem unless they already
+// solved it. contestStart maps contest IDs to start times.
+func PickDaily(problems []Problem, contestStart map[int]time.Time, date string, opts DailyOptions, solved map[string]bool) (*Problem, error) {
+	day, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date %q: %w", date, err)
+	}
+	cutoff := day.Add(-DailyPoolLag)
+
+	var best *Problem
+	var bestKey uint64
+	for i := range problems {
+		p := &problems[i]
+		if p.Rating == 0 || (opts.MinRating > 0 && p.Rating < opts.MinRating) || (opts.MaxRating > 0 && p.Rating > opts.MaxRating) {
+			continue
+		}
+		if start, ok := contestStart[p.ContestID]; !ok || !start.Before(cutoff) {
+			continue
+		}
+		if solved[p.ProblemID()] || hasTag(p.Tags, "*special") {
+			continue
+		}
+
+		key := dailyHash(opts.Seed, date, p.ProblemID())
+		if best == nil || key < bestKey || (key == bestKey && p.ProblemID() < best.ProblemID()) {
+			best, bestKey = p, key
+		}
+	}
+
+	if best == nil {
+		return nil, fmt.Errorf("no unsolved problems rated %d-%d for %s", opts.MinRating, opts.MaxRating, date)
+	}
+	return best, nil
+}
+
+// PickDaily picks the problem of the day for date, excluding problems
+// solved by handle (if not empty)
+func (c *Client) PickDaily(ctx context.Context, date string, handle string, opts DailyOptions) (*Problem, error) {
+	problems, err := c.GetProblems(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get problems: %w", err)
+	}
+	contests, err := c.GetContests(ctx, false)
+	if err != nil {
+		return nil, fmt.Errorf("get contests: %w", err)
+	}
+
+	contestStart := make(map[int]time.Time, len(contests))
+	for _, contest := range contests {
+		contestStart[contest.ID] = contest.StartTime()
+	}
+
+	solved := make(map[string]bool)
+	if handle != "" {
+		list, err := c.GetSolvedProblems(ctx, handle)
+		if err != nil {
+			return nil, fmt.Errorf("get solved problems: %w", err)
+		}
+		for _, p := range list {
+			solved[p.ProblemID()] = true
+		}
+	}
+
+	return PickDaily(problems.Problems, contestStart, date, opts, solved)
+}
+
+// dailyHash orders problems for a seed and date
+func dailyHash(seed, date, problemID string) uint64 {
+	sum := sha256.Sum256([]byte(seed + "|" + date + "|" + problemID))
+	return binary.BigEndian.Uint64(sum[:8])
+}
@@ -0,0 +1,122 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func dailyPool() ([]Problem, map[int]time.Time) {
+	old := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var problems []Problem
+	starts := map[int]time.Time{}
+	for i := 1; i <= 50; i++ {
+		problems = append(problems, Problem{ContestID: i, Index: "A", Rating: 800 + (i%8)*100})
+		starts[i] = old
+	}
+	return problems, starts
+}
+
+func TestPickDaily_Deterministic(t *testing.T) {
+	problems, starts := dailyPool()
+	opts := DailyOptions{Seed: "team", MinRating: 1000, MaxRating: 1300}
+
+	first, err := PickDaily(problems, starts, "2024-03-11", opts, nil)
+	if err != nil {
+		t.Fatalf("PickDaily() error = %v", err)
+	}
+	if first.Rating < 1000 || first.Rating > 1300 {
+		t.Errorf("picked rating %d outside 1000-1300", first.Rating)
+	}
+
+	// Same inputs, shuffled pool: same pick
+	shuffled := append([]Problem(nil), problems...)
+	for i, j := 0, len(shuffled)-1; i < j; i, j = i+1, j-1 {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	}
+	again, _ := PickDaily(shuffled, starts, "2024-03-11", opts, nil)
+	if again.ProblemID() != first.ProblemID() {
+		t.Errorf("pick changed with pool order: %s vs %s", again.ProblemID(), first.ProblemID())
+	}
+
+	// A solved pick is skipped for that user only
+	next, _ := PickDaily(problems, starts, "2024-03-11", opts, map[string]bool{first.ProblemID(): true})
+	if next.ProblemID() == first.ProblemID() {
+		t.Error("PickDaily() picked a solved problem")
+	}
+
+	// Other days and seeds vary
+	picks := map[string]bool{}
+	for day := 1; day <= 10; day++ {
+		p, _ := PickDaily(problems, starts, fmt.Sprintf("2024-03-%02d", day), opts, nil)
+		picks[p.ProblemID()] = true
+	}
+	if len(picks) < 3 {
+		t.Errorf("10 days gave only %d distinct problems", len(picks))
+	}
+}
+
+func TestPickDaily_Pool(t *testing.T) {
+	problems := []Problem{
+		{ContestID: 1, Index: "A", Rating: 1200},                             // too recent
+		{ContestID: 2, Index: "A", Rating: 1200, Tags: []string{"*special"}}, // special
+		{ContestID: 3, Index: "A", Rating: 0},                                // unrated
+		{ContestID: 4, Index: "A", Rating: 1200},                             // unknown contest
+		{ContestID: 5, Index: "A", Rating: 1200},
+	}
+	day := time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)
+	starts := map[int]time.Time{
+		1: day.Add(-DailyPoolLag + time.Hour),
+		2: day.AddDate(-1, 0, 0),
+		3: day.AddDate(-1, 0, 0),
+		5: day.AddDate(-1, 0, 0),
+	}
+
+	p, err := PickDaily(problems, starts, "2024-03-11", DailyOptions{}, nil)
+	if err != nil || p.ProblemID() != "5A" {
+		t.Errorf("PickDaily() = %v, %v; want 5A", p, err)
+	}
+
+	if _, err := PickDaily(problems, starts, "2024-03-11", DailyOptions{}, map[string]bool{"5A": true}); err == nil {
+		t.Error("PickDaily() should fail when nothing is left")
+	}
+	if _, err := PickDaily(problems, starts, "11/03/2024", DailyOptions{}, nil); err == nil {
+		t.Error("PickDaily() should reject a malformed date")
+	}
+}
+
+func TestClient_PickDaily(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			// GetProblems
+			{statusCode: 200, body: `{"status":"OK","result":{"problems":[
+				{"contestId":1,"index":"A","rating":1000},
+				{"contestId":1,"index":"B","rating":1100}
+			],"problemStatistics":[]}}`},
+			// GetContests
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":1,"startTimeSeconds":1500000000}]}`},
+			// GetSolvedProblems
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":1,"verdict":"OK","problem":{"contestId":1,"index":"A"}}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	p, err := client.PickDaily(context.Background(), "2024-03-11", "tourist", DailyOptions{})
+	if err != nil {
+		t.Fatalf("PickDaily() error = %v", err)
+	}
+	if p.ProblemID() != "1B" {
+		t.Errorf("PickDaily() = %s, want the unsolved 1B", p.ProblemID())
+	}
+}
+
+func TestDailyKey(t *testing.T) {
+	at := time.Date(2024, 3, 11, 23, 30, 0, 0, time.FixedZone("UTC-5", -5*3600))
+	if got := DailyKey(at); got != "2024-03-12" {
+		t.Errorf("DailyKey() = %s, want the UTC date 2024-03-12", got)
+	}
+}
@@ -0,0 +1,259 @@
+package cfapi
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// CachePolicy controls how long responses of one API method are reused.
+// Entries younger than TTL are fresh. Entries older than TTL but within
+// StaleWhileRevalidate are served immediately while a refresh runs in the
+// background. Older entries are refetched, but still served if the request
+// fails (e.g. offline).
+type CachePolicy struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+}
+
+// DefaultCachePolicies are the per-method policies used by the disk cache.
+// Methods not listed (e.g. contest.standings) are never cached on disk.
+var DefaultCachePolicies = map[string]CachePolicy{
+	"problemset.problems": {TTL: 24 * time.Hour, StaleWhileRevalidate: 7 * 24 * time.Hour},
+	"contest.list":        {TTL: time.Hour, StaleWhileRevalidate: 24 * time.Hour},
+	"user.info":           {TTL: 10 * time.Minute, StaleWhileRevalidate: time.Hour},
+	"user.rating":         {TTL: time.Hour, StaleWhileRevalidate: 24 * time.Hour},
+	"user.status":         {TTL: 5 * time.Minute},
+}
+
+// CachedResponse is a raw API response stored by a ResponseCache
+type CachedResponse struct {
+	Key      string
+	Method   string
+	StoredAt time.Time
+	Body     []byte
+}
+
+// Age returns how long ago the response was stored
+func (r *CachedResponse) Age() time.Duration {
+	return time.Since(r.StoredAt)
+}
+
+// CacheStats summarizes a response cache
+type CacheStats struct {
+	Entries   int
+	SizeBytes int64
+	Methods   map[string]MethodStats
+}
+
+// MethodStats summarizes cached responses for one API method
+type MethodStats struct {
+	Entries   int
+	SizeBytes int64
+	Oldest    time.Time
+	Newest    time.Time
+}
+
+// ResponseCache stores raw API responses across client instances.
+// Get returns entries regardless of age; freshness is decided by the client.
+type ResponseCache interface {
+	Get(key string) (*CachedResponse, bool)
+	Set(resp *CachedResponse) error
+	Clear(method string) (int, error) // empty method clears everything
+	Stats() (*CacheStats, error)
+}
+
+// DiskCache is a ResponseCache backed by one file per response
+type DiskCache struct {
+	dir string
+}
+
+// diskCacheHeader is the first line of a cache file
+type diskCacheHeader struct {
+	Key      string    `json:"key"`
+	Method   string    `json:"method"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// NewDiskCache creates a disk cache rooted at dir, creating it if needed
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Dir returns the cache directory
+func (d *DiskCache) Dir() string {
+	return d.dir
+}
+
+func (d *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.dir, hex.EncodeToString(sum[:16])+".cache")
+}
+
+// Get implements ResponseCache
+func (d *DiskCache) Get(key string) (*CachedResponse, bool) {
+	f, err := os.Open(d.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	header, err := readDiskCacheHeader(r)
+	if err != nil || header.Key != key {
+		return nil, false
+	}
+
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false
+	}
+
+	return &CachedResponse{
+		Key:      header.Key,
+		Method:   header.Method,
+		StoredAt: header.StoredAt,
+		Body:     body,
+	}, true
+}
+
+// Set implements ResponseCache. The file is written atomically so readers
+// never see a partial response.
+func (d *DiskCache) Set(resp *CachedResponse) error {
+	header, err := json.Marshal(diskCacheHeader{
+		Key:      resp.Key,
+		Method:   resp.Method,
+		StoredAt: resp.StoredAt,
+	})
+	if err != nil {
+		return fmt.Errorf("marshal cache header: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(d.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create cache file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	w.Write(header)
+	w.WriteByte('\n')
+	w.Write(resp.Body)
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write cache file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write cache file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), d.path(resp.Key)); err != nil {
+		return fmt.Errorf("store cache file: %w", err)
+	}
+	return nil
+}
+
+// Clear implements ResponseCache
+func (d *DiskCache) Clear(method string) (int, error) {
+	removed := 0
+	err := d.walk(func(path string, header *diskCacheHeader, _ int64) error {
+		if method != "" && header.Method != method {
+			return nil
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("remove cache file: %w", err)
+		}
+		removed++
+		return nil
+	})
+	return removed, err
+}
+
+// Stats implements ResponseCache
+func (d *DiskCache) Stats() (*CacheStats, error) {
+	stats := &CacheStats{Methods: make(map[string]MethodStats)}
+
+	err := d.walk(func(_ string, header *diskCacheHeader, size int64) error {
+		stats.Entries++
+		stats.SizeBytes += size
+
+		m := stats.Methods[header.Method]
+		m.Entries++
+		m.SizeBytes += size
+		if m.Oldest.IsZero() || header.StoredAt.Before(m.Oldest) {
+			m.Oldest = header.StoredAt
+		}
+		if header.StoredAt.After(m.Newest) {
+			m.Newest = header.StoredAt
+		}
+		stats.Methods[header.Method] = m
+		return nil
+	})
+
+	return stats, err
+}
+
+// walk calls fn for every readable cache file, reading only its header
+func (d *DiskCache) walk(fn func(path string, header *diskCacheHeader, size int64) error) error {
+	entries, err := os.ReadDir(d.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read cache dir: %w", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".cache") {
+			continue
+		}
+
+		path := filepath.Join(d.dir, entry.Name())
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		header, err := readDiskCacheHeader(bufio.NewReader(f))
+		f.Close()
+		if err != nil {
+			continue
+		}
+
+		if err := fn(path, header, info.Size()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func readDiskCacheHeader(r *bufio.Reader) (*diskCacheHeader, error) {
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		return nil, fmt.Errorf("read cache header: %w", err)
+	}
+
+	var header diskCacheHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return nil, fmt.Errorf("parse cache header: %w", err)
+	}
+	return &header, nil
+}
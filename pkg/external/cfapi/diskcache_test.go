@@ -0,0 +1,208 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCache_SetGet(t *testing.T) {
+	cache, err := NewDiskCache(filepath.Join(t.TempDir(), "api"))
+	if err != nil {
+		t.Fatalf("NewDiskCache() error = %v", err)
+	}
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Error("Get() should miss for unknown key")
+	}
+
+	stored := time.Now().Add(-time.Minute).Truncate(time.Second)
+	body := []byte("{\"status\":\"OK\",\n\"result\":[]}")
+	if err := cache.Set(&CachedResponse{Key: "contest.list?gym=false", Method: "contest.list", StoredAt: stored, Body: body}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	got, ok := cache.Get("contest.list?gym=false")
+	if !ok {
+		t.Fatal("Get() should hit after Set()")
+	}
+	if string(got.Body) != string(body) {
+		t.Errorf("Body = %q, want %q", got.Body, body)
+	}
+	if !got.StoredAt.Equal(stored) || got.Method != "contest.list" {
+		t.Errorf("entry = %+v", got)
+	}
+	if got.Age() < time.Minute {
+		t.Errorf("Age() = %v, want >= 1m", got.Age())
+	}
+}
+
+func TestDiskCache_StatsAndClear(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewDiskCache(dir)
+
+	now := time.Now()
+	cache.Set(&CachedResponse{Key: "a", Method: "contest.list", StoredAt: now, Body: []byte("12345")})
+	cache.Set(&CachedResponse{Key: "b", Method: "user.status", StoredAt: now.Add(-time.Hour), Body: []byte("1")})
+	cache.Set(&CachedResponse{Key: "c", Method: "user.status", StoredAt: now, Body: []byte("1")})
+
+	// Unreadable files are ignored
+	os.WriteFile(filepath.Join(dir, "junk.cache"), []byte("not a header"), 0644)
+
+	stats, err := cache.Stats()
+	if err != nil {
+		t.Fatalf("Stats() error = %v", err)
+	}
+	if stats.Entries != 3 || len(stats.Methods) != 2 {
+		t.Fatalf("Stats() = %+v, want 3 entries over 2 methods", stats)
+	}
+	status := stats.Methods["user.status"]
+	if status.Entries != 2 || !status.Oldest.Before(status.Newest) {
+		t.Errorf("user.status stats = %+v", status)
+	}
+
+	removed, err := cache.Clear("user.status")
+	if err != nil || removed != 2 {
+		t.Errorf("Clear(user.status) = %d, %v; want 2", removed, err)
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("Clear(user.status) removed another method's entry")
+	}
+
+	removed, _ = cache.Clear("")
+	if removed != 1 {
+		t.Errorf("Clear(\"\") = %d, want 1", removed)
+	}
+}
+
+// countingTransport serves a fixed body and counts requests
+type countingTransport struct {
+	body     string
+	fail     atomic.Bool
+	requests atomic.Int32
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests.Add(1)
+	if c.fail.Load() {
+		return nil, fmt.Errorf("network unreachable")
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+const contestListBody = `{"status":"OK","result":[{"id":1,"name":"Round 1","phase":"FINISHED"}]}`
+
+func newDiskClient(t *testing.T, transport http.RoundTripper, cache ResponseCache, policy CachePolicy) *Client {
+	t.Helper()
+	return NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithDiskCache(cache),
+		WithCachePolicy("contest.list", policy),
+	)
+}
+
+func TestClient_DiskCache_Fresh(t *testing.T) {
+	cache, _ := NewDiskCache(t.TempDir())
+	transport := &countingTransport{body: contestListBody}
+	ctx := context.Background()
+
+	// Separate clients share only the disk tier, like separate CLI runs
+	for i := 0; i < 3; i++ {
+		client := newDiskClient(t, transport, cache, CachePolicy{TTL: time.Hour})
+		contests, err := client.GetContests(ctx, false)
+		if err != nil || len(contests) != 1 {
+			t.Fatalf("GetContests() = %v, %v", contests, err)
+		}
+	}
+
+	if n := transport.requests.Load(); n != 1 {
+		t.Errorf("network requests = %d, want 1", n)
+	}
+}
+
+func TestClient_DiskCache_StaleIfError(t *testing.T) {
+	cache, _ := NewDiskCache(t.TempDir())
+	cache.Set(&CachedResponse{
+		Key:      "contest.list?gym=false",
+		Method:   "contest.list",
+		StoredAt: time.Now().Add(-48 * time.Hour),
+		Body:     []byte(contestListBody),
+	})
+
+	transport := &countingTransport{}
+	transport.fail.Store(true)
+
+	client := newDiskClient(t, transport, cache, CachePolicy{TTL: time.Hour})
+	contests, err := client.GetContests(context.Background(), false)
+	if err != nil {
+		t.Fatalf("GetContests() offline error = %v, want stale copy", err)
+	}
+	if len(contests) != 1 || transport.requests.Load() != 1 {
+		t.Errorf("contests = %d, requests = %d", len(contests), transport.requests.Load())
+	}
+}
+
+func TestClient_DiskCache_StaleWhileRevalidate(t *testing.T) {
+	cache, _ := NewDiskCache(t.TempDir())
+	old := time.Now().Add(-2 * time.Hour)
+	cache.Set(&CachedResponse{
+		Key:      "contest.list?gym=false",
+		Method:   "contest.list",
+		StoredAt: old,
+		Body:     []byte(contestListBody),
+	})
+
+	transport := &countingTransport{body: contestListBody}
+	client := newDiskClient(t, transport, cache, CachePolicy{TTL: time.Hour, StaleWhileRevalidate: 24 * time.Hour})
+
+	if _, err := client.GetContests(context.Background(), false); err != nil {
+		t.Fatalf("GetContests() error = %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Flush(ctx); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if n := transport.requests.Load(); n != 1 {
+		t.Errorf("background requests = %d, want 1", n)
+	}
+	entry, ok := cache.Get("contest.list?gym=false")
+	if !ok || !entry.StoredAt.After(old) {
+		t.Error("revalidation should refresh the disk entry")
+	}
+}
+
+func TestClient_DiskCache_SkipsFailuresAndUncached(t *testing.T) {
+	cache, _ := NewDiskCache(t.TempDir())
+	ctx := context.Background()
+
+	failed := &countingTransport{body: `{"status":"FAILED","comment":"nope"}`}
+	client := newDiskClient(t, failed, cache, CachePolicy{TTL: time.Hour})
+	if _, err := client.GetContests(ctx, false); err == nil {
+		t.Error("GetContests() should surface API failure")
+	}
+
+	standings := &countingTransport{body: `{"status":"OK","result":{"contest":{"id":1},"problems":[],"rows":[]}}`}
+	client = newDiskClient(t, standings, cache, CachePolicy{TTL: time.Hour})
+	if _, err := client.GetContestStandings(ctx, 1, 1, 1, nil, false); err != nil {
+		t.Fatalf("GetContestStandings() error = %v", err)
+	}
+
+	stats, _ := cache.Stats()
+	if stats.Entries != 0 {
+		t.Errorf("cache entries = %d, want 0 (failures and standings are not stored)", stats.Entries)
+	}
+}
@@ -166,6 +166,51 @@ func TestClient_GetUserSubmissions_InvalidJSON(t *testing.T) {
 	}
 }
 
+// ============ GetSubmissionStatus ============
+
+func TestClient_GetSubmissionStatus(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			// Latest submission is a newer one
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":200,"verdict":"OK"}]}`},
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":200,"verdict":"OK"},{"id":100,"verdict":"TESTING","passedTestCount":16}]}`},
+			// Polled again: not served from a cache
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":100,"verdict":"WRONG_ANSWER","passedTestCount":20}]}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	sub, err := client.GetSubmissionStatus(context.Background(), "test", 100)
+	if err != nil {
+		t.Fatalf("GetSubmissionStatus() error = %v", err)
+	}
+	if sub.Verdict != "TESTING" || sub.PassedTestCount != 16 {
+		t.Errorf("submission = %+v", sub)
+	}
+
+	sub, err = client.GetSubmissionStatus(context.Background(), "test", 100)
+	if err != nil {
+		t.Fatalf("GetSubmissionStatus() error = %v", err)
+	}
+	if sub.Verdict != "WRONG_ANSWER" || callCount != 3 {
+		t.Errorf("submission = %+v after %d requests", sub, callCount)
+	}
+}
+
+func TestClient_GetSubmissionStatus_NotFound(t *testing.T) {
+	transport := &mockTransport{
+		statusCode: 200,
+		body:       `{"status":"OK","result":[]}`,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	if _, err := client.GetSubmissionStatus(context.Background(), "test", 100); err == nil {
+		t.Error("Expected error for a submission that is not found")
+	}
+}
+
 // ============ GetUserRating Error Paths ============
 
 func TestClient_GetUserRating_APIFailed(t *testing.T) {
@@ -0,0 +1,362 @@
+package cfapi
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	// DefaultRecommendCount is the number of picks when none is requested
+	DefaultRecommendCount = 5
+
+	// unratedBase is the rating of the easiest problems, used for users
+	// without rated contests or solves
+	unratedBase = 800
+
+	// minTagSubmissions is how many submissions a tag needs before its
+	// acceptance rate counts as a weakness
+	minTagSubmissions = 5
+
+	// maxWeakTags caps the tags treated as weaknesses
+	maxWeakTags = 5
+)
+
+// TagStats counts a user's submissions on problems with a tag
+type TagStats struct {
+	Submissions int
+	Accepted    int
+	Solved      int // distinct problems
+}
+
+// AcceptanceRate returns the share of accepted submissions
+func (s TagStats) AcceptanceRate() float64 {
+	if s.Submissions == 0 {
+		return 0
+	}
+	return float64(s.Accepted) / float64(s.Submissions)
+}
+
+// WeaknessProfile describes where a user should practice: tags with low
+// acceptance and a rating band slightly above their current rating
+type WeaknessProfile struct {
+	Rating    int // current contest rating, or an estimate from solved problems
+	Rated     bool
+	MinRating int
+	MaxRating int
+
+	Acceptance float64 // overall acceptance rate
+	Tags       map[string]TagStats
+	WeakTags   []string // weakest first
+
+	Solved    map[string]bool // problem IDs
+	Attempted map[string]bool // problem IDs tried but not solved
+}
+
+// TagWeakness returns how far a tag's acceptance falls below the user's
+// overall acceptance, between 0 and 1
+func (p *WeaknessProfile) TagWeakness(tag string) float64 {
+	stats, ok := p.Tags[tag]
+	if !ok || stats.Submissions < minTagSubmissions || p.Acceptance == 0 {
+		return 0
+	}
+	return max(0, 1-stats.AcceptanceRate()/p.Acceptance)
+}
+
+// BuildProfile builds a weakness profile from a user's submissions and rating
+// history. The target band is current rating +100..+300.
+func BuildProfile(submissions []Submission, ratings []RatingChange) *WeaknessProfile {
+	profile := &WeaknessProfile{
+		Tags:      make(map[string]TagStats),
+		Solved:    make(map[string]bool),
+		Attempted: make(map[string]bool),
+	}
+
+	total, accepted := 0, 0
+	var solvedRatings []int
+	for _, sub := range submissions {
+		if sub.IsPending() || sub.Verdict == VerdictCompilationError || sub.Verdict == VerdictSkipped {
+			continue
+		}
+		id := sub.Problem.ProblemID()
+		ok := sub.IsAccepted()
+		newSolve := ok && !profile.Solved[id]
+
+		total++
+		if ok {
+			accepted++
+			profile.Solved[id] = true
+			delete(profile.Attempted, id)
+		} else if !profile.Solved[id] {
+			profile.Attempted[id] = true
+		}
+		if newSolve && sub.Problem.Rating > 0 {
+			solvedRatings = append(solvedRatings, sub.Problem.Rating)
+		}
+
+		for _, tag := range sub.Problem.Tags {
+			stats := profile.Tags[tag]
+			stats.Submissions++
+			if ok {
+				stats.Accepted++
+			}
+			if newSolve {
+				stats.Solved++
+			}
+			profile.Tags[tag] = stats
+		}
+	}
+	if total > 0 {
+		profile.Acceptance = float64(accepted) / float64(total)
+	}
+
+	// Rating history is in contest order; without it, the median rating of
+	// solved problems is the best estimate
+	switch {
+	case len(ratings) > 0:
+		profile.Rating = ratings[len(ratings)-1].NewRating
+		profile.Rated = true
+	case len(solvedRatings) > 0:
+		sort.Ints(solvedRatings)
+		profile.Rating = solvedRatings[len(solvedRatings)/2]
+	default:
+		profile.Rating = unratedBase
+	}
+	// The easiest problems are rated 800, so low ratings get the lowest band
+	profile.MinRating = max(profile.Rating+100, unratedBase)
+	profile.MaxRating = max(profile.Rating+300, unratedBase+200)
+
+	for tag := range profile.Tags {
+		if profile.TagWeakness(tag) > 0 {
+			profile.WeakTags = append(profile.WeakTags, tag)
+		}
+	}
+	sort.Slice(profile.WeakTags, func(i, j int) bool {
+		wi, wj := profile.TagWeakness(profile.WeakTags[i]), profile.TagWeakness(profile.WeakTags[j])
+		if wi != wj {
+			return wi > wj
+		}
+		return profile.WeakTags[i] < profile.WeakTags[j]
+	})
+	if len(profile.WeakTags) > maxWeakTags {
+		profile.WeakTags = profile.WeakTags[:maxWeakTags]
+	}
+
+	return profile
+}
+
+// RecommendOptions controls problem recommendations
+type RecommendOptions struct {
+	Count         int
+	MinRating     int      // overrides the profile's band when set
+	MaxRating     int      // overrides the profile's band when set
+	Tags          []string // only problems with one of these tags
+	PreferredTags []string // favoured, but not required
+}
+
+// Recommendation is a recommended problem with the reason it was picked
+type Recommendation struct {
+	Problem     Problem
+	SolvedCount int
+	Score       float64
+	Reason      string
+}
+
+// Recommend ranks the unsolved problems in the profile's rating band. Weak
+// tags weigh most, then preferred tags, popularity (solved count) and
+// closeness to the middle of the band. Problems attempted but never solved
+// get a boost. Picks are spread over weak tags rather than all hitting the
+// weakest one.
+func Recommend(profile *WeaknessProfile, problems *ProblemsResponse, opts RecommendOptions) []Recommendation {
+	count := opts.Count
+	if count <= 0 {
+		count = DefaultRecommendCount
+	}
+	minRating, maxRating := profile.MinRating, profile.MaxRating
+	if opts.MinRating > 0 {
+		minRating = opts.MinRating
+	}
+	if opts.MaxRating > 0 {
+		maxRating = opts.MaxRating
+	}
+
+	solvedCounts := make(map[string]int, len(problems.ProblemStatistics))
+	maxSolved := 0
+	for _, s := range problems.ProblemStatistics {
+		solvedCounts[fmt.Sprintf("%d%s", s.ContestID, s.Index)] = s.SolvedCount
+		maxSolved = max(maxSolved, s.SolvedCount)
+	}
+
+	weak := make(map[string]float64, len(profile.WeakTags))
+	for _, tag := range profile.WeakTags {
+		weak[tag] = profile.TagWeakness(tag)
+	}
+	preferred := toSet(opts.PreferredTags)
+	required := toSet(opts.Tags)
+
+	var candidates []Recommendation
+	for _, p := range problems.Problems {
+		id := p.ProblemID()
+		if p.Rating == 0 || p.Rating < minRating || p.Rating > maxRating || profile.Solved[id] || hasTag(p.Tags, "*special") {
+			continue
+		}
+		if len(required) > 0 && !anyTag(p.Tags, required) {
+			continue
+		}
+		candidates = append(candidates, Recommendation{Problem: p, SolvedCount: solvedCounts[id]})
+	}
+
+	// base scores everything except weak tags, which depend on earlier picks
+	mid := float64(minRating+maxRating) / 2
+	halfWidth := max(float64(maxRating-minRating)/2, 100)
+	base := make([]float64, len(candidates))
+	for i, c := range candidates {
+		score := 1 - 0.5*math.Abs(float64(c.Problem.Rating)-mid)/halfWidth
+		if maxSolved > 0 {
+			score += math.Log1p(float64(c.SolvedCount)) / math.Log1p(float64(maxSolved))
+		}
+		if anyTag(c.Problem.Tags, preferred) {
+			score += 0.75
+		}
+		if profile.Attempted[c.Problem.ProblemID()] {
+			score += 0.5
+		}
+		base[i] = score
+	}
+
+	covered := make(map[string]int)
+	weakScore := func(tags []string) float64 {
+		score := 0.0
+		for _, tag := range tags {
+			if w, ok := weak[tag]; ok {
+				score += 2 * w / float64(1+covered[tag])
+			}
+		}
+		return score
+	}
+
+	var picks []Recommendation
+	used := make([]bool, len(candidates))
+	for len(picks) < count {
+		best := -1
+		bestScore := 0.0
+		for i, c := range candidates {
+			if used[i] {
+				continue
+			}
+			score := base[i] + weakScore(c.Problem.Tags)
+			if best == -1 || score > bestScore || (score == bestScore && betterTieBreak(c, candidates[best])) {
+				best, bestScore = i, score
+			}
+		}
+		if best == -1 {
+			break
+		}
+		used[best] = true
+		pick := candidates[best]
+		pick.Score = bestScore
+		pick.Reason = recommendReason(profile, &pick, weak, preferred)
+		for _, tag := range pick.Problem.Tags {
+			covered[tag]++
+		}
+		picks = append(picks, pick)
+	}
+
+	return picks
+}
+
+// Recommend builds a weakness profile for handle and recommends problems
+func (c *Client) Recommend(ctx context.Context, handle string, opts RecommendOptions) (*WeaknessProfile, []Recommendation, error) {
+	submissions, err := c.GetAllSubmissions(ctx, handle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get submissions: %w", err)
+	}
+	ratings, err := c.GetUserRating(ctx, handle)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get rating history: %w", err)
+	}
+	problems, err := c.GetProblems(ctx, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get problems: %w", err)
+	}
+
+	profile := BuildProfile(submissions, ratings)
+	return profile, Recommend(profile, problems, opts), nil
+}
+
+// recommendReason explains a pick in one line, weakest matching tag first
+func recommendReason(profile *WeaknessProfile, pick *Recommendation, weak map[string]float64, preferred map[string]bool) string {
+	var parts []string
+
+	weakest, weakestScore := "", 0.0
+	for _, tag := range pick.Problem.Tags {
+		if w := weak[tag]; w > weakestScore {
+			weakest, weakestScore = tag, w
+		}
+	}
+	if weakest != "" {
+		parts = append(parts, fmt.Sprintf("weak tag %s (%.0f%% accepted)", weakest, profile.Tags[weakest].AcceptanceRate()*100))
+	}
+
+	for _, tag := range pick.Problem.Tags {
+		if preferred[tag] {
+			parts = append(parts, "preferred tag "+tag)
+			break
+		}
+	}
+
+	if profile.Attempted[pick.Problem.ProblemID()] {
+		parts = append(parts, "attempted before")
+	}
+
+	parts = append(parts, fmt.Sprintf("%+d vs your rating", pick.Problem.Rating-profile.Rating))
+	if pick.SolvedCount > 0 {
+		parts = append(parts, "solved by "+formatCount(pick.SolvedCount))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// betterTieBreak orders equally scored candidates by popularity, then ID
+func betterTieBreak(a, b Recommendation) bool {
+	if a.SolvedCount != b.SolvedCount {
+		return a.SolvedCount > b.SolvedCount
+	}
+	return a.Problem.ProblemID() < b.Problem.ProblemID()
+}
+
+// formatCount abbreviates large counts, e.g. 12345 -> "12.3k"
+func formatCount(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	return fmt.Sprintf("%.1fk", float64(n)/1000)
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func hasTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func anyTag(tags []string, set map[string]bool) bool {
+	for _, t := range tags {
+		if set[t] {
+			return true
+		}
+	}
+	return false
+}
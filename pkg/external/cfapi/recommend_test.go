@@ -0,0 +1,181 @@
+package cfapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// submissionsOn returns n submissions on a problem, the first ok of them accepted
+func submissionsOn(p Problem, n, ok int) []Submission {
+	subs := make([]Submission, n)
+	for i := range subs {
+		subs[i] = Submission{Problem: p, Verdict: VerdictWrongAnswer}
+		if i < ok {
+			subs[i].Verdict = VerdictOK
+		}
+	}
+	return subs
+}
+
+func TestBuildProfile(t *testing.T) {
+	dp := Problem{ContestID: 1, Index: "A", Rating: 1500, Tags: []string{"dp"}}
+	greedy := Problem{ContestID: 2, Index: "A", Rating: 1300, Tags: []string{"greedy"}}
+	graphs := Problem{ContestID: 3, Index: "A", Rating: 1700, Tags: []string{"graphs"}}
+
+	var subs []Submission
+	subs = append(subs, submissionsOn(dp, 6, 1)...)     // 17% accepted
+	subs = append(subs, submissionsOn(greedy, 6, 6)...) // 100% accepted
+	subs = append(subs, submissionsOn(graphs, 2, 0)...) // too few to judge
+	subs = append(subs, Submission{Problem: dp, Verdict: VerdictCompilationError})
+
+	profile := BuildProfile(subs, []RatingChange{{NewRating: 1200}, {NewRating: 1400}})
+
+	if profile.Rating != 1400 || !profile.Rated || profile.MinRating != 1500 || profile.MaxRating != 1700 {
+		t.Errorf("rating = %d (rated %v), band %d-%d; want 1400, 1500-1700",
+			profile.Rating, profile.Rated, profile.MinRating, profile.MaxRating)
+	}
+	if len(profile.WeakTags) != 1 || profile.WeakTags[0] != "dp" {
+		t.Errorf("WeakTags = %v, want [dp]", profile.WeakTags)
+	}
+	if s := profile.Tags["dp"]; s.Submissions != 6 || s.Accepted != 1 || s.Solved != 1 {
+		t.Errorf("dp stats = %+v", s)
+	}
+	if !profile.Solved["1A"] || !profile.Attempted["3A"] || profile.Attempted["1A"] {
+		t.Errorf("Solved = %v, Attempted = %v", profile.Solved, profile.Attempted)
+	}
+}
+
+func TestBuildProfile_Unrated(t *testing.T) {
+	tests := []struct {
+		name    string
+		subs    []Submission
+		want    int
+		wantMin int
+	}{
+		{"no submissions", nil, 800, 900},
+		{"median of solved", append(append(
+			submissionsOn(Problem{ContestID: 1, Index: "A", Rating: 800}, 1, 1),
+			submissionsOn(Problem{ContestID: 1, Index: "B", Rating: 1100}, 1, 1)...),
+			submissionsOn(Problem{ContestID: 1, Index: "C", Rating: 1000}, 1, 1)...), 1000, 1100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := BuildProfile(tt.subs, nil)
+			if profile.Rating != tt.want || profile.Rated || profile.MinRating != tt.wantMin {
+				t.Errorf("rating = %d (rated %v), min %d; want %d, min %d",
+					profile.Rating, profile.Rated, profile.MinRating, tt.want, tt.wantMin)
+			}
+		})
+	}
+
+	// Low ratings still get a band with problems in it
+	profile := BuildProfile(nil, []RatingChange{{NewRating: 400}})
+	if profile.MinRating != 800 || profile.MaxRating != 1000 {
+		t.Errorf("band = %d-%d, want 800-1000", profile.MinRating, profile.MaxRating)
+	}
+}
+
+func TestRecommend(t *testing.T) {
+	profile := &WeaknessProfile{
+		Rating:     1400,
+		MinRating:  1500,
+		MaxRating:  1700,
+		Acceptance: 0.5,
+		Tags: map[string]TagStats{
+			"dp":     {Submissions: 10, Accepted: 1},
+			"graphs": {Submissions: 10, Accepted: 2},
+		},
+		WeakTags:  []string{"dp", "graphs"},
+		Solved:    map[string]bool{"10A": true},
+		Attempted: map[string]bool{"14A": true},
+	}
+	problems := &ProblemsResponse{
+		Problems: []Problem{
+			{ContestID: 10, Index: "A", Rating: 1600, Tags: []string{"dp"}},             // solved
+			{ContestID: 11, Index: "A", Rating: 1600, Tags: []string{"dp"}},             // weakest tag
+			{ContestID: 12, Index: "A", Rating: 1600, Tags: []string{"dp"}},             // second dp
+			{ContestID: 13, Index: "A", Rating: 1600, Tags: []string{"graphs"}},         // other weak tag
+			{ContestID: 14, Index: "A", Rating: 1600, Tags: []string{"math"}},           // attempted
+			{ContestID: 15, Index: "A", Rating: 1600, Tags: []string{"strings"}},        // preferred
+			{ContestID: 16, Index: "A", Rating: 1900, Tags: []string{"dp"}},             // out of band
+			{ContestID: 17, Index: "A", Rating: 0, Tags: []string{"dp"}},                // unrated
+			{ContestID: 18, Index: "A", Rating: 1600, Tags: []string{"dp", "*special"}}, // special
+		},
+		ProblemStatistics: []ProblemStatistics{
+			{ContestID: 11, Index: "A", SolvedCount: 5000},
+			{ContestID: 12, Index: "A", SolvedCount: 4000},
+			{ContestID: 13, Index: "A", SolvedCount: 3000},
+			{ContestID: 14, Index: "A", SolvedCount: 100},
+			{ContestID: 15, Index: "A", SolvedCount: 100},
+		},
+	}
+
+	picks := Recommend(profile, problems, RecommendOptions{Count: 10, PreferredTags: []string{"strings"}})
+
+	var ids []string
+	for _, p := range picks {
+		ids = append(ids, p.Problem.ProblemID())
+	}
+	// The second dp problem drops behind graphs once dp is covered
+	want := "11A 13A 12A 15A 14A"
+	if got := strings.Join(ids, " "); got != want {
+		t.Errorf("picks = %s, want %s", got, want)
+	}
+
+	reasons := map[string]string{}
+	for _, p := range picks {
+		reasons[p.Problem.ProblemID()] = p.Reason
+	}
+	for id, want := range map[string]string{
+		"11A": "weak tag dp (10% accepted), +200 vs your rating, solved by 5.0k",
+		"15A": "preferred tag strings, +200 vs your rating, solved by 100",
+		"14A": "attempted before",
+	} {
+		if !strings.Contains(reasons[id], want) {
+			t.Errorf("reason for %s = %q, want it to contain %q", id, reasons[id], want)
+		}
+	}
+
+	picks = Recommend(profile, problems, RecommendOptions{Count: 1, Tags: []string{"graphs"}})
+	if len(picks) != 1 || picks[0].Problem.ProblemID() != "13A" {
+		t.Errorf("picks with --tag graphs = %+v", picks)
+	}
+
+	picks = Recommend(profile, problems, RecommendOptions{MinRating: 1800, MaxRating: 2000})
+	if len(picks) != 1 || picks[0].Problem.ProblemID() != "16A" {
+		t.Errorf("picks in 1800-2000 = %+v", picks)
+	}
+}
+
+func TestClient_Recommend(t *testing.T) {
+	callCount := 0
+	transport := &sequentialTransport{
+		responses: []mockResponse{
+			// GetAllSubmissions
+			{statusCode: 200, body: `{"status":"OK","result":[{"id":1,"verdict":"OK","problem":{"contestId":1,"index":"A","rating":1000}}]}`},
+			// GetUserRating
+			{statusCode: 200, body: `{"status":"OK","result":[{"contestId":1,"newRating":1100}]}`},
+			// GetProblems
+			{statusCode: 200, body: `{"status":"OK","result":{"problems":[
+				{"contestId":2,"index":"A","rating":1300},
+				{"contestId":2,"index":"B","rating":2000}
+			],"problemStatistics":[]}}`},
+		},
+		callCount: &callCount,
+	}
+	client := NewClient(WithHTTPClient(&http.Client{Transport: transport}))
+
+	profile, picks, err := client.Recommend(context.Background(), "tourist", RecommendOptions{})
+	if err != nil {
+		t.Fatalf("Recommend() error = %v", err)
+	}
+	if profile.Rating != 1100 || !profile.Solved["1A"] {
+		t.Errorf("profile = %+v", profile)
+	}
+	if len(picks) != 1 || picks[0].Problem.ProblemID() != "2A" {
+		t.Errorf("picks = %+v, want [2A]", picks)
+	}
+}
@@ -0,0 +1,280 @@
+package cfapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+const (
+	// syncFirstPage is the size of the first page fetched by a sync. Most
+	// syncs only find a handful of new submissions.
+	syncFirstPage = 50
+	// syncMaxPage caps the page size as a sync pages further back
+	syncMaxPage = 5000
+)
+
+// SubmissionLog is the locally stored submission history of one user,
+// newest first
+type SubmissionLog struct {
+	Handle      string       `json:"handle"`
+	SyncedAt    time.Time    `json:"syncedAt"`
+	Submissions []Submission `json:"submissions"`
+}
+
+// LatestID returns the newest stored submission ID, or 0 if empty
+func (l *SubmissionLog) LatestID() int64 {
+	if len(l.Submissions) == 0 {
+		return 0
+	}
+	return l.Submissions[0].ID
+}
+
+// syncBoundary returns the ID at or below which stored submissions are
+// final. Submissions still being judged are refetched until they settle.
+func (l *SubmissionLog) syncBoundary() int64 {
+	boundary := l.LatestID()
+	for _, s := range l.Submissions {
+		if s.IsPending() && s.ID-1 < boundary {
+			boundary = s.ID - 1
+		}
+	}
+	return boundary
+}
+
+// merge adds or replaces submissions by ID and keeps the log sorted.
+// Returns the submissions that were not stored before.
+func (l *SubmissionLog) merge(subs []Submission) []Submission {
+	index := make(map[int64]int, len(l.Submissions))
+	for i, s := range l.Submissions {
+		index[s.ID] = i
+	}
+
+	var added []Submission
+	for _, s := range subs {
+		if i, ok := index[s.ID]; ok {
+			l.Submissions[i] = s
+			continue
+		}
+		index[s.ID] = len(l.Submissions)
+		l.Submissions = append(l.Submissions, s)
+		added = append(added, s)
+	}
+
+	sort.SliceStable(l.Submissions, func(i, j int) bool {
+		return l.Submissions[i].ID > l.Submissions[j].ID
+	})
+	return added
+}
+
+// SubmissionStore persists submission logs, one JSON file per handle
+type SubmissionStore struct {
+	dir string
+}
+
+// NewSubmissionStore creates a store rooted at dir, creating it if needed
+func NewSubmissionStore(dir string) (*SubmissionStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create submission store: %w", err)
+	}
+	return &SubmissionStore{dir: dir}, nil
+}
+
+func (s *SubmissionStore) path(handle string) string {
+	return filepath.Join(s.dir, strings.ToLower(handle)+".json")
+}
+
+// Load returns the stored log for handle, or an empty log if none exists
+func (s *SubmissionStore) Load(handle string) (*SubmissionLog, error) {
+	data, err := os.ReadFile(s.path(handle))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SubmissionLog{Handle: handle}, nil
+		}
+		return nil, fmt.Errorf("read submission log: %w", err)
+	}
+
+	var log SubmissionLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("parse submission log: %w", err)
+	}
+	return &log, nil
+}
+
+// Save writes the log atomically
+func (s *SubmissionStore) Save(log *SubmissionLog) error {
+	data, err := json.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("marshal submission log: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("create submission log: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write submission log: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write submission log: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), s.path(log.Handle)); err != nil {
+		return fmt.Errorf("store submission log: %w", err)
+	}
+	return nil
+}
+
+// SyncResult describes one incremental sync
+type SyncResult struct {
+	Log      *SubmissionLog
+	New      []Submission // submissions not seen before, newest first
+	Requests int          // API pages fetched
+}
+
+// SyncSubmissions brings the stored log for handle up to date, fetching
+// only pages newer than the last known submission. Requires a store set
+// with WithSubmissionStore.
+func (c *Client) SyncSubmissions(ctx context.Context, handle string) (*SyncResult, error) {
+	if c.store == nil {
+		return nil, fmt.Errorf("no submission store configured")
+	}
+
+	log, err := c.store.Load(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SyncResult{Log: log}
+	boundary := log.syncBoundary()
+
+	var fetched []Submission
+	from, count := 1, syncFirstPage
+	if boundary == 0 {
+		count = 0 // first sync: everything in one request
+	}
+
+	for {
+		page, err := c.fetchSubmissions(ctx, handle, from, count)
+		if err != nil {
+			return nil, err
+		}
+		result.Requests++
+
+		done := count == 0 || len(page) < count
+		for _, s := range page {
+			if s.ID <= boundary {
+				done = true
+				break
+			}
+			fetched = append(fetched, s)
+		}
+		if done {
+			break
+		}
+
+		from += count
+		count = min(count*2, syncMaxPage)
+	}
+
+	result.New = log.merge(fetched)
+	log.Handle = handle
+	log.SyncedAt = time.Now()
+
+	if err := c.store.Save(log); err != nil {
+		return nil, err
+	}
+
+	c.cache.Set(allSubmissionsKey(handle), log.Submissions)
+	return result, nil
+}
+
+// InvalidateSubmissions drops the in-memory submission history of a user, so
+// the next GetAllSubmissions syncs with Codeforces again
+func (c *Client) InvalidateSubmissions(handle string) {
+	c.cache.Delete(allSubmissionsKey(handle))
+}
+
+// allSubmissionsKey is the in-memory cache key of a user's full history
+func allSubmissionsKey(handle string) string {
+	return "all-submissions:" + strings.ToLower(handle)
+}
+
+// GetAllSubmissions returns the full submission history of a user. With a
+// submission store it syncs incrementally and falls back to the stored log
+// when the API is unreachable; otherwise it fetches everything.
+func (c *Client) GetAllSubmissions(ctx context.Context, handle string) ([]Submission, error) {
+	if cached, ok := c.cache.Get(allSubmissionsKey(handle)); ok {
+		return cached.([]Submission), nil
+	}
+
+	if c.store == nil {
+		return c.GetUserSubmissions(ctx, handle, 1, 10000)
+	}
+
+	result, err := c.SyncSubmissions(ctx, handle)
+	if err != nil {
+		log, loadErr := c.store.Load(handle)
+		if loadErr != nil || len(log.Submissions) == 0 {
+			return nil, err
+		}
+		return log.Submissions, nil
+	}
+
+	return result.Log.Submissions, nil
+}
+
+// fetchSubmissions fetches one page of user.status over the network,
+// bypassing both caches. A zero count fetches all submissions.
+func (c *Client) fetchSubmissions(ctx context.Context, handle string, from, count int) ([]Submission, error) {
+	params := url.Values{}
+	params.Set("handle", handle)
+	params.Set("from", strconv.Itoa(from))
+	if count > 0 {
+		params.Set("count", strconv.Itoa(count))
+	}
+
+	body, err := c.fetch(ctx, "user.status", params)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp Response[[]Submission]
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parse response: %w", err)
+	}
+
+	if resp.Status != "OK" {
+		return nil, fmt.Errorf("api error: %s", resp.Comment)
+	}
+
+	return resp.Result, nil
+}
+
+// ToSchemaSubmission converts an API submission to a workspace record
+func (s *Submission) ToSchemaSubmission() *v1.Submission {
+	record := v1.NewSubmission(s.ID, s.Problem.ProblemID(), s.Problem.ContestID, s.ProgrammingLanguage, 0)
+	record.SubmittedAt = s.SubmissionTime()
+
+	if s.IsPending() {
+		record.Verdict = v1.VerdictTesting
+	} else {
+		record.Verdict = v1.Verdict(s.Verdict)
+		record.TimeUsed = fmt.Sprintf("%d ms", s.TimeConsumedMillis)
+		record.MemoryUsed = fmt.Sprintf("%d KB", s.MemoryConsumedBytes/1024)
+	}
+
+	return record
+}
@@ -0,0 +1,197 @@
+package cfapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// historyTransport serves user.status pages from an in-memory history
+type historyTransport struct {
+	history  []Submission // newest first
+	offline  bool
+	requests int
+}
+
+func (h *historyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	h.requests++
+	if h.offline {
+		return nil, fmt.Errorf("network unreachable")
+	}
+
+	q := req.URL.Query()
+	from, _ := strconv.Atoi(q.Get("from"))
+	count, _ := strconv.Atoi(q.Get("count"))
+
+	start := min(max(from-1, 0), len(h.history))
+	end := len(h.history)
+	if count > 0 {
+		end = min(start+count, end)
+	}
+
+	body, _ := json.Marshal(Response[[]Submission]{Status: "OK", Result: h.history[start:end]})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(body))),
+		Header:     make(http.Header),
+	}, nil
+}
+
+// add prepends n accepted submissions with increasing IDs
+func (h *historyTransport) add(n int) {
+	next := int64(1)
+	if len(h.history) > 0 {
+		next = h.history[0].ID + 1
+	}
+	for i := 0; i < n; i++ {
+		s := Submission{ID: next + int64(i), Verdict: VerdictOK, Problem: Problem{ContestID: 1, Index: "A"}}
+		h.history = append([]Submission{s}, h.history...)
+	}
+}
+
+func newSyncClient(t *testing.T, transport http.RoundTripper) (*Client, *SubmissionStore) {
+	t.Helper()
+	store, err := NewSubmissionStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewSubmissionStore() error = %v", err)
+	}
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithSubmissionStore(store),
+	)
+	return client, store
+}
+
+func TestSyncSubmissions_Incremental(t *testing.T) {
+	transport := &historyTransport{}
+	transport.add(300)
+	client, store := newSyncClient(t, transport)
+	ctx := context.Background()
+
+	result, err := client.SyncSubmissions(ctx, "Tourist")
+	if err != nil {
+		t.Fatalf("SyncSubmissions() error = %v", err)
+	}
+	if len(result.New) != 300 || result.Requests != 1 {
+		t.Errorf("first sync: new = %d, requests = %d; want 300, 1", len(result.New), result.Requests)
+	}
+
+	transport.add(3)
+	result, err = client.SyncSubmissions(ctx, "tourist")
+	if err != nil {
+		t.Fatalf("SyncSubmissions() error = %v", err)
+	}
+	if len(result.New) != 3 || result.Requests != 1 {
+		t.Errorf("second sync: new = %d, requests = %d; want 3, 1", len(result.New), result.Requests)
+	}
+	if result.New[0].ID != 303 {
+		t.Errorf("New[0].ID = %d, want newest first", result.New[0].ID)
+	}
+
+	// More new submissions than the first page: pages grow until a known ID
+	transport.add(120)
+	result, _ = client.SyncSubmissions(ctx, "tourist")
+	if len(result.New) != 120 || result.Requests != 2 {
+		t.Errorf("third sync: new = %d, requests = %d; want 120, 2", len(result.New), result.Requests)
+	}
+
+	log, err := store.Load("TOURIST")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if len(log.Submissions) != 423 || log.LatestID() != 423 || log.SyncedAt.IsZero() {
+		t.Errorf("stored log: %d submissions, latest %d", len(log.Submissions), log.LatestID())
+	}
+}
+
+func TestSyncSubmissions_RefreshesPending(t *testing.T) {
+	transport := &historyTransport{}
+	transport.add(10)
+	transport.history[1].Verdict = VerdictTesting
+	client, store := newSyncClient(t, transport)
+	ctx := context.Background()
+
+	if _, err := client.SyncSubmissions(ctx, "u"); err != nil {
+		t.Fatalf("SyncSubmissions() error = %v", err)
+	}
+
+	transport.history[1].Verdict = VerdictWrongAnswer
+	result, err := client.SyncSubmissions(ctx, "u")
+	if err != nil {
+		t.Fatalf("SyncSubmissions() error = %v", err)
+	}
+	if len(result.New) != 0 {
+		t.Errorf("New = %d, want 0", len(result.New))
+	}
+
+	log, _ := store.Load("u")
+	if got := log.Submissions[1].Verdict; got != VerdictWrongAnswer {
+		t.Errorf("pending verdict = %q, want refreshed WRONG_ANSWER", got)
+	}
+}
+
+func TestGetAllSubmissions_OfflineFallback(t *testing.T) {
+	transport := &historyTransport{}
+	transport.add(5)
+	client, store := newSyncClient(t, transport)
+
+	if _, err := client.SyncSubmissions(context.Background(), "u"); err != nil {
+		t.Fatalf("SyncSubmissions() error = %v", err)
+	}
+
+	// A fresh client has no in-memory copy and must read the store
+	transport.offline = true
+	client = NewClient(WithHTTPClient(&http.Client{Transport: transport}), WithSubmissionStore(store))
+
+	subs, err := client.GetAllSubmissions(context.Background(), "u")
+	if err != nil {
+		t.Fatalf("GetAllSubmissions() offline error = %v", err)
+	}
+	if len(subs) != 5 {
+		t.Errorf("GetAllSubmissions() = %d submissions, want 5", len(subs))
+	}
+
+	if _, err := client.GetAllSubmissions(context.Background(), "nobody"); err == nil {
+		t.Error("GetAllSubmissions() should fail offline with nothing stored")
+	}
+}
+
+func TestSyncSubmissions_NoStore(t *testing.T) {
+	client := NewClient()
+	if _, err := client.SyncSubmissions(context.Background(), "u"); err == nil {
+		t.Error("SyncSubmissions() without a store should fail")
+	}
+}
+
+func TestSubmission_ToSchemaSubmission(t *testing.T) {
+	sub := Submission{
+		ID:                  42,
+		CreationTimeSeconds: 1700000000,
+		Problem:             Problem{ContestID: 1325, Index: "A"},
+		ProgrammingLanguage: "GNU C++17",
+		Verdict:             VerdictOK,
+		TimeConsumedMillis:  31,
+		MemoryConsumedBytes: 4096 * 1024,
+	}
+
+	record := sub.ToSchemaSubmission()
+	if record.ID != 42 || record.ProblemID != "1325A" || record.ContestID != 1325 {
+		t.Errorf("identity = %d %s %d", record.ID, record.ProblemID, record.ContestID)
+	}
+	if record.Verdict != "OK" || record.TimeUsed != "31 ms" || record.MemoryUsed != "4096 KB" {
+		t.Errorf("result = %s %s %s", record.Verdict, record.TimeUsed, record.MemoryUsed)
+	}
+	if record.SubmittedAt.Unix() != 1700000000 {
+		t.Errorf("SubmittedAt = %v", record.SubmittedAt)
+	}
+
+	sub.Verdict = VerdictTesting
+	if got := sub.ToSchemaSubmission(); got.Verdict != "TESTING" || got.TimeUsed != "" {
+		t.Errorf("pending record = %s %q", got.Verdict, got.TimeUsed)
+	}
+}
@@ -228,6 +228,11 @@ func (s *Submission) IsAccepted() bool {
 	return s.Verdict == VerdictOK
 }
 
+// IsPending returns true if the submission is still being judged
+func (s *Submission) IsPending() bool {
+	return s.Verdict == "" || s.Verdict == VerdictTesting
+}
+
 // SubmissionTime returns the submission time as time.Time
 func (s *Submission) SubmissionTime() time.Time {
 	return time.Unix(s.CreationTimeSeconds, 0)
@@ -0,0 +1,37 @@
+package cfweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+)
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"rate limited", &StatusError{Page: "problem", StatusCode: 429}, true},
+		{"server error", &StatusError{Page: "problem", StatusCode: 503}, true},
+		{"not found", &StatusError{Page: "problem", StatusCode: 404}, false},
+		{"wrapped status", fmt.Errorf("parse: %w", &StatusError{Page: "gym", StatusCode: 502}), true},
+		{"unexpected EOF", fmt.Errorf("read body: %w", io.ErrUnexpectedEOF), true},
+		{"connection refused", &net.OpError{Op: "dial", Err: errors.New("connection refused")}, true},
+		{"cancelled", fmt.Errorf("fetch: %w", context.Canceled), false},
+		{"deadline", context.DeadlineExceeded, false},
+		{"parse failure", errors.New("problem statement not found"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsTransient(tt.err); got != tt.want {
+				t.Errorf("IsTransient(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package cfweb
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	reBlankLines = regexp.MustCompile(`\n{3,}`)
+
+	// MathJax delimiters used by Codeforces: $$$$$$x$$$$$$ for display math,
+	// $$$x$$$ for inline math
+	mathReplacer = strings.NewReplacer("$$$$$$", "$$", "$$$", "$")
+)
+
+// statementSections are the .problem-statement children that are not part of
+// the legend
+var statementSections = []string{"header", "input-specification", "output-specification", "sample-tests", "note"}
+
+// markdownConverter renders problem statement HTML as Markdown
+type markdownConverter struct {
+	base   *url.URL // resolves relative image and link URLs; may be nil
+	images []string // distinct absolute image URLs, in order of appearance
+}
+
+func newMarkdownConverter(pageURL string) *markdownConverter {
+	c := &markdownConverter{}
+	if u, err := url.Parse(pageURL); err == nil && u.IsAbs() {
+		c.base = u
+	}
+	return c
+}
+
+// legend converts the statement text, skipping the header, input/output
+// specifications, samples and note
+func (c *markdownConverter) legend(statement *goquery.Selection) string {
+	if statement == nil || statement.Length() == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	statement.First().Contents().Each(func(i int, s *goquery.Selection) {
+		for _, class := range statementSections {
+			if s.HasClass(class) {
+				return
+			}
+		}
+		c.node(&sb, s)
+	})
+	return normalizeMarkdown(sb.String())
+}
+
+// convert converts a section such as the input specification. Its
+// section title is dropped.
+func (c *markdownConverter) convert(section *goquery.Selection) string {
+	if section == nil || section.Length() == 0 {
+		return ""
+	}
+	return normalizeMarkdown(c.render(section.First()))
+}
+
+func (c *markdownConverter) render(sel *goquery.Selection) string {
+	var sb strings.Builder
+	sel.Contents().Each(func(i int, s *goquery.Selection) {
+		c.node(&sb, s)
+	})
+	return sb.String()
+}
+
+func (c *markdownConverter) node(sb *strings.Builder, s *goquery.Selection) {
+	name := goquery.NodeName(s)
+	switch name {
+	case "#text":
+		text := mathReplacer.Replace(reWhitespace.ReplaceAllString(s.Text(), " "))
+		if atLineStart(sb) {
+			text = strings.TrimLeft(text, " ")
+		}
+		sb.WriteString(text)
+	case "script", "style", "#comment":
+	case "br":
+		sb.WriteString("\n")
+	case "p", "div", "center", "blockquote":
+		if s.HasClass("section-title") {
+			return
+		}
+		c.block(sb, strings.TrimSpace(c.render(s)))
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		level, _ := strconv.Atoi(name[1:])
+		c.block(sb, strings.Repeat("#", level+2)+" "+strings.TrimSpace(c.render(s)))
+	case "pre":
+		c.block(sb, "```\n"+extractPreContent(s)+"\n```")
+	case "ul", "ol":
+		c.list(sb, s, name == "ol")
+	case "b", "strong":
+		wrapInline(sb, c.render(s), "**")
+	case "i", "em":
+		wrapInline(sb, c.render(s), "*")
+	case "s", "strike", "del":
+		wrapInline(sb, c.render(s), "~~")
+	case "tt", "code":
+		wrapInline(sb, s.Text(), "`")
+	case "span":
+		switch {
+		case s.HasClass("tex-font-style-bf"):
+			wrapInline(sb, c.render(s), "**")
+		case s.HasClass("tex-font-style-it"), s.HasClass("tex-font-style-sl"):
+			wrapInline(sb, c.render(s), "*")
+		case s.HasClass("tex-font-style-tt"):
+			wrapInline(sb, s.Text(), "`")
+		default:
+			sb.WriteString(c.render(s))
+		}
+	case "img":
+		src, _ := s.Attr("src")
+		if src == "" {
+			return
+		}
+		src = c.resolve(src)
+		if !slices.Contains(c.images, src) {
+			c.images = append(c.images, src)
+		}
+		alt, _ := s.Attr("alt")
+		fmt.Fprintf(sb, "![%s](%s)", alt, src)
+	case "a":
+		text := strings.TrimSpace(c.render(s))
+		href, _ := s.Attr("href")
+		if href == "" || text == "" {
+			sb.WriteString(text)
+			return
+		}
+		fmt.Fprintf(sb, "[%s](%s)", text, c.resolve(href))
+	default:
+		sb.WriteString(c.render(s))
+	}
+}
+
+// block writes content as a paragraph separated by blank lines
+func (c *markdownConverter) block(sb *strings.Builder, content string) {
+	if content == "" {
+		return
+	}
+	sb.WriteString("\n\n")
+	sb.WriteString(content)
+	sb.WriteString("\n\n")
+}
+
+// list writes a bulleted or numbered list. Continuation lines, including
+// nested lists, are indented under their item.
+func (c *markdownConverter) list(sb *strings.Builder, s *goquery.Selection, ordered bool) {
+	var items []string
+	s.ChildrenFiltered("li").Each(func(i int, li *goquery.Selection) {
+		marker := "- "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", i+1)
+		}
+		content := normalizeMarkdown(c.render(li))
+		indent := strings.Repeat(" ", len(marker))
+		lines := strings.Split(content, "\n")
+		for j := 1; j < len(lines); j++ {
+			if lines[j] != "" {
+				lines[j] = indent + lines[j]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	})
+	c.block(sb, strings.Join(items, "\n"))
+}
+
+// resolve makes a URL absolute against the page URL
+func (c *markdownConverter) resolve(ref string) string {
+	if c.base == nil {
+		return ref
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+	return c.base.ResolveReference(u).String()
+}
+
+// wrapInline surrounds text with an emphasis marker, keeping surrounding
+// whitespace outside the marker
+func wrapInline(sb *strings.Builder, text, marker string) {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		sb.WriteString(text)
+		return
+	}
+	start := strings.Index(text, trimmed)
+	sb.WriteString(text[:start])
+	sb.WriteString(marker + trimmed + marker)
+	sb.WriteString(text[start+len(trimmed):])
+}
+
+func atLineStart(sb *strings.Builder) bool {
+	return strings.HasSuffix(sb.String(), "\n")
+}
+
+// normalizeMarkdown trims trailing spaces and collapses runs of blank lines
+func normalizeMarkdown(md string) string {
+	lines := strings.Split(md, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	md = strings.Join(lines, "\n")
+	return strings.TrimSpace(reBlankLines.ReplaceAllString(md, "\n\n"))
+}
+
+// Markdown renders the full statement: legend, input and output
+// specifications and note. Samples are not included. Interactive problems
+// that describe the protocol in place of the input and output sections get
+// an "Interaction" heading.
+func (p *ParsedProblem) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(p.Statement)
+
+	inputTitle := "Input"
+	if p.Interactive && p.OutputSpec == "" {
+		inputTitle = "Interaction"
+	}
+
+	for _, section := range []struct{ title, body string }{
+		{inputTitle, p.InputSpec},
+		{"Output", p.OutputSpec},
+		{"Note", p.Note},
+	} {
+		if section.body == "" {
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("\n\n### %s\n\n", section.title))
+		sb.WriteString(section.body)
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// DownloadImages saves the statement images into dir and points the
+// statement at the local copies. Images that fail to download stay linked
+// to Codeforces. Returns the number of images saved.
+func (p *Parser) DownloadImages(problem *ParsedProblem, dir string) (int, error) {
+	if len(problem.Images) == 0 {
+		return 0, nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return 0, fmt.Errorf("create image dir: %w", err)
+	}
+
+	var errs []error
+	saved := 0
+	used := make(map[string]bool)
+
+	for i, src := range problem.Images {
+		name := imageFileName(src, i+1)
+		if used[name] {
+			name = fmt.Sprintf("%d-%s", i+1, name)
+		}
+
+		if err := p.downloadFile(src, filepath.Join(dir, name)); err != nil {
+			errs = append(errs, fmt.Errorf("download %s: %w", src, err))
+			continue
+		}
+		used[name] = true
+		saved++
+
+		replace := func(s string) string {
+			return strings.ReplaceAll(s, "]("+src+")", "]("+name+")")
+		}
+		problem.Statement = replace(problem.Statement)
+		problem.InputSpec = replace(problem.InputSpec)
+		problem.OutputSpec = replace(problem.OutputSpec)
+		problem.Note = replace(problem.Note)
+	}
+
+	return saved, errors.Join(errs...)
+}
+
+func (p *Parser) downloadFile(src, dst string) error {
+	resp, err := p.fetch(src)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	f, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		os.Remove(dst)
+		return err
+	}
+	return f.Close()
+}
+
+// imageFileName derives a local file name from an image URL
+func imageFileName(src string, n int) string {
+	name := ""
+	if u, err := url.Parse(src); err == nil {
+		name = path.Base(u.Path)
+	}
+	if name == "" || name == "." || name == "/" {
+		return fmt.Sprintf("image-%d.png", n)
+	}
+	return name
+}
@@ -0,0 +1,224 @@
+package cfweb
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func convertSection(t *testing.T, html string) string {
+	t.Helper()
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+	if err != nil {
+		t.Fatalf("Failed to parse HTML: %v", err)
+	}
+	return newMarkdownConverter("https://codeforces.com/contest/1/problem/A").convert(doc.Find("div").First())
+}
+
+func TestMarkdownConverter_Convert(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "inline math",
+			html: `<div><p>Given $$$n$$$ integers $$$a_1, a_2, \ldots, a_n$$$.</p></div>`,
+			want: `Given $n$ integers $a_1, a_2, \ldots, a_n$.`,
+		},
+		{
+			name: "display math",
+			html: `<div><p>Compute $$$$$$\sum_{i=1}^{n} a_i$$$$$$</p></div>`,
+			want: `Compute $$\sum_{i=1}^{n} a_i$$`,
+		},
+		{
+			name: "escaped comparison in math",
+			html: `<div><p>$$$1 \le n &lt; 10^5$$$</p></div>`,
+			want: `$1 \le n < 10^5$`,
+		},
+		{
+			name: "paragraphs",
+			html: `<div><p>First
+				line.</p><p>Second.</p></div>`,
+			want: "First line.\n\nSecond.",
+		},
+		{
+			name: "section title dropped",
+			html: `<div><div class="section-title">Input</div><p>One integer.</p></div>`,
+			want: "One integer.",
+		},
+		{
+			name: "bold and italic",
+			html: `<div><p>This is <b>bold</b>, <i>italic</i> and <span class="tex-font-style-bf">tex bold</span>.</p></div>`,
+			want: "This is **bold**, *italic* and **tex bold**.",
+		},
+		{
+			name: "whitespace kept outside emphasis",
+			html: `<div><p>a<b> b </b>c</p></div>`,
+			want: "a **b** c",
+		},
+		{
+			name: "monospace",
+			html: `<div><p>Print <span class="tex-font-style-tt">YES</span> or <code>NO</code>.</p></div>`,
+			want: "Print `YES` or `NO`.",
+		},
+		{
+			name: "unordered list",
+			html: `<div><ul><li>first</li><li>second $$$x$$$</li></ul></div>`,
+			want: "- first\n- second $x$",
+		},
+		{
+			name: "ordered list",
+			html: `<div><p>Steps:</p><ol><li>one</li><li>two</li></ol></div>`,
+			want: "Steps:\n\n1. one\n2. two",
+		},
+		{
+			name: "nested list",
+			html: `<div><ul><li>outer<ul><li>inner</li></ul></li></ul></div>`,
+			want: "- outer\n\n  - inner",
+		},
+		{
+			name: "pre block",
+			html: "<div><p>Example:</p><pre>1 2\n3 4</pre></div>",
+			want: "Example:\n\n```\n1 2\n3 4\n```",
+		},
+		{
+			name: "relative image",
+			html: `<div><center><img src="/predownloaded/ab/cd.png" alt="graph"></center></div>`,
+			want: "![graph](https://codeforces.com/predownloaded/ab/cd.png)",
+		},
+		{
+			name: "absolute link",
+			html: `<div><p>See <a href="https://example.com/x">this</a>.</p></div>`,
+			want: "See [this](https://example.com/x).",
+		},
+		{
+			name: "empty",
+			html: `<div></div>`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := convertSection(t, tt.html)
+			if got != tt.want {
+				t.Errorf("convert() =\n%q\nwant\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParser_ParseProblemHTML_Markdown(t *testing.T) {
+	html := `<html><body>
+<div class="problem-statement">
+	<div class="header"><div class="title">A. Sum</div></div>
+	<div><p>Given $$$n$$$ numbers.</p><p><img src="//espresso.codeforces.com/pic.png"></p></div>
+	<div class="input-specification"><div class="section-title">Input</div><p>The first line contains $$$n$$$.</p></div>
+	<div class="output-specification"><div class="section-title">Output</div><p>Print the <b>sum</b>.</p></div>
+	<div class="sample-tests"><div class="sample-test">
+		<div class="input"><pre>1</pre></div><div class="output"><pre>1</pre></div>
+	</div></div>
+	<div class="note"><div class="section-title">Note</div><ul><li>Trivial.</li></ul></div>
+</div>
+</body></html>`
+
+	parser := NewParser(nil)
+	problem, err := parser.parseProblemHTML(strings.NewReader(html), 1, "A", "https://codeforces.com/contest/1/problem/A")
+	if err != nil {
+		t.Fatalf("parseProblemHTML() error = %v", err)
+	}
+
+	wantStatement := "Given $n$ numbers.\n\n![](https://espresso.codeforces.com/pic.png)"
+	if problem.Statement != wantStatement {
+		t.Errorf("Statement = %q, want %q", problem.Statement, wantStatement)
+	}
+	if problem.InputSpec != "The first line contains $n$." {
+		t.Errorf("InputSpec = %q", problem.InputSpec)
+	}
+	if problem.OutputSpec != "Print the **sum**." {
+		t.Errorf("OutputSpec = %q", problem.OutputSpec)
+	}
+	if problem.Note != "- Trivial." {
+		t.Errorf("Note = %q", problem.Note)
+	}
+	if len(problem.Images) != 1 || problem.Images[0] != "https://espresso.codeforces.com/pic.png" {
+		t.Errorf("Images = %v", problem.Images)
+	}
+
+	md := problem.Markdown()
+	for _, want := range []string{"Given $n$ numbers.", "### Input\n\nThe first line", "### Output\n\nPrint", "### Note\n\n- Trivial."} {
+		if !strings.Contains(md, want) {
+			t.Errorf("Markdown() missing %q:\n%s", want, md)
+		}
+	}
+	if strings.Contains(md, "sample") || strings.Contains(md, "A. Sum") {
+		t.Errorf("Markdown() should not include header or samples:\n%s", md)
+	}
+}
+
+func TestParsedProblem_Markdown_SkipsEmptySections(t *testing.T) {
+	problem := &ParsedProblem{Statement: "Legend.", OutputSpec: "Print it."}
+
+	want := "Legend.\n\n### Output\n\nPrint it."
+	if got := problem.Markdown(); got != want {
+		t.Errorf("Markdown() = %q, want %q", got, want)
+	}
+}
+
+func TestParser_DownloadImages(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.png" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("PNG"))
+	}))
+	defer server.Close()
+
+	ok := server.URL + "/img/graph.png"
+	missing := server.URL + "/missing.png"
+	problem := &ParsedProblem{
+		Statement: "See ![](" + ok + ").",
+		Note:      "![](" + missing + ")",
+		Images:    []string{ok, missing},
+	}
+
+	dir := t.TempDir()
+	parser := NewParser(nil)
+	saved, err := parser.DownloadImages(problem, dir)
+	if saved != 1 {
+		t.Errorf("saved = %d, want 1", saved)
+	}
+	if err == nil {
+		t.Error("DownloadImages() should report the failed image")
+	}
+
+	data, readErr := os.ReadFile(filepath.Join(dir, "graph.png"))
+	if readErr != nil || string(data) != "PNG" {
+		t.Errorf("graph.png = %q, %v", data, readErr)
+	}
+	if problem.Statement != "See ![](graph.png)." {
+		t.Errorf("Statement = %q, want local image reference", problem.Statement)
+	}
+	if problem.Note != "![]("+missing+")" {
+		t.Errorf("Note = %q, failed image should stay linked", problem.Note)
+	}
+}
+
+func TestParsedProblem_Markdown_Interaction(t *testing.T) {
+	p := &ParsedProblem{Statement: "Guess the number.", InputSpec: "Print `? x` to ask.", Interactive: true}
+	if got := p.Markdown(); !strings.Contains(got, "### Interaction\n\nPrint `? x` to ask.") {
+		t.Errorf("Markdown() = %q, want an Interaction section", got)
+	}
+
+	p.OutputSpec = "Print `! x`."
+	if got := p.Markdown(); !strings.Contains(got, "### Input") || strings.Contains(got, "### Interaction") {
+		t.Errorf("Markdown() = %q, want Input and Output sections", got)
+	}
+}
@@ -1381,3 +1381,22 @@ func (e *errorReadCloser) Read(p []byte) (n int, err error) {
 func (e *errorReadCloser) Close() error {
 	return nil
 }
+
+func TestParser_SetRateLimit(t *testing.T) {
+	transport := &mockTransport{statusCode: http.StatusOK, body: "<html></html>"}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+	parser.SetRateLimit(20, 1) // one request every 50ms
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		resp, err := parser.fetch("https://codeforces.com/")
+		if err != nil {
+			t.Fatalf("fetch() error = %v", err)
+		}
+		resp.Body.Close()
+	}
+
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 rate-limited fetches took %v, want >= 100ms", elapsed)
+	}
+}
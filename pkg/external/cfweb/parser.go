@@ -1,15 +1,20 @@
 package cfweb
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
 	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"golang.org/x/time/rate"
 )
 
 // Pre-compiled regexes for performance
@@ -24,6 +29,7 @@ var (
 type Parser struct {
 	session   *Session
 	selectors Selectors
+	limiter   *rate.Limiter // nil means unlimited
 }
 
 // NewParser creates a new parser
@@ -44,6 +50,13 @@ func NewParserWithClient(client *http.Client) *Parser {
 	}
 }
 
+// SetRateLimit limits page fetches to r requests per second with the given
+// burst. The parser is safe for concurrent use, so one limiter can pace
+// several goroutines.
+func (p *Parser) SetRateLimit(r rate.Limit, burst int) {
+	p.limiter = rate.NewLimiter(r, burst)
+}
+
 // ParsedProblem contains parsed problem data
 type ParsedProblem struct {
 	ContestID   int
@@ -51,14 +64,16 @@ type ParsedProblem struct {
 	Name        string
 	TimeLimit   string
 	MemoryLimit string
-	Statement   string
-	InputSpec   string
-	OutputSpec  string
-	Note        string
+	Statement   string   // legend, as Markdown
+	InputSpec   string   // Markdown
+	OutputSpec  string   // Markdown
+	Note        string   // Markdown
+	Images      []string // absolute URLs of statement images
 	Samples     []Sample
 	Tags        []string
 	Rating      int
 	URL         string
+	Interactive bool
 }
 
 // Sample represents a test case
@@ -70,6 +85,10 @@ type Sample struct {
 
 // ParseProblem parses a problem page
 func (p *Parser) ParseProblem(contestID int, index string) (*ParsedProblem, error) {
+	if v1.IsGymContest(contestID) {
+		return p.ParseGymProblem(contestID, index)
+	}
+
 	// Construct problem URL
 	url := fmt.Sprintf("%s/contest/%d/problem/%s", BaseURL, contestID, index)
 
@@ -80,7 +99,7 @@ func (p *Parser) ParseProblem(contestID int, index string) (*ParsedProblem, erro
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("problem page returned status %d", resp.StatusCode)
+		return nil, &StatusError{Page: "problem", StatusCode: resp.StatusCode}
 	}
 
 	return p.parseProblemHTML(resp.Body, contestID, index, url)
@@ -97,7 +116,7 @@ func (p *Parser) ParseProblemset(contestID int, index string) (*ParsedProblem, e
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("problemset page returned status %d", resp.StatusCode)
+		return nil, &StatusError{Page: "problemset", StatusCode: resp.StatusCode}
 	}
 
 	return p.parseProblemHTML(resp.Body, contestID, index, url)
@@ -132,21 +151,20 @@ func (p *Parser) parseProblemHTML(r io.Reader, contestID int, index, url string)
 
 	// Parse statement - get the full problem statement div
 	statementNode := doc.Find(sel.Statement).First()
+	md := newMarkdownConverter(url)
+
+	// Build statement from the legend
+	problem.Statement = md.legend(statementNode)
 
 	// Get input specification
-	inputSpec := doc.Find(sel.InputSpec).First()
-	problem.InputSpec = cleanHTML(inputSpec.Text())
+	problem.InputSpec = md.convert(doc.Find(sel.InputSpec).First())
 
 	// Get output specification
-	outputSpec := doc.Find(sel.OutputSpec).First()
-	problem.OutputSpec = cleanHTML(outputSpec.Text())
+	problem.OutputSpec = md.convert(doc.Find(sel.OutputSpec).First())
 
 	// Get note
-	note := doc.Find(sel.Note).First()
-	problem.Note = cleanHTML(note.Text())
-
-	// Build statement from parts
-	problem.Statement = buildStatement(statementNode)
+	problem.Note = md.convert(doc.Find(sel.Note).First())
+	problem.Images = md.images
 
 	// Parse samples
 	sampleTests := doc.Find(sel.SampleTests)
@@ -175,12 +193,37 @@ func (p *Parser) parseProblemHTML(r io.Reader, contestID int, index, url string)
 		problem.Rating = parseRating(ratingText)
 	}
 
+	problem.Interactive = isInteractive(statementNode, problem.Tags)
+
 	return problem, nil
 }
 
-// ParseContestProblems parses all problems from a contest
+// isInteractive reports whether a statement describes an interactive
+// problem: it is tagged "interactive", has an "Interaction" section, or
+// says so in the legend
+func isInteractive(statement *goquery.Selection, tags []string) bool {
+	if slices.Contains(tags, "interactive") {
+		return true
+	}
+
+	interaction := false
+	statement.Find(".section-title").EachWithBreak(func(i int, s *goquery.Selection) bool {
+		interaction = strings.EqualFold(strings.TrimSpace(s.Text()), "Interaction")
+		return !interaction
+	})
+	if interaction {
+		return true
+	}
+
+	return strings.Contains(strings.ToLower(statement.Text()), "this is an interactive problem")
+}
+
+// ParseContestProblems parses all problems listed on a contest or gym page
 func (p *Parser) ParseContestProblems(contestID int) ([]ParsedProblem, error) {
 	url := fmt.Sprintf("%s/contest/%d", BaseURL, contestID)
+	if v1.IsGymContest(contestID) {
+		url = fmt.Sprintf("%s/gym/%d", BaseURL, contestID)
+	}
 
 	resp, err := p.fetch(url)
 	if err != nil {
@@ -189,7 +232,7 @@ func (p *Parser) ParseContestProblems(contestID int) ([]ParsedProblem, error) {
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("contest page returned status %d", resp.StatusCode)
+		return nil, &StatusError{Page: "contest", StatusCode: resp.StatusCode}
 	}
 
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
@@ -235,6 +278,12 @@ func (p *Parser) ParseContestProblems(contestID int) ([]ParsedProblem, error) {
 
 // fetch makes an HTTP GET request
 func (p *Parser) fetch(url string) (*http.Response, error) {
+	if p.limiter != nil {
+		if err := p.limiter.Wait(context.Background()); err != nil {
+			return nil, fmt.Errorf("rate limit: %w", err)
+		}
+	}
+
 	if p.session != nil && p.session.client != nil {
 		req, err := http.NewRequest(http.MethodGet, url, nil)
 		if err != nil {
@@ -264,6 +313,7 @@ func (p *ParsedProblem) ToSchemaProblem() *v1.Problem {
 	}
 
 	return &v1.Problem{
+		Schema:    schema.NewSchemaHeader(schema.TypeProblem),
 		ID:        fmt.Sprintf("%d%s", p.ContestID, p.Index),
 		Platform:  "codeforces",
 		ContestID: p.ContestID,
@@ -280,6 +330,12 @@ func (p *ParsedProblem) ToSchemaProblem() *v1.Problem {
 		},
 		Samples: samples,
 		// Note: Statement is saved separately as statement.md
+		Practice: v1.PracticeData{
+			Status: v1.StatusUnseen,
+		},
+		Interactive: p.Interactive,
+		FetchedAt:   time.Now(),
+		FetchMethod: v1.FetchMethodWeb,
 	}
 }
 
@@ -305,25 +361,9 @@ func cleanHTML(text string) string {
 	return text
 }
 
+// buildStatement converts the statement legend to Markdown
 func buildStatement(statement *goquery.Selection) string {
-	if statement == nil {
-		return ""
-	}
-
-	var sb strings.Builder
-
-	// Get direct text content, excluding child elements we handle separately
-	statement.Contents().Each(func(i int, s *goquery.Selection) {
-		if goquery.NodeName(s) == "#text" {
-			text := strings.TrimSpace(s.Text())
-			if text != "" {
-				sb.WriteString(text)
-				sb.WriteString(" ")
-			}
-		}
-	})
-
-	return strings.TrimSpace(sb.String())
+	return newMarkdownConverter("").legend(statement)
 }
 
 func parseSamples(sampleTests *goquery.Selection, sel ProblemSelectors) []Sample {
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/PuerkitoBio/goquery"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 )
 
 func TestNewParser(t *testing.T) {
@@ -93,6 +94,12 @@ func TestParsedProblem_ToSchemaProblem(t *testing.T) {
 	if problem.Samples[0].Index != 1 {
 		t.Errorf("Samples[0].Index = %v, want 1", problem.Samples[0].Index)
 	}
+	if problem.FetchMethod != v1.FetchMethodWeb || problem.FetchedAt.IsZero() {
+		t.Errorf("FetchMethod = %q, FetchedAt = %v", problem.FetchMethod, problem.FetchedAt)
+	}
+	if problem.Practice.Status != v1.StatusUnseen {
+		t.Errorf("Practice.Status = %q, want unseen", problem.Practice.Status)
+	}
 }
 
 func TestCleanTitle(t *testing.T) {
@@ -444,3 +451,29 @@ func TestParser_ParseProblemHTML(t *testing.T) {
 		t.Errorf("Rating = %v, want 1200", problem.Rating)
 	}
 }
+
+func TestIsInteractive(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		tags []string
+		want bool
+	}{
+		{"plain", `<div class="problem-statement"><div class="input-specification"><div class="section-title">Input</div></div></div>`, nil, false},
+		{"tagged", `<div class="problem-statement"></div>`, []string{"binary search", "interactive"}, true},
+		{"interaction section", `<div class="problem-statement"><div class="input-specification"><div class="section-title">Interaction</div></div></div>`, nil, true},
+		{"legend", `<div class="problem-statement"><div><p>This is an interactive problem.</p></div></div>`, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := goquery.NewDocumentFromReader(strings.NewReader(tt.html))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := isInteractive(doc.Find(".problem-statement"), tt.tags); got != tt.want {
+				t.Errorf("isInteractive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package cfweb
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// RefKind is the section of Codeforces a problem is reached through
+type RefKind string
+
+const (
+	RefContest    RefKind = "contest"
+	RefProblemset RefKind = "problemset"
+	RefGym        RefKind = "gym"
+	RefGroup      RefKind = "group"
+)
+
+var (
+	reRefCompact = regexp.MustCompile(`^(\d+)\s*/?\s*([A-Za-z][A-Za-z0-9]*)$`)
+	reRefIndex   = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+	refPaths = []struct {
+		kind RefKind
+		re   *regexp.Regexp
+	}{
+		{RefContest, regexp.MustCompile(`^/contest/(\d+)/problem/([A-Za-z0-9]+)/?$`)},
+		{RefProblemset, regexp.MustCompile(`^/problemset/problem/(\d+)/([A-Za-z0-9]+)/?$`)},
+		{RefGym, regexp.MustCompile(`^/gym/(\d+)/problem/([A-Za-z0-9]+)/?$`)},
+		{RefGroup, regexp.MustCompile(`^/group/([A-Za-z0-9_-]+)/contest/(\d+)/problem/([A-Za-z0-9]+)/?$`)},
+	}
+)
+
+// ProblemRef identifies a problem and how to reach it on Codeforces
+type ProblemRef struct {
+	Kind      RefKind
+	GroupID   string // set for RefGroup
+	ContestID int
+	Index     string
+}
+
+// ParseProblemRef parses command arguments naming a problem. Accepted forms
+// are "1325A", "1325 a", "1325/A" and problem URLs under /contest/,
+// /problemset/problem/, /gym/ and /group/<id>/contest/. Bare contest IDs in
+// the gym range resolve to gym problems.
+func ParseProblemRef(args ...string) (ProblemRef, error) {
+	switch len(args) {
+	case 1:
+		arg := strings.TrimSpace(args[0])
+		if strings.Contains(arg, "codeforces.") || strings.HasPrefix(arg, "/") || strings.HasPrefix(arg, "http") {
+			return parseProblemURL(arg)
+		}
+		m := reRefCompact.FindStringSubmatch(arg)
+		if m == nil {
+			return ProblemRef{}, fmt.Errorf("invalid problem %q (expected e.g. 1325A or a problem URL)", arg)
+		}
+		return newProblemRef(m[1], m[2])
+	case 2:
+		if !reRefIndex.MatchString(args[1]) {
+			return ProblemRef{}, fmt.Errorf("invalid problem index: %s", args[1])
+		}
+		return newProblemRef(args[0], args[1])
+	default:
+		return ProblemRef{}, fmt.Errorf("expected <problem> or <contest_id> <problem_index>, got %d argument(s)", len(args))
+	}
+}
+
+func newProblemRef(contest, index string) (ProblemRef, error) {
+	contestID, err := strconv.Atoi(strings.TrimSpace(contest))
+	if err != nil || contestID <= 0 {
+		return ProblemRef{}, fmt.Errorf("invalid contest ID: %s", contest)
+	}
+	kind := RefContest
+	if v1.IsGymContest(contestID) {
+		kind = RefGym
+	}
+	return ProblemRef{Kind: kind, ContestID: contestID, Index: strings.ToUpper(index)}, nil
+}
+
+// parseProblemURL parses a problem URL, with or without scheme and host
+func parseProblemURL(raw string) (ProblemRef, error) {
+	if !strings.Contains(raw, "://") && !strings.HasPrefix(raw, "/") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return ProblemRef{}, fmt.Errorf("invalid problem URL: %w", err)
+	}
+	if u.Host != "" && !strings.HasSuffix(u.Hostname(), "codeforces.com") && !strings.HasSuffix(u.Hostname(), "codeforces.ml") {
+		return ProblemRef{}, fmt.Errorf("not a Codeforces URL: %s", raw)
+	}
+
+	for _, p := range refPaths {
+		m := p.re.FindStringSubmatch(u.Path)
+		if m == nil {
+			continue
+		}
+		var ref ProblemRef
+		if p.kind == RefGroup {
+			ref, err = newProblemRef(m[2], m[3])
+			ref.GroupID = m[1]
+		} else {
+			ref, err = newProblemRef(m[1], m[2])
+		}
+		if err != nil {
+			return ProblemRef{}, err
+		}
+		ref.Kind = p.kind
+		return ref, nil
+	}
+
+	return ProblemRef{}, fmt.Errorf("unrecognized problem URL: %s", raw)
+}
+
+// ID returns the problem ID, e.g. "1325A"
+func (r ProblemRef) ID() string {
+	return fmt.Sprintf("%d%s", r.ContestID, r.Index)
+}
+
+// String returns the problem ID, prefixed with the group for group problems
+func (r ProblemRef) String() string {
+	if r.Kind == RefGroup {
+		return fmt.Sprintf("group/%s/%s", r.GroupID, r.ID())
+	}
+	return r.ID()
+}
+
+// IsGym reports whether the problem belongs to a gym contest
+func (r ProblemRef) IsGym() bool {
+	return r.Kind == RefGym
+}
+
+// URL returns the problem page URL
+func (r ProblemRef) URL() string {
+	switch r.Kind {
+	case RefProblemset:
+		return fmt.Sprintf("%s/problemset/problem/%d/%s", BaseURL, r.ContestID, r.Index)
+	case RefGym:
+		return fmt.Sprintf("%s/gym/%d/problem/%s", BaseURL, r.ContestID, r.Index)
+	case RefGroup:
+		return fmt.Sprintf("%s/group/%s/contest/%d/problem/%s", BaseURL, r.GroupID, r.ContestID, r.Index)
+	default:
+		return fmt.Sprintf("%s/contest/%d/problem/%s", BaseURL, r.ContestID, r.Index)
+	}
+}
+
+// ParseRef parses a problem from the page its reference points to
+func (p *Parser) ParseRef(ref ProblemRef) (*ParsedProblem, error) {
+	url := ref.URL()
+
+	resp, err := p.fetch(url)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s page: %w", ref.Kind, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Page: string(ref.Kind), StatusCode: resp.StatusCode}
+	}
+
+	return p.parseProblemHTML(resp.Body, ref.ContestID, ref.Index, url)
+}
+
+// ParseGymProblem parses a problem from a gym contest
+func (p *Parser) ParseGymProblem(gymID int, index string) (*ParsedProblem, error) {
+	return p.ParseRef(ProblemRef{Kind: RefGym, ContestID: gymID, Index: index})
+}
@@ -0,0 +1,205 @@
+package cfweb
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestParseProblemRef(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		want    ProblemRef
+		wantErr bool
+	}{
+		{
+			name: "compact",
+			args: []string{"1325A"},
+			want: ProblemRef{Kind: RefContest, ContestID: 1325, Index: "A"},
+		},
+		{
+			name: "compact lowercase with number",
+			args: []string{"1559b1"},
+			want: ProblemRef{Kind: RefContest, ContestID: 1559, Index: "B1"},
+		},
+		{
+			name: "two args",
+			args: []string{"1325", "a"},
+			want: ProblemRef{Kind: RefContest, ContestID: 1325, Index: "A"},
+		},
+		{
+			name: "slash separated",
+			args: []string{"1325/C"},
+			want: ProblemRef{Kind: RefContest, ContestID: 1325, Index: "C"},
+		},
+		{
+			name: "gym id",
+			args: []string{"102001", "B"},
+			want: ProblemRef{Kind: RefGym, ContestID: 102001, Index: "B"},
+		},
+		{
+			name: "contest url",
+			args: []string{"https://codeforces.com/contest/1325/problem/A"},
+			want: ProblemRef{Kind: RefContest, ContestID: 1325, Index: "A"},
+		},
+		{
+			name: "problemset url",
+			args: []string{"https://codeforces.com/problemset/problem/1325/D"},
+			want: ProblemRef{Kind: RefProblemset, ContestID: 1325, Index: "D"},
+		},
+		{
+			name: "gym url",
+			args: []string{"https://codeforces.com/gym/102001/problem/C"},
+			want: ProblemRef{Kind: RefGym, ContestID: 102001, Index: "C"},
+		},
+		{
+			name: "group url",
+			args: []string{"https://codeforces.com/group/MWSDmqGsZm/contest/219158/problem/E"},
+			want: ProblemRef{Kind: RefGroup, GroupID: "MWSDmqGsZm", ContestID: 219158, Index: "E"},
+		},
+		{
+			name: "url without scheme",
+			args: []string{"codeforces.com/contest/4/problem/A"},
+			want: ProblemRef{Kind: RefContest, ContestID: 4, Index: "A"},
+		},
+		{
+			name: "url with query and trailing slash",
+			args: []string{"https://codeforces.com/contest/4/problem/A/?locale=en"},
+			want: ProblemRef{Kind: RefContest, ContestID: 4, Index: "A"},
+		},
+		{
+			name: "path only",
+			args: []string{"/gym/102001/problem/A"},
+			want: ProblemRef{Kind: RefGym, ContestID: 102001, Index: "A"},
+		},
+		{name: "contest only", args: []string{"1325"}, wantErr: true},
+		{name: "index first", args: []string{"A1325"}, wantErr: true},
+		{name: "bad contest", args: []string{"abc", "A"}, wantErr: true},
+		{name: "bad index", args: []string{"1325", "?"}, wantErr: true},
+		{name: "contest page url", args: []string{"https://codeforces.com/contest/1325"}, wantErr: true},
+		{name: "other host", args: []string{"https://example.com/contest/1325/problem/A"}, wantErr: true},
+		{name: "no args", args: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseProblemRef(tt.args...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseProblemRef(%v) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("ParseProblemRef(%v) = %+v, want %+v", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProblemRef_URL(t *testing.T) {
+	tests := []struct {
+		ref  ProblemRef
+		url  string
+		name string
+	}{
+		{ProblemRef{Kind: RefContest, ContestID: 1325, Index: "A"}, BaseURL + "/contest/1325/problem/A", "1325A"},
+		{ProblemRef{Kind: RefProblemset, ContestID: 1325, Index: "A"}, BaseURL + "/problemset/problem/1325/A", "1325A"},
+		{ProblemRef{Kind: RefGym, ContestID: 102001, Index: "B"}, BaseURL + "/gym/102001/problem/B", "102001B"},
+		{ProblemRef{Kind: RefGroup, GroupID: "abc", ContestID: 219158, Index: "E"}, BaseURL + "/group/abc/contest/219158/problem/E", "group/abc/219158E"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.ref.URL(); got != tt.url {
+			t.Errorf("URL() = %s, want %s", got, tt.url)
+		}
+		if got := tt.ref.String(); got != tt.name {
+			t.Errorf("String() = %s, want %s", got, tt.name)
+		}
+	}
+}
+
+// recordingTransport serves a fixed page and records requested URLs
+type recordingTransport struct {
+	urls []string
+	body string
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.urls = append(r.urls, req.URL.String())
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(r.body)),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestParser_ParseRef(t *testing.T) {
+	page := `<div class="problem-statement"><div class="header"><div class="title">B. Gym Task</div></div></div>`
+
+	tests := []struct {
+		name  string
+		parse func(p *Parser) (*ParsedProblem, error)
+		want  string
+	}{
+		{
+			name: "gym ref",
+			parse: func(p *Parser) (*ParsedProblem, error) {
+				return p.ParseRef(ProblemRef{Kind: RefGym, ContestID: 102001, Index: "B"})
+			},
+			want: BaseURL + "/gym/102001/problem/B",
+		},
+		{
+			name: "group ref",
+			parse: func(p *Parser) (*ParsedProblem, error) {
+				return p.ParseRef(ProblemRef{Kind: RefGroup, GroupID: "abc", ContestID: 219158, Index: "B"})
+			},
+			want: BaseURL + "/group/abc/contest/219158/problem/B",
+		},
+		{
+			name: "ParseProblem with gym id",
+			parse: func(p *Parser) (*ParsedProblem, error) {
+				return p.ParseProblem(102001, "B")
+			},
+			want: BaseURL + "/gym/102001/problem/B",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &recordingTransport{body: page}
+			parser := NewParserWithClient(&http.Client{Transport: transport})
+
+			problem, err := tt.parse(parser)
+			if err != nil {
+				t.Fatalf("parse error = %v", err)
+			}
+			if len(transport.urls) != 1 || transport.urls[0] != tt.want {
+				t.Errorf("requested %v, want %s", transport.urls, tt.want)
+			}
+			if problem.URL != tt.want || problem.Name != "Gym Task" {
+				t.Errorf("problem = %q at %s", problem.Name, problem.URL)
+			}
+		})
+	}
+}
+
+func TestParser_ParseContestProblems_Gym(t *testing.T) {
+	page := `<table class="problems">
+		<tr><th>#</th></tr>
+		<tr><td class="id"><a href="/gym/102001/problem/A">A</a></td><td><div><a href="/gym/102001/problem/A">Gym Task</a></div></td></tr>
+	</table>`
+
+	transport := &recordingTransport{body: page}
+	parser := NewParserWithClient(&http.Client{Transport: transport})
+
+	problems, err := parser.ParseContestProblems(102001)
+	if err != nil {
+		t.Fatalf("ParseContestProblems() error = %v", err)
+	}
+	if want := BaseURL + "/gym/102001"; len(transport.urls) != 1 || transport.urls[0] != want {
+		t.Errorf("requested %v, want %s", transport.urls, want)
+	}
+	if len(problems) != 1 || problems[0].Index != "A" || problems[0].URL != BaseURL+"/gym/102001/problem/A" {
+		t.Errorf("problems = %+v", problems)
+	}
+}
@@ -1,6 +1,8 @@
 // Package cfweb provides web scraping and submission for Codeforces
 package cfweb
 
+import "strings"
+
 // SelectorVersion represents a versioned set of CSS selectors
 // When CF changes their HTML structure, we add a new version
 type SelectorVersion struct {
@@ -134,26 +136,27 @@ type Language struct {
 	Name        string
 	Extension   string
 	CompilerID  int
+	APIName     string // programmingLanguage reported by the API
 }
 
 // SupportedLanguages lists all supported languages for submission
 var SupportedLanguages = []Language{
-	{ID: "cpp17", Name: "GNU G++17 7.3.0", Extension: ".cpp", CompilerID: 54},
-	{ID: "cpp20", Name: "GNU G++20 11.2.0 (64 bit)", Extension: ".cpp", CompilerID: 89},
-	{ID: "cpp23", Name: "GNU G++23 14.2 (64 bit)", Extension: ".cpp", CompilerID: 91},
-	{ID: "python3", Name: "Python 3.8.10", Extension: ".py", CompilerID: 31},
-	{ID: "pypy3", Name: "PyPy 3.10 (7.3.15)", Extension: ".py", CompilerID: 70},
-	{ID: "java17", Name: "Java 17 64bit", Extension: ".java", CompilerID: 87},
-	{ID: "java21", Name: "Java 21 64bit", Extension: ".java", CompilerID: 88},
-	{ID: "go", Name: "Go 1.22.2", Extension: ".go", CompilerID: 32},
-	{ID: "rust", Name: "Rust 1.75.0 (2021)", Extension: ".rs", CompilerID: 75},
-	{ID: "kotlin", Name: "Kotlin 1.9.21", Extension: ".kt", CompilerID: 83},
-	{ID: "csharp", Name: "C# 10, .NET SDK 6.0", Extension: ".cs", CompilerID: 79},
-	{ID: "ruby", Name: "Ruby 3.2.2", Extension: ".rb", CompilerID: 67},
-	{ID: "js", Name: "JavaScript V8 4.8.0", Extension: ".js", CompilerID: 34},
-	{ID: "php", Name: "PHP 8.1.7", Extension: ".php", CompilerID: 6},
-	{ID: "haskell", Name: "Haskell GHC 8.10.1", Extension: ".hs", CompilerID: 12},
-	{ID: "scala", Name: "Scala 2.12.8", Extension: ".scala", CompilerID: 20},
+	{ID: "cpp17", Name: "GNU G++17 7.3.0", Extension: ".cpp", CompilerID: 54, APIName: "GNU C++17"},
+	{ID: "cpp20", Name: "GNU G++20 11.2.0 (64 bit)", Extension: ".cpp", CompilerID: 89, APIName: "GNU C++20 (64)"},
+	{ID: "cpp23", Name: "GNU G++23 14.2 (64 bit)", Extension: ".cpp", CompilerID: 91, APIName: "C++23 (GCC 14-64, msys2)"},
+	{ID: "python3", Name: "Python 3.8.10", Extension: ".py", CompilerID: 31, APIName: "Python 3"},
+	{ID: "pypy3", Name: "PyPy 3.10 (7.3.15)", Extension: ".py", CompilerID: 70, APIName: "PyPy 3-64"},
+	{ID: "java17", Name: "Java 17 64bit", Extension: ".java", CompilerID: 87, APIName: "Java 17"},
+	{ID: "java21", Name: "Java 21 64bit", Extension: ".java", CompilerID: 88, APIName: "Java 21"},
+	{ID: "go", Name: "Go 1.22.2", Extension: ".go", CompilerID: 32, APIName: "Go"},
+	{ID: "rust", Name: "Rust 1.75.0 (2021)", Extension: ".rs", CompilerID: 75, APIName: "Rust 2021"},
+	{ID: "kotlin", Name: "Kotlin 1.9.21", Extension: ".kt", CompilerID: 83, APIName: "Kotlin 1.9"},
+	{ID: "csharp", Name: "C# 10, .NET SDK 6.0", Extension: ".cs", CompilerID: 79, APIName: "C# 10"},
+	{ID: "ruby", Name: "Ruby 3.2.2", Extension: ".rb", CompilerID: 67, APIName: "Ruby 3"},
+	{ID: "js", Name: "JavaScript V8 4.8.0", Extension: ".js", CompilerID: 34, APIName: "JavaScript"},
+	{ID: "php", Name: "PHP 8.1.7", Extension: ".php", CompilerID: 6, APIName: "PHP"},
+	{ID: "haskell", Name: "Haskell GHC 8.10.1", Extension: ".hs", CompilerID: 12, APIName: "Haskell"},
+	{ID: "scala", Name: "Scala 2.12.8", Extension: ".scala", CompilerID: 20, APIName: "Scala"},
 }
 
 // GetLanguageByExtension returns language info by file extension
@@ -176,6 +179,17 @@ func GetLanguageByID(id string) *Language {
 	return nil
 }
 
+// GetLanguageByAPIName returns language info for a programmingLanguage
+// reported by the API, or nil if it is not a supported language
+func GetLanguageByAPIName(name string) *Language {
+	for i := range SupportedLanguages {
+		if strings.EqualFold(SupportedLanguages[i].APIName, name) || strings.EqualFold(SupportedLanguages[i].Name, name) {
+			return &SupportedLanguages[i]
+		}
+	}
+	return nil
+}
+
 // GetLanguageByCompilerID returns language info by compiler ID
 func GetLanguageByCompilerID(compilerID int) *Language {
 	for i := range SupportedLanguages {
@@ -180,6 +180,32 @@ func TestGetLanguageByID(t *testing.T) {
 	}
 }
 
+func TestGetLanguageByAPIName(t *testing.T) {
+	tests := []struct {
+		name   string
+		wantID string
+	}{
+		{"GNU C++17", "cpp17"},
+		{"gnu c++17", "cpp17"},
+		{"PyPy 3-64", "pypy3"},
+		{"Rust 2021", "rust"},
+		{"GNU G++20 11.2.0 (64 bit)", "cpp20"},
+		{"Befunge", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lang := GetLanguageByAPIName(tt.name)
+			switch {
+			case tt.wantID == "" && lang != nil:
+				t.Errorf("GetLanguageByAPIName(%s) = %v, want nil", tt.name, lang)
+			case tt.wantID != "" && (lang == nil || lang.ID != tt.wantID):
+				t.Errorf("GetLanguageByAPIName(%s) = %v, want %s", tt.name, lang, tt.wantID)
+			}
+		})
+	}
+}
+
 func TestGetLanguageByCompilerID(t *testing.T) {
 	tests := []struct {
 		compilerID int
@@ -1,6 +1,8 @@
 package cfweb
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -11,6 +13,7 @@ import (
 	"time"
 
 	"github.com/PuerkitoBio/goquery"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
 )
 
 // Pre-compiled regexes for parsing submission results
@@ -18,6 +21,7 @@ var (
 	reTimeMs   = regexp.MustCompile(`(\d+)\s*ms`)
 	reMemoryKB = regexp.MustCompile(`(\d+)\s*KB`)
 	reMemoryMB = regexp.MustCompile(`(\d+)\s*MB`)
+	reOnTest   = regexp.MustCompile(`(?i)on (?:pre)?test (\d+)`)
 )
 
 // Submitter handles solution submission to CF
@@ -43,8 +47,39 @@ type SubmissionResult struct {
 	Time         time.Duration
 	Memory       int64 // bytes
 	PassedTests  int
+	CurrentTest  int // test being run, or the failed test; 0 if unknown
 	SubmittedAt  time.Time
-	Status       string
+	Status       string // see Status* constants
+}
+
+// Judging states of a SubmissionResult
+const (
+	StatusInQueue  = "In queue"
+	StatusRunning  = "Running"
+	StatusAccepted = "Accepted"
+	StatusJudged   = "Judged"
+)
+
+// Pending reports whether the submission is still waiting or being judged
+func (r *SubmissionResult) Pending() bool {
+	return r.Status == StatusInQueue || r.Status == StatusRunning
+}
+
+// Progress describes the judging state, e.g. "Running on test 17" or
+// "WRONG_ANSWER on test 3"
+func (r *SubmissionResult) Progress() string {
+	switch {
+	case r.Status == StatusInQueue:
+		return StatusInQueue
+	case r.Status == StatusRunning && r.CurrentTest > 0:
+		return fmt.Sprintf("Running on test %d", r.CurrentTest)
+	case r.Status == StatusRunning:
+		return StatusRunning
+	case r.Verdict != "OK" && r.CurrentTest > 0:
+		return fmt.Sprintf("%s on test %d", r.Verdict, r.CurrentTest)
+	default:
+		return r.Verdict
+	}
 }
 
 // Submit submits a solution to a problem
@@ -254,50 +289,63 @@ func (s *Submitter) getLatestGymSubmission(gymID int, problemIndex string) (*Sub
 
 // WaitForVerdict waits for the submission to be judged
 func (s *Submitter) WaitForVerdict(submissionID int64, contestID int, timeout time.Duration) (*SubmissionResult, error) {
-	deadline := time.Now().Add(timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
 
-	for time.Now().Before(deadline) {
-		result, err := s.GetSubmission(submissionID, contestID)
-		if err != nil {
-			return nil, err
-		}
-
-		// Check if judging is complete
-		if result.Status != "In queue" && result.Status != "Running" {
-			return result, nil
-		}
-
-		time.Sleep(2 * time.Second)
+	result, err := NewVerdictTracker(s, submissionID, contestID).Track(ctx, nil)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timeout waiting for verdict")
 	}
-
-	return nil, fmt.Errorf("timeout waiting for verdict")
+	return result, err
 }
 
 // GetSubmission gets a specific submission's status
 func (s *Submitter) GetSubmission(submissionID int64, contestID int) (*SubmissionResult, error) {
-	statusURL := fmt.Sprintf("%s/contest/%d/submission/%d", BaseURL, contestID, submissionID)
+	return s.GetSubmissionContext(context.Background(), submissionID, contestID)
+}
 
-	resp, err := s.get(statusURL)
+// GetSubmissionContext gets a specific submission's status from its page.
+// It returns ErrVerdictNotFound if the page has no verdict to read.
+func (s *Submitter) GetSubmissionContext(ctx context.Context, submissionID int64, contestID int) (*SubmissionResult, error) {
+	section := "contest"
+	if v1.IsGymContest(contestID) {
+		section = "gym"
+	}
+	statusURL := fmt.Sprintf("%s/%s/%d/submission/%d", BaseURL, section, contestID, submissionID)
+
+	resp, err := s.getContext(ctx, statusURL)
 	if err != nil {
 		return nil, fmt.Errorf("get submission status: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{Page: "submission", StatusCode: resp.StatusCode}
+	}
+
 	doc, err := goquery.NewDocumentFromReader(resp.Body)
 	if err != nil {
 		return nil, fmt.Errorf("parse submission page: %w", err)
 	}
 
-	// Find submission details
-	verdictSpan := doc.Find(".verdict-accepted, .verdict-rejected, .verdict-waiting")
-	verdict := strings.TrimSpace(verdictSpan.Text())
+	// Prefer the verdict span; while queued the wrapper may hold plain text
+	verdictSel := doc.Find(".verdict-accepted, .verdict-rejected, .verdict-waiting, .verdict-failed").First()
+	if verdictSel.Length() == 0 {
+		verdictSel = doc.Find(".submissionVerdictWrapper, td.status-cell").First()
+	}
+	if verdictSel.Length() == 0 {
+		return nil, ErrVerdictNotFound
+	}
 
 	result := &SubmissionResult{
 		SubmissionID: submissionID,
 		ContestID:    contestID,
-		Verdict:      verdict,
 		SubmittedAt:  time.Now(),
 	}
+	result.Status, result.Verdict, result.CurrentTest = parseVerdictText(verdictSel.Text())
+	if result.CurrentTest > 0 {
+		result.PassedTests = result.CurrentTest - 1
+	}
 
 	// Parse time and memory from the info table
 	doc.Find(".datatable tr td").Each(func(i int, sel *goquery.Selection) {
@@ -310,25 +358,17 @@ func (s *Submitter) GetSubmission(submissionID int64, contestID int) (*Submissio
 		}
 	})
 
-	// Determine status
-	switch {
-	case verdict == "" || strings.Contains(verdict, "queue"):
-		result.Status = "In queue"
-	case strings.Contains(verdict, "Running"):
-		result.Status = "Running"
-	case strings.Contains(verdict, "Accepted"):
-		result.Status = "Accepted"
-		result.Verdict = "OK"
-	default:
-		result.Status = "Judged"
-	}
-
 	return result, nil
 }
 
 // get makes a GET request
 func (s *Submitter) get(url string) (*http.Response, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+	return s.getContext(context.Background(), url)
+}
+
+// getContext makes a GET request bound to ctx
+func (s *Submitter) getContext(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -371,24 +411,40 @@ func parseSubmissionRow(row *goquery.Selection, contestID int) (*SubmissionResul
 		SubmissionID: submissionID,
 		ContestID:    contestID,
 		ProblemIndex: problemIndex,
-		Verdict:      normalizeVerdict(verdict),
 		Time:         parseTime(timeText),
 		Memory:       parseMemory(memoryText),
 		SubmittedAt:  time.Now(),
 	}
-
-	// Determine status
-	if verdict == "" || strings.Contains(verdict, "queue") {
-		result.Status = "In queue"
-	} else if strings.Contains(verdict, "Running") {
-		result.Status = "Running"
-	} else {
-		result.Status = "Judged"
+	result.Status, result.Verdict, result.CurrentTest = parseVerdictText(verdict)
+	if result.CurrentTest > 0 {
+		result.PassedTests = result.CurrentTest - 1
 	}
 
 	return result, nil
 }
 
+// parseVerdictText reads a verdict as Codeforces shows it, e.g. "Running on
+// test 17" or "Wrong answer on pretest 3", into a status, a normalized
+// verdict and the test it refers to
+func parseVerdictText(text string) (status, verdict string, test int) {
+	text = strings.Join(strings.Fields(text), " ")
+	if m := reOnTest.FindStringSubmatch(text); m != nil {
+		test, _ = strconv.Atoi(m[1])
+	}
+
+	lower := strings.ToLower(text)
+	switch {
+	case text == "" || strings.Contains(lower, "queue") || strings.Contains(lower, "waiting"):
+		return StatusInQueue, "", 0
+	case strings.Contains(lower, "running") || strings.Contains(lower, "compiling") || strings.Contains(lower, "testing"):
+		return StatusRunning, string(v1.VerdictTesting), test
+	case strings.Contains(text, "Accepted") || strings.Contains(text, "Pretests passed") || strings.Contains(text, "Perfect result"):
+		return StatusAccepted, "OK", 0
+	default:
+		return StatusJudged, normalizeVerdict(text), test
+	}
+}
+
 // parseTime parses time string like "46 ms"
 func parseTime(text string) time.Duration {
 	matches := reTimeMs.FindStringSubmatch(text)
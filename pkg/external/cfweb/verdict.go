@@ -0,0 +1,199 @@
+package cfweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+const (
+	// DefaultMinPollInterval is the delay after the judging state changed
+	DefaultMinPollInterval = time.Second
+	// DefaultMaxPollInterval caps the delay while nothing changes
+	DefaultMaxPollInterval = 5 * time.Second
+
+	// maxPollErrors is how many transient failures in a row Track tolerates
+	maxPollErrors = 5
+)
+
+// ErrVerdictNotFound is returned when a submission page has no verdict,
+// e.g. because the page layout changed
+var ErrVerdictNotFound = errors.New("verdict not found on submission page")
+
+// SubmissionAPI looks up a submission through the Codeforces API;
+// *cfapi.Client implements it
+type SubmissionAPI interface {
+	GetSubmissionStatus(ctx context.Context, handle string, submissionID int64) (*cfapi.Submission, error)
+}
+
+// VerdictTracker follows a submission until it is judged. It reads the
+// submission page and falls back to the API (user.status) when the page
+// cannot be fetched or parsed. Polling starts at MinInterval after every
+// change, such as a new test starting, and slows down towards MaxInterval
+// while nothing changes.
+type VerdictTracker struct {
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	submitter    *Submitter // nil to use the API only
+	api          SubmissionAPI
+	handle       string
+	submissionID int64
+	contestID    int
+
+	interval time.Duration
+	last     *SubmissionResult
+	failures int
+}
+
+// NewVerdictTracker creates a tracker for a submission. submitter may be
+// nil if an API fallback is set.
+func NewVerdictTracker(submitter *Submitter, submissionID int64, contestID int) *VerdictTracker {
+	return &VerdictTracker{
+		MinInterval:  DefaultMinPollInterval,
+		MaxInterval:  DefaultMaxPollInterval,
+		submitter:    submitter,
+		submissionID: submissionID,
+		contestID:    contestID,
+	}
+}
+
+// SetAPIFallback sets the API client used when the submission page fails,
+// and to fill in the number of passed tests once judged
+func (t *VerdictTracker) SetAPIFallback(api SubmissionAPI, handle string) {
+	t.api = api
+	t.handle = handle
+}
+
+// SubmissionID returns the tracked submission
+func (t *VerdictTracker) SubmissionID() int64 {
+	return t.submissionID
+}
+
+// Delay returns how long to wait before the next Poll
+func (t *VerdictTracker) Delay() time.Duration {
+	if t.interval == 0 {
+		return t.MinInterval
+	}
+	return t.interval
+}
+
+// Poll fetches the submission's state once and adapts the polling delay.
+// Once the submission is judged, the API is asked for the passed test count.
+func (t *VerdictTracker) Poll(ctx context.Context) (*SubmissionResult, error) {
+	result, err := t.fetch(ctx)
+	if err != nil {
+		t.failures++
+		t.interval = min(max(t.Delay()*2, t.MinInterval), t.MaxInterval)
+		return nil, err
+	}
+	t.failures = 0
+
+	if t.last != nil && !changed(t.last, result) {
+		t.interval = min(t.Delay()*3/2, t.MaxInterval)
+	} else {
+		t.interval = t.MinInterval
+	}
+
+	if !result.Pending() && result.PassedTests == 0 && t.api != nil && t.handle != "" {
+		if sub, err := t.api.GetSubmissionStatus(ctx, t.handle, t.submissionID); err == nil && sub.Verdict != "" && sub.Verdict != string(v1.VerdictTesting) {
+			result.PassedTests = sub.PassedTestCount
+		}
+	}
+
+	t.last = result
+	return result, nil
+}
+
+// Track polls until the submission is judged or ctx is done, calling
+// onUpdate (if not nil) whenever the judging state changes. Transient
+// failures are retried with a growing delay.
+func (t *VerdictTracker) Track(ctx context.Context, onUpdate func(*SubmissionResult)) (*SubmissionResult, error) {
+	var last *SubmissionResult
+	for {
+		result, err := t.Poll(ctx)
+		switch {
+		case err != nil && ctx.Err() != nil:
+			return last, ctx.Err()
+		case err != nil && (!IsTransient(err) || t.failures >= maxPollErrors):
+			return last, err
+		case err == nil:
+			if onUpdate != nil && (last == nil || changed(last, result)) {
+				onUpdate(result)
+			}
+			last = result
+			if !result.Pending() {
+				return result, nil
+			}
+		}
+
+		select {
+		case <-time.After(t.Delay()):
+		case <-ctx.Done():
+			return last, ctx.Err()
+		}
+	}
+}
+
+// fetch reads the submission page, falling back to the API
+func (t *VerdictTracker) fetch(ctx context.Context) (*SubmissionResult, error) {
+	var webErr error
+	if t.submitter != nil {
+		result, err := t.submitter.GetSubmissionContext(ctx, t.submissionID, t.contestID)
+		if err == nil || t.api == nil || t.handle == "" || ctx.Err() != nil {
+			return result, err
+		}
+		webErr = err
+	} else if t.api == nil {
+		return nil, fmt.Errorf("no submission page or API to poll")
+	}
+
+	sub, err := t.api.GetSubmissionStatus(ctx, t.handle, t.submissionID)
+	if err != nil {
+		if webErr != nil {
+			return nil, fmt.Errorf("%w; api fallback: %w", webErr, err)
+		}
+		return nil, err
+	}
+	return resultFromAPI(sub), nil
+}
+
+// changed reports whether the judging state differs between two results
+func changed(a, b *SubmissionResult) bool {
+	return a.Status != b.Status || a.Verdict != b.Verdict || a.CurrentTest != b.CurrentTest
+}
+
+// resultFromAPI converts an API submission to a SubmissionResult
+func resultFromAPI(sub *cfapi.Submission) *SubmissionResult {
+	result := &SubmissionResult{
+		SubmissionID: sub.ID,
+		ContestID:    sub.ContestID,
+		ProblemIndex: sub.Problem.Index,
+		Verdict:      sub.Verdict,
+		Time:         time.Duration(sub.TimeConsumedMillis) * time.Millisecond,
+		Memory:       sub.MemoryConsumedBytes,
+		PassedTests:  sub.PassedTestCount,
+		SubmittedAt:  time.Unix(sub.CreationTimeSeconds, 0),
+	}
+
+	switch sub.Verdict {
+	case "":
+		result.Status = StatusInQueue
+	case string(v1.VerdictTesting):
+		result.Status = StatusRunning
+		result.CurrentTest = sub.PassedTestCount + 1
+	case "OK":
+		result.Status = StatusAccepted
+	case "COMPILATION_ERROR", "SKIPPED", "REJECTED", "CHALLENGED":
+		result.Status = StatusJudged
+	default:
+		result.Status = StatusJudged
+		// The API counts tests passed before the failing one
+		result.CurrentTest = sub.PassedTestCount + 1
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+package cfweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+)
+
+func TestParseVerdictText(t *testing.T) {
+	tests := []struct {
+		text        string
+		wantStatus  string
+		wantVerdict string
+		wantTest    int
+	}{
+		{"", StatusInQueue, "", 0},
+		{"In queue", StatusInQueue, "", 0},
+		{"Running on test 17", StatusRunning, "TESTING", 17},
+		{"  Running on\n pretest 3 ", StatusRunning, "TESTING", 3},
+		{"Compiling", StatusRunning, "TESTING", 0},
+		{"Accepted", StatusAccepted, "OK", 0},
+		{"Pretests passed", StatusAccepted, "OK", 0},
+		{"Wrong answer on test 3", StatusJudged, "WRONG_ANSWER", 3},
+		{"Time limit exceeded on pretest 12", StatusJudged, "TIME_LIMIT_EXCEEDED", 12},
+		{"Compilation error", StatusJudged, "COMPILATION_ERROR", 0},
+	}
+
+	for _, tt := range tests {
+		status, verdict, test := parseVerdictText(tt.text)
+		if status != tt.wantStatus || verdict != tt.wantVerdict || test != tt.wantTest {
+			t.Errorf("parseVerdictText(%q) = %q, %q, %d; want %q, %q, %d",
+				tt.text, status, verdict, test, tt.wantStatus, tt.wantVerdict, tt.wantTest)
+		}
+	}
+}
+
+func TestSubmissionResult_Progress(t *testing.T) {
+	tests := []struct {
+		result SubmissionResult
+		want   string
+	}{
+		{SubmissionResult{Status: StatusInQueue}, "In queue"},
+		{SubmissionResult{Status: StatusRunning, CurrentTest: 17}, "Running on test 17"},
+		{SubmissionResult{Status: StatusRunning}, "Running"},
+		{SubmissionResult{Status: StatusJudged, Verdict: "WRONG_ANSWER", CurrentTest: 3}, "WRONG_ANSWER on test 3"},
+		{SubmissionResult{Status: StatusAccepted, Verdict: "OK", PassedTests: 42}, "OK"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.result.Progress(); got != tt.want {
+			t.Errorf("Progress() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestResultFromAPI(t *testing.T) {
+	tests := []struct {
+		verdict    string
+		passed     int
+		wantStatus string
+		wantTest   int
+	}{
+		{"", 0, StatusInQueue, 0},
+		{"TESTING", 16, StatusRunning, 17},
+		{"OK", 42, StatusAccepted, 0},
+		{"WRONG_ANSWER", 2, StatusJudged, 3},
+		{"COMPILATION_ERROR", 0, StatusJudged, 0},
+	}
+
+	for _, tt := range tests {
+		result := resultFromAPI(&cfapi.Submission{ID: 1, Verdict: tt.verdict, PassedTestCount: tt.passed})
+		if result.Status != tt.wantStatus || result.CurrentTest != tt.wantTest || result.PassedTests != tt.passed {
+			t.Errorf("resultFromAPI(%s, %d) = %+v", tt.verdict, tt.passed, result)
+		}
+	}
+}
+
+// fakeSubmissionAPI returns its submissions in order, repeating the last
+type fakeSubmissionAPI struct {
+	subs  []cfapi.Submission
+	err   error
+	calls int
+}
+
+func (f *fakeSubmissionAPI) GetSubmissionStatus(ctx context.Context, handle string, submissionID int64) (*cfapi.Submission, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	sub := f.subs[min(f.calls, len(f.subs))-1]
+	return &sub, nil
+}
+
+func newTestTracker(submitter *Submitter, api SubmissionAPI) *VerdictTracker {
+	tracker := NewVerdictTracker(submitter, 123, 1)
+	tracker.MinInterval = time.Millisecond
+	tracker.MaxInterval = 4 * time.Millisecond
+	if api != nil {
+		tracker.SetAPIFallback(api, "testuser")
+	}
+	return tracker
+}
+
+func TestVerdictTracker_Track(t *testing.T) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	session.client.Transport = &sequentialMockTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `<span class="verdict-waiting">In queue</span>`},
+			{statusCode: 200, body: `<span class="verdict-waiting">Running on test 1</span>`},
+			{statusCode: 200, body: `<span class="verdict-waiting">Running on test 1</span>`},
+			{statusCode: 200, body: `<span class="verdict-waiting">Running on test 17</span>`},
+			{statusCode: 200, body: `<span class="verdict-accepted">Accepted</span>`},
+		},
+		callCount: &callCount,
+	}
+	api := &fakeSubmissionAPI{subs: []cfapi.Submission{{ID: 123, Verdict: "OK", PassedTestCount: 42}}}
+	tracker := newTestTracker(&Submitter{session: session}, api)
+
+	var progress []string
+	result, err := tracker.Track(context.Background(), func(r *SubmissionResult) {
+		progress = append(progress, r.Progress())
+	})
+	if err != nil {
+		t.Fatalf("Track() error = %v", err)
+	}
+
+	want := "In queue|Running on test 1|Running on test 17|OK"
+	if got := strings.Join(progress, "|"); got != want {
+		t.Errorf("progress = %s, want %s", got, want)
+	}
+	if result.Verdict != "OK" || result.PassedTests != 42 {
+		t.Errorf("result = %+v, want OK with 42 passed tests from the API", result)
+	}
+}
+
+func TestVerdictTracker_APIFallback(t *testing.T) {
+	callCount := 0
+	session := createMockSession(&mockTransport{})
+	session.client.Transport = &sequentialMockTransport{
+		responses: []mockResponse{
+			{statusCode: 200, body: `<html>redesigned page</html>`},
+			{statusCode: 503, body: ``},
+		},
+		callCount: &callCount,
+	}
+	api := &fakeSubmissionAPI{subs: []cfapi.Submission{
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 4},
+		{ID: 123, Verdict: "WRONG_ANSWER", PassedTestCount: 6},
+	}}
+	tracker := newTestTracker(&Submitter{session: session}, api)
+
+	result, err := tracker.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if result.Progress() != "Running on test 5" {
+		t.Errorf("Progress() = %q, want Running on test 5", result.Progress())
+	}
+
+	result, err = tracker.Poll(context.Background())
+	if err != nil {
+		t.Fatalf("Poll() error = %v", err)
+	}
+	if result.Progress() != "WRONG_ANSWER on test 7" || result.PassedTests != 6 {
+		t.Errorf("result = %+v", result)
+	}
+}
+
+func TestVerdictTracker_Errors(t *testing.T) {
+	// Without a fallback, a page without a verdict is a permanent error
+	session := createMockSession(&mockTransport{statusCode: 200, body: `<html></html>`})
+	tracker := newTestTracker(&Submitter{session: session}, nil)
+	if _, err := tracker.Track(context.Background(), nil); !errors.Is(err, ErrVerdictNotFound) {
+		t.Errorf("Track() error = %v, want ErrVerdictNotFound", err)
+	}
+
+	// Both sources failing reports both errors
+	session = createMockSession(&mockTransport{statusCode: 200, body: `<html></html>`})
+	tracker = newTestTracker(&Submitter{session: session}, &fakeSubmissionAPI{err: fmt.Errorf("api down")})
+	_, err := tracker.Poll(context.Background())
+	if err == nil || !errors.Is(err, ErrVerdictNotFound) || !strings.Contains(err.Error(), "api down") {
+		t.Errorf("Poll() error = %v, want both errors", err)
+	}
+
+	// Transient failures are retried until the context ends
+	session = createMockSession(&mockTransport{statusCode: 502})
+	tracker = newTestTracker(&Submitter{session: session}, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = tracker.Track(ctx, nil)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) && !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Track() error = %v, want status error or deadline", err)
+	}
+}
+
+func TestVerdictTracker_Backoff(t *testing.T) {
+	api := &fakeSubmissionAPI{subs: []cfapi.Submission{
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 0},
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 0},
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 0},
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 0},
+		{ID: 123, Verdict: "TESTING", PassedTestCount: 1},
+	}}
+	tracker := NewVerdictTracker(nil, 123, 1)
+	tracker.SetAPIFallback(api, "testuser")
+
+	var delays []time.Duration
+	for range api.subs {
+		if _, err := tracker.Poll(context.Background()); err != nil {
+			t.Fatalf("Poll() error = %v", err)
+		}
+		delays = append(delays, tracker.Delay())
+	}
+
+	want := []time.Duration{time.Second, 1500 * time.Millisecond, 2250 * time.Millisecond, 3375 * time.Millisecond, time.Second}
+	for i := range want {
+		if delays[i] != want[i] {
+			t.Errorf("delays = %v, want %v", delays, want)
+			break
+		}
+	}
+}
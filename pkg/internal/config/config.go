@@ -44,6 +44,24 @@ func configDir() (string, error) {
 	return filepath.Join(home, ".cf"), nil
 }
 
+// CacheDir returns the directory for cached data (~/.cf/cache)
+func CacheDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "cache"), nil
+}
+
+// DataDir returns the directory for locally synced data (~/.cf/data)
+func DataDir() (string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "data"), nil
+}
+
 // configFilePath returns the config file path
 func configFilePath() (string, error) {
 	dir, err := configDir()
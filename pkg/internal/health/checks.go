@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/config"
+	"github.com/harshit-vibes/cf/pkg/internal/migrate"
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
 	"github.com/harshit-vibes/cf/pkg/internal/workspace"
 )
@@ -179,9 +180,11 @@ func (c *SchemaVersionCheck) Check(ctx context.Context) Result {
 	}
 
 	current := schema.CurrentVersion
+	_, pathErr := migrate.DefaultRegistry.Path(version, current)
+	canMigrate := version.Compare(current) < 0 && pathErr == nil
 
 	if !current.IsCompatible(version) {
-		return Result{
+		result := Result{
 			Name:     c.Name(),
 			Category: c.Category(),
 			Status:   StatusCritical,
@@ -190,15 +193,25 @@ func (c *SchemaVersionCheck) Check(ctx context.Context) Result {
 			Action:   ActionManualFix,
 			Duration: time.Since(start),
 		}
+		if canMigrate {
+			// Migrating backs up every file first, so it is safe to run unattended
+			result.Recoverable = true
+			result.Action = ActionAutoFix
+		}
+		return result
 	}
 
 	if current.NeedsMigration(version) {
+		details := version.String() + " → " + current.String()
+		if canMigrate {
+			details += ". Run: cf migrate"
+		}
 		return Result{
 			Name:        c.Name(),
 			Category:    c.Category(),
 			Status:      StatusDegraded,
 			Message:     "Schema migration available",
-			Details:     version.String() + " → " + current.String(),
+			Details:     details,
 			Recoverable: true,
 			Action:      ActionUserPrompt,
 			Duration:    time.Since(start),
@@ -213,3 +226,9 @@ func (c *SchemaVersionCheck) Check(ctx context.Context) Result {
 		Duration: time.Since(start),
 	}
 }
+
+// AutoFix migrates the workspace to the current schema version
+func (c *SchemaVersionCheck) AutoFix(ctx context.Context) error {
+	_, err := migrate.Migrate(c.ws, migrate.Options{})
+	return err
+}
@@ -0,0 +1,241 @@
+package judge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// DefaultEpsilon is the float checker tolerance when none is configured
+const DefaultEpsilon = 1e-6
+
+// DefaultCheckerTimeout bounds how long an external checker may run
+const DefaultCheckerTimeout = 10 * time.Second
+
+// Checker decides whether a program's output is correct. It returns nil
+// when the output is accepted, and an error if the checker itself failed.
+type Checker interface {
+	Check(ctx context.Context, input, output, answer []byte) (*Mismatch, error)
+}
+
+// NewChecker creates the checker described by a problem's config. Relative
+// custom checker paths are resolved against problemDir. Checkers built from
+// source must be released with Cleanup.
+func NewChecker(ctx context.Context, cfg v1.CheckerConfig, problemDir string) (Checker, error) {
+	switch cfg.Type {
+	case "", v1.CheckerLines:
+		return LinesChecker{}, nil
+	case v1.CheckerTokens:
+		return TokensChecker{}, nil
+	case v1.CheckerFloat:
+		eps := cfg.Epsilon
+		if eps <= 0 {
+			eps = DefaultEpsilon
+		}
+		return FloatChecker{Epsilon: eps}, nil
+	case v1.CheckerYesNo:
+		return YesNoChecker{}, nil
+	case v1.CheckerCustom:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("custom checker needs a path")
+		}
+		path := cfg.Path
+		if !filepath.IsAbs(path) && problemDir != "" {
+			path = filepath.Join(problemDir, path)
+		}
+		return NewExternalChecker(ctx, path)
+	default:
+		return nil, fmt.Errorf("unknown checker type: %s", cfg.Type)
+	}
+}
+
+// LinesChecker compares output line by line, ignoring trailing whitespace
+type LinesChecker struct{}
+
+// Check implements Checker
+func (LinesChecker) Check(_ context.Context, _, output, answer []byte) (*Mismatch, error) {
+	return Compare(output, answer), nil
+}
+
+// TokensChecker compares whitespace-separated tokens exactly
+type TokensChecker struct{}
+
+// Check implements Checker
+func (TokensChecker) Check(_ context.Context, _, output, answer []byte) (*Mismatch, error) {
+	return compareTokens(output, answer, func(got, want string) bool { return got == want }), nil
+}
+
+// YesNoChecker compares tokens case-insensitively, so "Yes" matches "YES"
+type YesNoChecker struct{}
+
+// Check implements Checker
+func (YesNoChecker) Check(_ context.Context, _, output, answer []byte) (*Mismatch, error) {
+	return compareTokens(output, answer, strings.EqualFold), nil
+}
+
+// FloatChecker compares tokens, accepting numbers within Epsilon of the
+// answer in either absolute or relative terms
+type FloatChecker struct {
+	Epsilon float64
+}
+
+// Check implements Checker
+func (c FloatChecker) Check(_ context.Context, _, output, answer []byte) (*Mismatch, error) {
+	return compareTokens(output, answer, func(got, want string) bool {
+		if got == want {
+			return true
+		}
+		g, errG := strconv.ParseFloat(got, 64)
+		w, errW := strconv.ParseFloat(want, 64)
+		if errG != nil || errW != nil || math.IsNaN(g) {
+			return false
+		}
+		diff := math.Abs(g - w)
+		return diff <= c.Epsilon || diff <= c.Epsilon*math.Abs(w)
+	}), nil
+}
+
+// compareTokens reports the first token where equal returns false
+func compareTokens(output, answer []byte, equal func(got, want string) bool) *Mismatch {
+	got := strings.Fields(string(output))
+	want := strings.Fields(string(answer))
+
+	for i := 0; i < len(got) && i < len(want); i++ {
+		if !equal(got[i], want[i]) {
+			return &Mismatch{
+				Message:  fmt.Sprintf("token %d differs", i+1),
+				Expected: want[i],
+				Got:      got[i],
+			}
+		}
+	}
+
+	switch {
+	case len(got) < len(want):
+		return &Mismatch{
+			Message:  fmt.Sprintf("expected %d tokens, got %d", len(want), len(got)),
+			Expected: want[len(got)],
+		}
+	case len(got) > len(want):
+		return &Mismatch{
+			Message: fmt.Sprintf("expected %d tokens, got %d", len(want), len(got)),
+			Got:     got[len(want)],
+		}
+	}
+
+	return nil
+}
+
+// ExternalChecker runs a testlib-style checker as "checker input output answer".
+// Exit code 0 accepts; 1 (WA) and 2 (PE) reject; anything else is a checker failure.
+type ExternalChecker struct {
+	command []string
+	program *Program
+}
+
+// NewExternalChecker creates a checker from an executable, or compiles it
+// first if path is a source file in a known language
+func NewExternalChecker(ctx context.Context, path string) (*ExternalChecker, error) {
+	command, prog, err := prepareTool(ctx, "checker", path)
+	if err != nil {
+		return nil, err
+	}
+	return &ExternalChecker{command: command, program: prog}, nil
+}
+
+// prepareTool returns the command line for a helper program such as a
+// checker. Source files are compiled; the returned program, if any, must
+// be cleaned up.
+func prepareTool(ctx context.Context, name, path string) ([]string, *Program, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, nil, fmt.Errorf("%s not found: %w", name, err)
+	}
+
+	if LanguageForFile(path) != nil {
+		prog, err := Compile(ctx, path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("build %s: %w", name, err)
+		}
+		return prog.Command(), prog, nil
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("resolve %s: %w", name, err)
+	}
+	return []string{abs}, nil, nil
+}
+
+// Cleanup removes the compiled checker, if any
+func (c *ExternalChecker) Cleanup() {
+	if c.program != nil {
+		c.program.Cleanup()
+	}
+}
+
+// Check implements Checker
+func (c *ExternalChecker) Check(ctx context.Context, input, output, answer []byte) (*Mismatch, error) {
+	dir, err := os.MkdirTemp("", "cf-checker-")
+	if err != nil {
+		return nil, fmt.Errorf("create checker dir: %w", err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"input.txt", input},
+		{"output.txt", output},
+		{"answer.txt", answer},
+	}
+	args := append([]string{}, c.command[1:]...)
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, f.data, 0644); err != nil {
+			return nil, fmt.Errorf("write checker %s: %w", f.name, err)
+		}
+		args = append(args, path)
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, DefaultCheckerTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(checkCtx, c.command[0], args...)
+	cmd.Dir = dir
+	var report bytes.Buffer
+	cmd.Stdout = &report
+	cmd.Stderr = &report
+
+	err = cmd.Run()
+	message := strings.TrimSpace(report.String())
+	if err == nil {
+		return nil, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && checkCtx.Err() == nil {
+		switch exitErr.ExitCode() {
+		case 1, 2:
+			if message == "" {
+				message = "rejected by checker"
+			}
+			return &Mismatch{Message: message}, nil
+		}
+	}
+
+	if message != "" {
+		return nil, fmt.Errorf("checker failed: %v: %s", err, message)
+	}
+	return nil, fmt.Errorf("checker failed: %w", err)
+}
@@ -0,0 +1,114 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestCheckers(t *testing.T) {
+	tests := []struct {
+		name    string
+		checker Checker
+		output  string
+		answer  string
+		wantOK  bool
+	}{
+		{"lines ok", LinesChecker{}, "1 2\n", "1 2", true},
+		{"lines spacing", LinesChecker{}, "1  2\n", "1 2\n", false},
+		{"tokens spacing", TokensChecker{}, "1  2\n\n3", "1 2 3\n", true},
+		{"tokens differ", TokensChecker{}, "1 2 4", "1 2 3", false},
+		{"tokens missing", TokensChecker{}, "1 2", "1 2 3", false},
+		{"tokens extra", TokensChecker{}, "1 2 3 4", "1 2 3", false},
+		{"float absolute", FloatChecker{Epsilon: 1e-6}, "0.3333333", "0.333333333", true},
+		{"float relative", FloatChecker{Epsilon: 1e-6}, "1000000001", "1000000000", true},
+		{"float outside", FloatChecker{Epsilon: 1e-6}, "0.334", "0.333333", false},
+		{"float words", FloatChecker{Epsilon: 1e-6}, "YES 1.0", "YES 1", true},
+		{"float nan", FloatChecker{Epsilon: 1e-6}, "nan", "1", false},
+		{"yesno case", YesNoChecker{}, "yes\nNo", "YES\nNO", true},
+		{"yesno wrong", YesNoChecker{}, "yes", "NO", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mismatch, err := tt.checker.Check(context.Background(), nil, []byte(tt.output), []byte(tt.answer))
+			if err != nil {
+				t.Fatalf("Check() error = %v", err)
+			}
+			if (mismatch == nil) != tt.wantOK {
+				t.Errorf("Check() = %+v, wantOK %v", mismatch, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNewChecker(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		cfg     v1.CheckerConfig
+		want    Checker
+		wantErr bool
+	}{
+		{v1.CheckerConfig{}, LinesChecker{}, false},
+		{v1.CheckerConfig{Type: v1.CheckerTokens}, TokensChecker{}, false},
+		{v1.CheckerConfig{Type: v1.CheckerFloat}, FloatChecker{Epsilon: DefaultEpsilon}, false},
+		{v1.CheckerConfig{Type: v1.CheckerFloat, Epsilon: 1e-4}, FloatChecker{Epsilon: 1e-4}, false},
+		{v1.CheckerConfig{Type: v1.CheckerYesNo}, YesNoChecker{}, false},
+		{v1.CheckerConfig{Type: v1.CheckerCustom}, nil, true},
+		{v1.CheckerConfig{Type: v1.CheckerCustom, Path: "missing"}, nil, true},
+		{v1.CheckerConfig{Type: "bogus"}, nil, true},
+	}
+
+	for _, tt := range tests {
+		got, err := NewChecker(ctx, tt.cfg, t.TempDir())
+		if (err != nil) != tt.wantErr {
+			t.Errorf("NewChecker(%+v) error = %v, wantErr %v", tt.cfg, err, tt.wantErr)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("NewChecker(%+v) = %#v, want %#v", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestExternalChecker(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	// Accepts any output whose sum matches the answer; exits 3 on bad input
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "check.py"), []byte(`import sys
+inp, out, ans = (open(p).read().split() for p in sys.argv[1:4])
+if not inp:
+    sys.exit(3)
+if sum(map(int, out)) != int(ans[0]):
+    print("wrong sum")
+    sys.exit(1)
+`), 0644)
+
+	ctx := context.Background()
+	checker, err := NewChecker(ctx, v1.CheckerConfig{Type: v1.CheckerCustom, Path: "check.py"}, dir)
+	if err != nil {
+		t.Fatalf("NewChecker() error = %v", err)
+	}
+	defer checker.(*ExternalChecker).Cleanup()
+
+	if m, err := checker.Check(ctx, []byte("5"), []byte("2 3"), []byte("5")); err != nil || m != nil {
+		t.Errorf("Check() accepted case = %+v, %v", m, err)
+	}
+
+	m, err := checker.Check(ctx, []byte("5"), []byte("1 1"), []byte("5"))
+	if err != nil || m == nil || m.Message != "wrong sum" {
+		t.Errorf("Check() rejected case = %+v, %v; want message 'wrong sum'", m, err)
+	}
+
+	if _, err := checker.Check(ctx, nil, []byte("1"), []byte("1")); err == nil {
+		t.Error("Check() should report checker failure")
+	}
+}
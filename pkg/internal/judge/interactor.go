@@ -0,0 +1,269 @@
+package judge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Side identifies who sent a message during an interaction
+type Side string
+
+const (
+	SideSolution   Side = "solution"
+	SideInteractor Side = "interactor"
+)
+
+// Message is a chunk of data one side wrote to the other
+type Message struct {
+	From Side
+	Data []byte
+}
+
+// Interactor runs a testlib-style interactor as "interactor input output [answer]"
+// with its stdin and stdout cross-wired to the solution's. Exit code 0
+// accepts; 1 (WA) and 2 (PE) reject; anything else is an interactor failure.
+type Interactor struct {
+	command []string
+	program *Program
+}
+
+// NewInteractor creates an interactor from an executable, or compiles it
+// first if path is a source file in a known language
+func NewInteractor(ctx context.Context, path string) (*Interactor, error) {
+	command, prog, err := prepareTool(ctx, "interactor", path)
+	if err != nil {
+		return nil, err
+	}
+	return &Interactor{command: command, program: prog}, nil
+}
+
+// Cleanup removes the compiled interactor, if any
+func (i *Interactor) Cleanup() {
+	if i.program != nil {
+		i.program.Cleanup()
+	}
+}
+
+// Interact runs the solution against the interactor on one test. The limits
+// apply to the solution; the interactor gets DefaultCheckerTimeout on top of
+// its time limit. Both sides of the conversation are kept in the transcript.
+func (i *Interactor) Interact(ctx context.Context, prog *Program, input, answer []byte, limits Limits) *TestResult {
+	result := &TestResult{}
+
+	dir, err := os.MkdirTemp("", "cf-interactor-")
+	if err != nil {
+		return interactorFailed(result, fmt.Errorf("create interactor dir: %w", err))
+	}
+	defer os.RemoveAll(dir)
+
+	args := append([]string{}, i.command[1:]...)
+	write := func(name string, data []byte) error {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return fmt.Errorf("write interactor %s: %w", name, err)
+		}
+		args = append(args, path)
+		return nil
+	}
+	if err := write("input.txt", input); err != nil {
+		return interactorFailed(result, err)
+	}
+	if err := write("output.txt", nil); err != nil {
+		return interactorFailed(result, err)
+	}
+	if answer != nil {
+		if err := write("answer.txt", answer); err != nil {
+			return interactorFailed(result, err)
+		}
+	}
+
+	solCtx := ctx
+	if limits.Time > 0 {
+		var cancel context.CancelFunc
+		solCtx, cancel = context.WithTimeout(ctx, limits.Time)
+		defer cancel()
+	}
+	interCtx, cancelInter := context.WithTimeout(ctx, limits.Time+DefaultCheckerTimeout)
+	defer cancelInter()
+
+	sol := exec.CommandContext(solCtx, prog.command[0], prog.command[1:]...)
+	sol.Dir = prog.dir
+	sol.WaitDelay = time.Second
+	var solStderr bytes.Buffer
+	sol.Stderr = &solStderr
+
+	inter := exec.CommandContext(interCtx, i.command[0], args...)
+	inter.Dir = dir
+	inter.WaitDelay = time.Second
+	var report bytes.Buffer
+	inter.Stderr = &report
+
+	solIn, err1 := sol.StdinPipe()
+	solOut, err2 := sol.StdoutPipe()
+	interIn, err3 := inter.StdinPipe()
+	interOut, err4 := inter.StdoutPipe()
+	if err := errors.Join(err1, err2, err3, err4); err != nil {
+		return interactorFailed(result, fmt.Errorf("create pipes: %w", err))
+	}
+
+	sb := newSandbox(limits, prog.Language)
+	defer sb.cleanup()
+	sb.prepare(sol)
+
+	if err := inter.Start(); err != nil {
+		return interactorFailed(result, fmt.Errorf("start interactor: %w", err))
+	}
+	start := time.Now()
+	if err := sol.Start(); err != nil {
+		cancelInter()
+		inter.Wait()
+		result.Verdict = VerdictRuntimeError
+		result.ExitCode = -1
+		result.Stderr = []byte(err.Error())
+		return result
+	}
+	sb.started(sol.Process.Pid)
+
+	rec := &transcript{}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		rec.pump(SideSolution, solOut, interIn)
+		result.Duration = time.Since(start)
+	}()
+	go func() {
+		defer wg.Done()
+		rec.pump(SideInteractor, interOut, solIn)
+	}()
+	wg.Wait()
+
+	solErr := sol.Wait()
+	interErr := inter.Wait()
+	used := sb.finish(sol.ProcessState)
+
+	result.Transcript = rec.messages
+	result.Output = rec.sent(SideSolution)
+	result.Stderr = solStderr.Bytes()
+	result.CPUTime = used.CPUTime
+	result.Memory = used.Memory
+
+	switch {
+	case used.OOMKilled || (limits.Memory > 0 && used.Memory > limits.Memory):
+		result.Verdict = VerdictMemoryLimitExceeded
+		return result
+	case solCtx.Err() == context.DeadlineExceeded || (limits.CPUTime > 0 && used.CPUTime > limits.CPUTime):
+		result.Verdict = VerdictTimeLimitExceeded
+		result.ExitCode = -1
+		return result
+	}
+
+	// A rejecting interactor decides the verdict even when the solution
+	// crashed afterwards, e.g. on a closed pipe once the interactor quit
+	message := strings.TrimSpace(report.String())
+	var interExit *exec.ExitError
+	if errors.As(interErr, &interExit) && interCtx.Err() == nil {
+		switch interExit.ExitCode() {
+		case 1, 2:
+			if message == "" {
+				message = "rejected by interactor"
+			}
+			result.Verdict = VerdictWrongAnswer
+			result.Mismatch = &Mismatch{Message: message}
+			return result
+		}
+	}
+
+	if solErr != nil {
+		var exitErr *exec.ExitError
+		if errors.As(solErr, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else {
+			result.ExitCode = -1
+			result.Stderr = append(result.Stderr, []byte(solErr.Error())...)
+		}
+		result.Verdict = VerdictRuntimeError
+		return result
+	}
+
+	if interErr != nil {
+		if message != "" {
+			return interactorFailed(result, fmt.Errorf("interactor failed: %v: %s", interErr, message))
+		}
+		return interactorFailed(result, fmt.Errorf("interactor failed: %w", interErr))
+	}
+
+	result.Verdict = VerdictOK
+	return result
+}
+
+func interactorFailed(result *TestResult, err error) *TestResult {
+	result.Verdict = VerdictCheckerFailed
+	result.Mismatch = &Mismatch{Message: err.Error()}
+	return result
+}
+
+// transcript records the data passed between solution and interactor
+type transcript struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+// pump copies src to dst, recording each chunk, and closes dst at EOF.
+// Once dst stops accepting data, src is still drained so its writer
+// never blocks.
+func (t *transcript) pump(from Side, src io.Reader, dst io.WriteCloser) {
+	defer dst.Close()
+
+	buf := make([]byte, 32*1024)
+	open := true
+	for {
+		n, err := src.Read(buf)
+		if n > 0 {
+			data := append([]byte(nil), buf[:n]...)
+			t.record(from, data)
+
+			if open {
+				if _, err := dst.Write(data); err != nil {
+					open = false
+				}
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// record appends data to the transcript, merging consecutive writes from
+// the same side
+func (t *transcript) record(from Side, data []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last := len(t.messages) - 1; last >= 0 && t.messages[last].From == from {
+		t.messages[last].Data = append(t.messages[last].Data, data...)
+		return
+	}
+	t.messages = append(t.messages, Message{From: from, Data: data})
+}
+
+// sent returns everything one side wrote
+func (t *transcript) sent(from Side) []byte {
+	var out []byte
+	for _, m := range t.messages {
+		if m.From == from {
+			out = append(out, m.Data...)
+		}
+	}
+	return out
+}
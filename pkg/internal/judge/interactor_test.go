@@ -0,0 +1,158 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// guessInteractor reads a secret number from the test input and answers
+// "? x" queries with <, > or =; "! x" ends the game
+const guessInteractor = `import sys
+secret = int(open(sys.argv[1]).read())
+for _ in range(20):
+    line = sys.stdin.readline().split()
+    if not line:
+        sys.stderr.write("unexpected end of output")
+        sys.exit(2)
+    x = int(line[1])
+    if line[0] == "!":
+        if x != secret:
+            sys.stderr.write("wrong guess %d" % x)
+            sys.exit(1)
+        sys.exit(0)
+    print("=" if x == secret else ("<" if secret < x else ">"), flush=True)
+sys.stderr.write("too many queries")
+sys.exit(1)
+`
+
+func TestInteractor(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	dir := t.TempDir()
+	interactorPath := filepath.Join(dir, "interactor.py")
+	if err := os.WriteFile(interactorPath, []byte(guessInteractor), 0644); err != nil {
+		t.Fatal(err)
+	}
+	interactor, err := NewInteractor(context.Background(), interactorPath)
+	if err != nil {
+		t.Fatalf("NewInteractor() error = %v", err)
+	}
+	defer interactor.Cleanup()
+
+	tests := []struct {
+		name        string
+		solution    string
+		want        Verdict
+		wantMessage string
+	}{
+		{
+			name: "binary search",
+			solution: `lo, hi = 1, 100
+while True:
+    mid = (lo + hi) // 2
+    print("?", mid, flush=True)
+    r = input()
+    if r == "=":
+        print("!", mid, flush=True)
+        break
+    if r == "<":
+        hi = mid - 1
+    else:
+        lo = mid + 1
+`,
+			want: VerdictOK,
+		},
+		{
+			name:        "wrong guess",
+			solution:    "print('! 1', flush=True)\n",
+			want:        VerdictWrongAnswer,
+			wantMessage: "wrong guess 1",
+		},
+		{
+			name:        "silent",
+			solution:    "pass\n",
+			want:        VerdictWrongAnswer,
+			wantMessage: "unexpected end of output",
+		},
+		{
+			// The interactor quits on the wrong guess, so the solution's next
+			// read fails; the interactor's verdict wins
+			name:        "rejected then crashes",
+			solution:    "print('! 1', flush=True)\ninput()\n",
+			want:        VerdictWrongAnswer,
+			wantMessage: "wrong guess 1",
+		},
+		{
+			name:     "crash",
+			solution: "import sys\nprint('! 37', flush=True)\nsys.exit(3)\n",
+			want:     VerdictRuntimeError,
+		},
+		{
+			name:     "waits forever",
+			solution: "import time\ntime.sleep(10)\n",
+			want:     VerdictTimeLimitExceeded,
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prog := compileScript(t, t.TempDir(), "sol.py", tt.solution)
+
+			result := Evaluate(context.Background(), prog, []byte("37\n"), nil, Options{
+				TimeLimit:  time.Second,
+				Interactor: interactor,
+			})
+
+			if result.Verdict != tt.want {
+				t.Fatalf("test %d: verdict = %s, want %s (mismatch %+v, stderr %q)", i, result.Verdict, tt.want, result.Mismatch, result.Stderr)
+			}
+			if tt.wantMessage != "" && (result.Mismatch == nil || result.Mismatch.Message != tt.wantMessage) {
+				t.Errorf("mismatch = %+v, want message %q", result.Mismatch, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestInteractor_Transcript(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	dir := t.TempDir()
+	interactorPath := filepath.Join(dir, "interactor.py")
+	if err := os.WriteFile(interactorPath, []byte(guessInteractor), 0644); err != nil {
+		t.Fatal(err)
+	}
+	interactor, err := NewInteractor(context.Background(), interactorPath)
+	if err != nil {
+		t.Fatalf("NewInteractor() error = %v", err)
+	}
+	defer interactor.Cleanup()
+
+	prog := compileScript(t, dir, "sol.py", "print('? 50', flush=True)\ninput()\nprint('! 37', flush=True)\n")
+	result := Evaluate(context.Background(), prog, []byte("37\n"), nil, Options{TimeLimit: 2 * time.Second, Interactor: interactor})
+	if result.Verdict != VerdictOK {
+		t.Fatalf("verdict = %s (%+v)", result.Verdict, result.Mismatch)
+	}
+
+	var lines []string
+	for _, m := range result.Transcript {
+		for _, line := range strings.Split(strings.TrimRight(string(m.Data), "\n"), "\n") {
+			lines = append(lines, string(m.From)+": "+line)
+		}
+	}
+	want := "solution: ? 50|interactor: <|solution: ! 37"
+	if got := strings.Join(lines, "|"); got != want {
+		t.Errorf("transcript = %s, want %s", got, want)
+	}
+	if string(result.Output) != "? 50\n! 37\n" {
+		t.Errorf("Output = %q", result.Output)
+	}
+}
@@ -0,0 +1,295 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultTimeLimit is used when a problem has no parsable time limit
+const DefaultTimeLimit = 2 * time.Second
+
+var (
+	reTimeLimit = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(seconds?|s|milliseconds?|ms)$`)
+	reTestIndex = regexp.MustCompile(`(\d+)$`)
+)
+
+// Verdict is the outcome of running one test
+type Verdict string
+
+const (
+	VerdictOK                  Verdict = "OK"
+	VerdictWrongAnswer         Verdict = "WA"
+	VerdictTimeLimitExceeded   Verdict = "TLE"
+	VerdictMemoryLimitExceeded Verdict = "MLE"
+	VerdictOutputLimitExceeded Verdict = "OLE"
+	VerdictRuntimeError        Verdict = "RE"
+	VerdictCompilationError    Verdict = "CE"
+	VerdictCheckerFailed       Verdict = "FAIL"
+)
+
+// TestCase is a single input with its expected answer
+type TestCase struct {
+	Name       string
+	InputPath  string
+	AnswerPath string // empty if the expected output is unknown
+}
+
+// Mismatch describes why output was rejected
+type Mismatch struct {
+	Line     int    // 1-based, 0 if the checker is not line based
+	Message  string // checker explanation, if any
+	Expected string
+	Got      string
+}
+
+// Options controls how tests are run and judged
+type Options struct {
+	TimeLimit   time.Duration // wall clock and CPU time
+	MemoryLimit int64         // bytes, 0 for none
+	OutputLimit int64         // bytes, 0 for none
+	NoFork      bool
+	Checker     Checker     // defaults to LinesChecker
+	Interactor  *Interactor // judges interactively instead of checking output
+}
+
+// Limits returns the sandbox limits for one run of the solution
+func (o Options) Limits() Limits {
+	return Limits{
+		Time:    o.TimeLimit,
+		CPUTime: o.TimeLimit,
+		Memory:  o.MemoryLimit,
+		Output:  o.OutputLimit,
+		NoFork:  o.NoFork,
+	}
+}
+
+// TestResult is the outcome of running a program on a test
+type TestResult struct {
+	Test     TestCase
+	Verdict  Verdict
+	Duration time.Duration
+	CPUTime  time.Duration
+	Memory   int64 // peak, bytes
+	Output   []byte
+	Stderr   []byte
+	ExitCode int
+	Mismatch *Mismatch
+
+	Transcript []Message // interactive tests only
+}
+
+// ParseTimeLimit parses a Codeforces time limit such as "2 seconds"
+func ParseTimeLimit(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	matches := reTimeLimit.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid time limit: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time limit: %q", s)
+	}
+
+	unit := time.Second
+	if strings.HasPrefix(matches[2], "m") {
+		unit = time.Millisecond
+	}
+
+	return time.Duration(value * float64(unit)), nil
+}
+
+// LoadTests finds all tests in a directory. Inputs are *.in files; the
+// expected answer is the matching *.out file when present.
+func LoadTests(dir string) ([]TestCase, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read tests dir: %w", err)
+	}
+
+	var tests []TestCase
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".in" {
+			continue
+		}
+
+		name := strings.TrimSuffix(entry.Name(), ".in")
+		test := TestCase{
+			Name:      name,
+			InputPath: filepath.Join(dir, entry.Name()),
+		}
+
+		answerPath := filepath.Join(dir, name+".out")
+		if _, err := os.Stat(answerPath); err == nil {
+			test.AnswerPath = answerPath
+		}
+
+		tests = append(tests, test)
+	}
+
+	sortTests(tests)
+	return tests, nil
+}
+
+// sortTests orders tests by name, comparing trailing numbers numerically
+// so that sample_10 comes after sample_2
+func sortTests(tests []TestCase) {
+	sort.Slice(tests, func(i, j int) bool {
+		a, b := tests[i].Name, tests[j].Name
+		ma, mb := reTestIndex.FindStringIndex(a), reTestIndex.FindStringIndex(b)
+		if ma != nil && mb != nil && a[:ma[0]] == b[:mb[0]] {
+			na, _ := strconv.Atoi(a[ma[0]:])
+			nb, _ := strconv.Atoi(b[mb[0]:])
+			return na < nb
+		}
+		return a < b
+	})
+}
+
+// Compare checks output against the expected answer, ignoring trailing
+// whitespace on each line and trailing blank lines
+func Compare(output, answer []byte) *Mismatch {
+	got := normalizeLines(string(output))
+	want := normalizeLines(string(answer))
+
+	for i := 0; i < len(got) || i < len(want); i++ {
+		var g, w string
+		if i < len(got) {
+			g = got[i]
+		}
+		if i < len(want) {
+			w = want[i]
+		}
+		if g != w || i >= len(got) || i >= len(want) {
+			return &Mismatch{Line: i + 1, Expected: w, Got: g}
+		}
+	}
+
+	return nil
+}
+
+func normalizeLines(s string) []string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	lines := strings.Split(s, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t\r")
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// RunTest runs the program on a single test and judges the output
+func RunTest(ctx context.Context, prog *Program, test TestCase, opts Options) *TestResult {
+	input, err := os.ReadFile(test.InputPath)
+	if err != nil {
+		return &TestResult{
+			Test:    test,
+			Verdict: VerdictRuntimeError,
+			Stderr:  []byte(fmt.Sprintf("read input: %v", err)),
+		}
+	}
+
+	var answer []byte
+	if test.AnswerPath != "" {
+		answer, err = os.ReadFile(test.AnswerPath)
+		if err != nil {
+			return &TestResult{
+				Test:    test,
+				Verdict: VerdictRuntimeError,
+				Stderr:  []byte(fmt.Sprintf("read answer: %v", err)),
+			}
+		}
+	}
+
+	result := Evaluate(ctx, prog, input, answer, opts)
+	result.Test = test
+	return result
+}
+
+// Evaluate runs the program on the given input and judges its output
+// against answer. A nil answer only checks that the program runs cleanly.
+// With an interactor, the interactor decides instead.
+func Evaluate(ctx context.Context, prog *Program, input, answer []byte, opts Options) *TestResult {
+	if opts.Interactor != nil {
+		return opts.Interactor.Interact(ctx, prog, input, answer, opts.Limits())
+	}
+
+	result := &TestResult{}
+
+	run := prog.RunLimited(ctx, input, opts.Limits())
+	result.Duration = run.Duration
+	result.CPUTime = run.CPUTime
+	result.Memory = run.Memory
+	result.Output = run.Stdout
+	result.Stderr = run.Stderr
+	result.ExitCode = run.ExitCode
+
+	if verdict, failed := runVerdict(run); failed {
+		result.Verdict = verdict
+		if run.Err != nil {
+			result.Stderr = append(result.Stderr, []byte(run.Err.Error())...)
+		}
+		return result
+	}
+
+	if answer == nil {
+		result.Verdict = VerdictOK
+		return result
+	}
+
+	checker := opts.Checker
+	if checker == nil {
+		checker = LinesChecker{}
+	}
+
+	mismatch, err := checker.Check(ctx, input, run.Stdout, answer)
+	if err != nil {
+		result.Verdict = VerdictCheckerFailed
+		result.Mismatch = &Mismatch{Message: err.Error()}
+		return result
+	}
+	if mismatch != nil {
+		result.Verdict = VerdictWrongAnswer
+		result.Mismatch = mismatch
+		return result
+	}
+
+	result.Verdict = VerdictOK
+	return result
+}
+
+// runVerdict classifies a run that broke a limit or did not exit cleanly
+func runVerdict(run *RunResult) (Verdict, bool) {
+	switch {
+	case run.MemoryExceeded:
+		return VerdictMemoryLimitExceeded, true
+	case run.TimedOut:
+		return VerdictTimeLimitExceeded, true
+	case run.OutputExceeded:
+		return VerdictOutputLimitExceeded, true
+	case run.Err != nil, run.ExitCode != 0:
+		return VerdictRuntimeError, true
+	}
+	return VerdictOK, false
+}
+
+// RunTests runs the program on every test in order
+func RunTests(ctx context.Context, prog *Program, tests []TestCase, opts Options) []*TestResult {
+	results := make([]*TestResult, 0, len(tests))
+	for _, test := range tests {
+		if ctx.Err() != nil {
+			break
+		}
+		results = append(results, RunTest(ctx, prog, test, opts))
+	}
+	return results
+}
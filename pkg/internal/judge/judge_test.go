@@ -0,0 +1,208 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTimeLimit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"2 seconds", 2 * time.Second, false},
+		{"1 second", time.Second, false},
+		{"0.5 seconds", 500 * time.Millisecond, false},
+		{"1500 ms", 1500 * time.Millisecond, false},
+		{"3s", 3 * time.Second, false},
+		{"", 0, true},
+		{"fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseTimeLimit(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseTimeLimit(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseTimeLimit(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		answer   string
+		wantLine int // 0 means match
+	}{
+		{"exact", "1 2\n3\n", "1 2\n3\n", 0},
+		{"trailing spaces", "1 2  \n3\t\n", "1 2\n3", 0},
+		{"trailing blank lines", "42\n\n\n", "42\n", 0},
+		{"crlf", "a\r\nb\r\n", "a\nb\n", 0},
+		{"different line", "1\n2\n", "1\n3\n", 2},
+		{"missing line", "1\n", "1\n2\n", 2},
+		{"extra line", "1\n2\n", "1\n", 2},
+		{"leading space matters", " 1\n", "1\n", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Compare([]byte(tt.output), []byte(tt.answer))
+			if tt.wantLine == 0 {
+				if got != nil {
+					t.Errorf("Compare() = %+v, want match", got)
+				}
+				return
+			}
+			if got == nil || got.Line != tt.wantLine {
+				t.Errorf("Compare() = %+v, want mismatch on line %d", got, tt.wantLine)
+			}
+		})
+	}
+}
+
+func TestLoadTests(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"sample_10.in", "sample_10.out", "sample_2.in", "sample_2.out", "sample_1.in", "notes.txt"} {
+		os.WriteFile(filepath.Join(dir, name), []byte("x"), 0644)
+	}
+
+	tests, err := LoadTests(dir)
+	if err != nil {
+		t.Fatalf("LoadTests() error = %v", err)
+	}
+	if len(tests) != 3 {
+		t.Fatalf("LoadTests() returned %d tests, want 3", len(tests))
+	}
+
+	wantNames := []string{"sample_1", "sample_2", "sample_10"}
+	for i, name := range wantNames {
+		if tests[i].Name != name {
+			t.Errorf("tests[%d].Name = %v, want %v", i, tests[i].Name, name)
+		}
+	}
+	if tests[0].AnswerPath != "" {
+		t.Error("sample_1 should have no answer")
+	}
+	if tests[1].AnswerPath == "" {
+		t.Error("sample_2 should have an answer")
+	}
+
+	if _, err := LoadTests(filepath.Join(dir, "missing")); err == nil {
+		t.Error("LoadTests() should error for missing dir")
+	}
+}
+
+func TestLanguageForFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"main.cpp", "cpp"},
+		{"sol.CC", "cpp"},
+		{"a.py", "python"},
+		{"Main.java", "java"},
+		{"notes.txt", ""},
+	}
+
+	for _, tt := range tests {
+		lang := LanguageForFile(tt.path)
+		got := ""
+		if lang != nil {
+			got = lang.Name
+		}
+		if got != tt.want {
+			t.Errorf("LanguageForFile(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRunTests_Python(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	dir := t.TempDir()
+	source := filepath.Join(dir, "main.py")
+	os.WriteFile(source, []byte(`import sys
+a, b = map(int, sys.stdin.read().split())
+if a < 0:
+    raise SystemExit(3)
+if a == 0:
+    while True:
+        pass
+print(a + b)
+`), 0644)
+
+	testsDir := filepath.Join(dir, "tests")
+	os.MkdirAll(testsDir, 0755)
+	writeTest := func(name, input, answer string) {
+		os.WriteFile(filepath.Join(testsDir, name+".in"), []byte(input), 0644)
+		os.WriteFile(filepath.Join(testsDir, name+".out"), []byte(answer), 0644)
+	}
+	writeTest("t1", "1 2\n", "3\n")
+	writeTest("t2", "2 2\n", "5\n")
+	writeTest("t3", "-1 2\n", "1\n")
+	writeTest("t4", "0 2\n", "2\n")
+
+	ctx := context.Background()
+	prog, err := Compile(ctx, source)
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+	defer prog.Cleanup()
+
+	tests, err := LoadTests(testsDir)
+	if err != nil {
+		t.Fatalf("LoadTests() error = %v", err)
+	}
+
+	results := RunTests(ctx, prog, tests, Options{TimeLimit: 500 * time.Millisecond})
+	want := []Verdict{VerdictOK, VerdictWrongAnswer, VerdictRuntimeError, VerdictTimeLimitExceeded}
+	if len(results) != len(want) {
+		t.Fatalf("RunTests() returned %d results, want %d", len(results), len(want))
+	}
+	for i, v := range want {
+		if results[i].Verdict != v {
+			t.Errorf("%s verdict = %v, want %v", results[i].Test.Name, results[i].Verdict, v)
+		}
+	}
+	if results[1].Mismatch == nil || results[1].Mismatch.Got != "4" {
+		t.Errorf("t2 mismatch = %+v, want got 4", results[1].Mismatch)
+	}
+	if results[2].ExitCode != 3 {
+		t.Errorf("t3 exit code = %d, want 3", results[2].ExitCode)
+	}
+}
+
+func TestCompile_Error(t *testing.T) {
+	if _, err := exec.LookPath("g++"); err != nil {
+		t.Skip("g++ not available")
+	}
+
+	source := filepath.Join(t.TempDir(), "main.cpp")
+	os.WriteFile(source, []byte("int main() { return x; }\n"), 0644)
+
+	_, err := Compile(context.Background(), source)
+	compileErr, ok := err.(*CompileError)
+	if !ok {
+		t.Fatalf("Compile() error = %v, want *CompileError", err)
+	}
+	if compileErr.Output == "" {
+		t.Error("CompileError should include compiler output")
+	}
+}
+
+func TestCompile_Unsupported(t *testing.T) {
+	if _, err := Compile(context.Background(), "main.xyz"); err == nil {
+		t.Error("Compile() should fail for unsupported extension")
+	}
+}
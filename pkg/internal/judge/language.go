@@ -0,0 +1,118 @@
+// Package judge compiles solutions and runs them against local tests
+package judge
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Placeholders expanded in language command templates
+const (
+	PlaceholderSource = "{src}"   // path to the source file
+	PlaceholderBinary = "{bin}"   // path to the compiled binary
+	PlaceholderDir    = "{dir}"   // build directory
+	PlaceholderClass  = "{class}" // source file name without extension
+)
+
+// Language describes how to build and run solutions in one language
+type Language struct {
+	Name       string
+	Extensions []string
+	Compile    []string // empty for interpreted languages
+	Run        []string
+	Threaded   bool // runtime starts threads and reserves address space up front
+}
+
+// Languages lists the languages the local judge knows how to run
+var Languages = []Language{
+	{
+		Name:       "cpp",
+		Extensions: []string{".cpp", ".cc", ".cxx"},
+		Compile:    []string{"g++", "-std=c++17", "-O2", "-o", PlaceholderBinary, PlaceholderSource},
+		Run:        []string{PlaceholderBinary},
+	},
+	{
+		Name:       "c",
+		Extensions: []string{".c"},
+		Compile:    []string{"gcc", "-O2", "-o", PlaceholderBinary, PlaceholderSource, "-lm"},
+		Run:        []string{PlaceholderBinary},
+	},
+	{
+		Name:       "python",
+		Extensions: []string{".py"},
+		Run:        []string{"python3", PlaceholderSource},
+	},
+	{
+		Name:       "go",
+		Extensions: []string{".go"},
+		Compile:    []string{"go", "build", "-o", PlaceholderBinary, PlaceholderSource},
+		Run:        []string{PlaceholderBinary},
+		Threaded:   true,
+	},
+	{
+		Name:       "rust",
+		Extensions: []string{".rs"},
+		Compile:    []string{"rustc", "-O", "--edition", "2021", "-o", PlaceholderBinary, PlaceholderSource},
+		Run:        []string{PlaceholderBinary},
+	},
+	{
+		Name:       "java",
+		Extensions: []string{".java"},
+		Compile:    []string{"javac", "-d", PlaceholderDir, PlaceholderSource},
+		Run:        []string{"java", "-Xss64m", "-cp", PlaceholderDir, PlaceholderClass},
+		Threaded:   true,
+	},
+	{
+		Name:       "kotlin",
+		Extensions: []string{".kt"},
+		Compile:    []string{"kotlinc", PlaceholderSource, "-include-runtime", "-d", PlaceholderBinary + ".jar"},
+		Run:        []string{"java", "-jar", PlaceholderBinary + ".jar"},
+		Threaded:   true,
+	},
+	{
+		Name:       "javascript",
+		Extensions: []string{".js"},
+		Run:        []string{"node", PlaceholderSource},
+		Threaded:   true,
+	},
+	{
+		Name:       "ruby",
+		Extensions: []string{".rb"},
+		Run:        []string{"ruby", PlaceholderSource},
+	},
+}
+
+// LanguageForFile returns the language for a source file, or nil if unknown
+func LanguageForFile(path string) *Language {
+	ext := strings.ToLower(filepath.Ext(path))
+	for i := range Languages {
+		for _, e := range Languages[i].Extensions {
+			if e == ext {
+				return &Languages[i]
+			}
+		}
+	}
+	return nil
+}
+
+// IsCompiled returns true if the language needs a compile step
+func (l *Language) IsCompiled() bool {
+	return len(l.Compile) > 0
+}
+
+// expand replaces placeholders in a command template
+func expand(template []string, src, bin, dir string) []string {
+	class := strings.TrimSuffix(filepath.Base(src), filepath.Ext(src))
+	replacer := strings.NewReplacer(
+		PlaceholderSource, src,
+		PlaceholderBinary, bin,
+		PlaceholderDir, dir,
+		PlaceholderClass, class,
+	)
+
+	args := make([]string, len(template))
+	for i, arg := range template {
+		args[i] = replacer.Replace(arg)
+	}
+	return args
+}
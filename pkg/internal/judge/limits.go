@@ -0,0 +1,93 @@
+package judge
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultMemoryLimit is used when a problem has no parsable memory limit
+	DefaultMemoryLimit = 256 << 20
+	// DefaultOutputLimit bounds how much a solution may print
+	DefaultOutputLimit = 64 << 20
+)
+
+var (
+	reMemoryLimit = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*(gigabytes?|gb|g|megabytes?|mb|m|kilobytes?|kb|k|bytes?|b)$`)
+
+	// reOutOfMemory matches runtime messages for failed allocations, which is
+	// how an address-space limit shows up
+	reOutOfMemory = regexp.MustCompile(`std::bad_alloc|MemoryError|OutOfMemoryError|memory allocation of \d+ bytes failed|out of memory`)
+)
+
+// Limits bounds the resources of a single run. Zero values mean no limit.
+type Limits struct {
+	Time    time.Duration // wall clock
+	CPUTime time.Duration
+	Memory  int64 // peak memory, bytes
+	Output  int64 // stdout and written files, bytes
+	NoFork  bool  // forbid creating processes (not applied to threaded runtimes)
+}
+
+// ParseMemoryLimit parses a Codeforces memory limit such as "256 megabytes"
+func ParseMemoryLimit(s string) (int64, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	matches := reMemoryLimit.FindStringSubmatch(s)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid memory limit: %q", s)
+	}
+
+	value, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory limit: %q", s)
+	}
+
+	unit := int64(1)
+	switch matches[2][0] {
+	case 'g':
+		unit = 1 << 30
+	case 'm':
+		unit = 1 << 20
+	case 'k':
+		unit = 1 << 10
+	}
+
+	return int64(value * float64(unit)), nil
+}
+
+// FormatMemory formats a byte count the way Codeforces does, e.g. "262144 KB"
+func FormatMemory(bytes int64) string {
+	return fmt.Sprintf("%d KB", bytes/1024)
+}
+
+// limitedBuffer collects output up to a limit and fails writes beyond it,
+// which closes the pipe to the writing process
+type limitedBuffer struct {
+	buf      []byte
+	limit    int64 // 0 for no limit
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.limit > 0 && int64(len(b.buf)+len(p)) > b.limit {
+		n := int(b.limit) - len(b.buf)
+		b.buf = append(b.buf, p[:n]...)
+		b.exceeded = true
+		return n, errOutputLimit
+	}
+	b.buf = append(b.buf, p...)
+	return len(p), nil
+}
+
+var errOutputLimit = errors.New("output limit exceeded")
+
+// usage is the resource usage of a finished run
+type usage struct {
+	CPUTime   time.Duration
+	Memory    int64 // peak, bytes
+	OOMKilled bool  // killed by the memory controller
+}
@@ -0,0 +1,106 @@
+package judge
+
+import (
+	"context"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestParseMemoryLimit(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int64
+		wantErr bool
+	}{
+		{"256 megabytes", 256 << 20, false},
+		{"1 megabyte", 1 << 20, false},
+		{"1 gigabyte", 1 << 30, false},
+		{"512 MB", 512 << 20, false},
+		{"64m", 64 << 20, false},
+		{"1.5 GB", 3 << 29, false},
+		{"65536 KB", 64 << 20, false},
+		{"", 0, true},
+		{"lots", 0, true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMemoryLimit(tt.input)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseMemoryLimit(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseMemoryLimit(%q) = %d, want %d", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRunLimited(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	tests := []struct {
+		name   string
+		source string
+		limits Limits
+		want   Verdict
+	}{
+		{
+			name:   "within limits",
+			source: "print(sum(range(10**5)))\n",
+			limits: Limits{Time: 2 * time.Second, CPUTime: 2 * time.Second, Memory: 256 << 20, Output: 1 << 20},
+			want:   VerdictOK,
+		},
+		{
+			name:   "memory",
+			source: "a = bytearray(300 * 1024 * 1024)\nfor i in range(0, len(a), 4096):\n    a[i] = 1\n",
+			limits: Limits{Time: 5 * time.Second, Memory: 64 << 20},
+			want:   VerdictMemoryLimitExceeded,
+		},
+		{
+			name:   "cpu time",
+			source: "while True:\n    pass\n",
+			limits: Limits{Time: 3 * time.Second, CPUTime: 300 * time.Millisecond},
+			want:   VerdictTimeLimitExceeded,
+		},
+		{
+			name:   "output",
+			source: "import sys\nwhile True:\n    sys.stdout.write('x' * 4096)\n",
+			limits: Limits{Time: 5 * time.Second, Output: 1 << 16},
+			want:   VerdictOutputLimitExceeded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prog := compileScript(t, t.TempDir(), "main.py", tt.source)
+			run := prog.RunLimited(context.Background(), nil, tt.limits)
+
+			got, _ := runVerdict(run)
+			if got != tt.want {
+				t.Errorf("verdict = %s, want %s (exit %d, cpu %v, memory %d, stderr %q)",
+					got, tt.want, run.ExitCode, run.CPUTime, run.Memory, run.Stderr)
+			}
+		})
+	}
+}
+
+func TestRunLimited_Usage(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	prog := compileScript(t, t.TempDir(), "main.py", "import time\nt = time.process_time()\nwhile time.process_time() - t < 0.2:\n    pass\n")
+	run := prog.RunLimited(context.Background(), nil, Limits{Time: 5 * time.Second})
+	if run.ExitCode != 0 {
+		t.Fatalf("exit code = %d, stderr %q", run.ExitCode, run.Stderr)
+	}
+	if run.CPUTime < 150*time.Millisecond {
+		t.Errorf("CPUTime = %v, want at least 200ms", run.CPUTime)
+	}
+	if SandboxMode() != "none" && run.Memory <= 0 {
+		t.Errorf("Memory = %d, want peak memory to be measured", run.Memory)
+	}
+}
@@ -0,0 +1,210 @@
+package judge
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCompileTimeout bounds how long a compiler may run
+const DefaultCompileTimeout = 60 * time.Second
+
+// Program is a solution that is ready to run
+type Program struct {
+	Language *Language
+	Source   string
+	dir      string
+	command  []string
+}
+
+// CompileError is returned when a solution fails to compile
+type CompileError struct {
+	Output string
+	Err    error
+}
+
+// Error implements the error interface
+func (e *CompileError) Error() string {
+	return fmt.Sprintf("compilation failed: %v", e.Err)
+}
+
+// Unwrap returns the underlying cause
+func (e *CompileError) Unwrap() error {
+	return e.Err
+}
+
+// Compile prepares a source file for running, compiling it if needed.
+// Call Cleanup on the returned program when done.
+func Compile(ctx context.Context, source string) (*Program, error) {
+	lang := LanguageForFile(source)
+	if lang == nil {
+		return nil, fmt.Errorf("unsupported language: %s", filepath.Ext(source))
+	}
+
+	absSource, err := filepath.Abs(source)
+	if err != nil {
+		return nil, fmt.Errorf("resolve source: %w", err)
+	}
+
+	dir, err := os.MkdirTemp("", "cf-judge-")
+	if err != nil {
+		return nil, fmt.Errorf("create build dir: %w", err)
+	}
+
+	// Build from a copy so compilers that care about file names (javac)
+	// and concurrent runs never touch the workspace
+	buildSource := filepath.Join(dir, filepath.Base(absSource))
+	if err := copyFile(absSource, buildSource); err != nil {
+		os.RemoveAll(dir)
+		return nil, fmt.Errorf("copy source: %w", err)
+	}
+
+	bin := filepath.Join(dir, "solution")
+
+	if lang.IsCompiled() {
+		compileCtx, cancel := context.WithTimeout(ctx, DefaultCompileTimeout)
+		defer cancel()
+
+		args := expand(lang.Compile, buildSource, bin, dir)
+		cmd := exec.CommandContext(compileCtx, args[0], args[1:]...)
+		cmd.Dir = dir
+
+		var output bytes.Buffer
+		cmd.Stdout = &output
+		cmd.Stderr = &output
+
+		if err := cmd.Run(); err != nil {
+			os.RemoveAll(dir)
+			return nil, &CompileError{Output: output.String(), Err: err}
+		}
+	}
+
+	return &Program{
+		Language: lang,
+		Source:   absSource,
+		dir:      dir,
+		command:  expand(lang.Run, buildSource, bin, dir),
+	}, nil
+}
+
+// Command returns the command line used to run the program
+func (p *Program) Command() []string {
+	return p.command
+}
+
+// Cleanup removes build artifacts
+func (p *Program) Cleanup() {
+	if p.dir != "" {
+		os.RemoveAll(p.dir)
+	}
+}
+
+// RunResult holds the outcome of a single execution
+type RunResult struct {
+	Stdout   []byte
+	Stderr   []byte
+	Duration time.Duration
+	CPUTime  time.Duration
+	Memory   int64 // peak, bytes
+	ExitCode int
+	TimedOut bool  // wall clock or CPU time limit exceeded
+	Err      error // set when the process could not be started
+
+	MemoryExceeded bool
+	OutputExceeded bool
+}
+
+// Run executes the program with the given stdin and wall-clock time limit
+func (p *Program) Run(ctx context.Context, input []byte, timeLimit time.Duration, args ...string) *RunResult {
+	return p.RunLimited(ctx, input, Limits{Time: timeLimit}, args...)
+}
+
+// RunLimited executes the program with the given stdin inside a sandbox
+// enforcing limits, and reports its CPU time and peak memory
+func (p *Program) RunLimited(ctx context.Context, input []byte, limits Limits, args ...string) *RunResult {
+	runCtx := ctx
+	if limits.Time > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, limits.Time)
+		defer cancel()
+	}
+
+	command := append(append([]string{}, p.command...), args...)
+	cmd := exec.CommandContext(runCtx, command[0], command[1:]...)
+	cmd.Dir = p.dir
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.WaitDelay = time.Second
+
+	stdout := &limitedBuffer{limit: limits.Output}
+	var stderr bytes.Buffer
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+
+	sb := newSandbox(limits, p.Language)
+	defer sb.cleanup()
+	sb.prepare(cmd)
+
+	start := time.Now()
+	err := cmd.Start()
+	if err == nil {
+		sb.started(cmd.Process.Pid)
+		err = cmd.Wait()
+	}
+	used := sb.finish(cmd.ProcessState)
+
+	result := &RunResult{
+		Duration:       time.Since(start),
+		CPUTime:        used.CPUTime,
+		Memory:         used.Memory,
+		Stdout:         stdout.buf,
+		Stderr:         stderr.Bytes(),
+		OutputExceeded: stdout.exceeded,
+		MemoryExceeded: used.OOMKilled || (limits.Memory > 0 && used.Memory > limits.Memory),
+	}
+	if limits.Memory > 0 && err != nil && reOutOfMemory.Match(result.Stderr) {
+		result.MemoryExceeded = true
+	}
+
+	if runCtx.Err() == context.DeadlineExceeded || (limits.CPUTime > 0 && used.CPUTime > limits.CPUTime) {
+		result.TimedOut = true
+		result.ExitCode = -1
+		return result
+	}
+
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			result.ExitCode = exitErr.ExitCode()
+		} else if !stdout.exceeded {
+			result.Err = err
+			result.ExitCode = -1
+		}
+	}
+
+	return result
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
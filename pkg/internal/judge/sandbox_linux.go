@@ -0,0 +1,221 @@
+package judge
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// addressSpaceSlack is added to the memory limit for RLIMIT_AS, which
+	// also counts shared libraries and reserved but untouched memory
+	addressSpaceSlack = 64 << 20
+)
+
+var (
+	cgroupOnce   sync.Once
+	cgroupParent string // cgroup v2 directory runs are placed under, empty if unusable
+	cgroupPids   bool   // the pids controller is available there
+	cgroupSeq    atomic.Int64
+)
+
+// SandboxMode reports how run limits are enforced on this system:
+// "cgroup" (cgroups v2) or "rlimit"
+func SandboxMode() string {
+	if findCgroupParent() != "" {
+		return "cgroup"
+	}
+	return "rlimit"
+}
+
+// findCgroupParent locates a cgroup v2 directory we may create child groups
+// in: our own cgroup, with the memory controller enabled for its children
+func findCgroupParent() string {
+	cgroupOnce.Do(func() {
+		if _, err := os.Stat(filepath.Join(cgroupRoot, "cgroup.controllers")); err != nil {
+			return
+		}
+
+		data, err := os.ReadFile("/proc/self/cgroup")
+		if err != nil {
+			return
+		}
+		var self string
+		for _, line := range strings.Split(string(data), "\n") {
+			if path, ok := strings.CutPrefix(line, "0::"); ok {
+				self = path
+			}
+		}
+		if self == "" {
+			return
+		}
+
+		dir := filepath.Join(cgroupRoot, self)
+		controllers, err := os.ReadFile(filepath.Join(dir, "cgroup.subtree_control"))
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(string(controllers))
+		if !slices.Contains(fields, "memory") || unix.Access(dir, unix.W_OK) != nil {
+			return
+		}
+
+		cgroupParent = dir
+		cgroupPids = slices.Contains(fields, "pids")
+	})
+	return cgroupParent
+}
+
+// sandbox applies Limits to one process: a fresh cgroup v2 group when
+// possible, rlimits otherwise. Its usage is measured either way.
+type sandbox struct {
+	limits   Limits
+	threaded bool
+	cgroup   string // group directory, empty when using rlimits
+	cgroupFD int
+}
+
+func newSandbox(limits Limits, lang *Language) *sandbox {
+	return &sandbox{
+		limits:   limits,
+		threaded: lang != nil && lang.Threaded,
+		cgroupFD: -1,
+	}
+}
+
+// prepare configures cmd to start inside the sandbox
+func (s *sandbox) prepare(cmd *exec.Cmd) {
+	if s.limits.Memory <= 0 && !s.limits.NoFork {
+		return
+	}
+
+	parent := findCgroupParent()
+	if parent == "" {
+		return
+	}
+
+	dir := filepath.Join(parent, fmt.Sprintf("cf-judge-%d-%d", os.Getpid(), cgroupSeq.Add(1)))
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return
+	}
+
+	settings := map[string]string{}
+	if s.limits.Memory > 0 {
+		settings["memory.max"] = strconv.FormatInt(s.limits.Memory, 10)
+		settings["memory.swap.max"] = "0"
+	}
+	if s.limits.NoFork && !s.threaded && cgroupPids {
+		settings["pids.max"] = "1"
+	}
+	for name, value := range settings {
+		// Swap accounting may be disabled; everything else must apply
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(value), 0644); err != nil && name != "memory.swap.max" {
+			os.Remove(dir)
+			return
+		}
+	}
+
+	fd, err := unix.Open(dir, unix.O_DIRECTORY|unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		os.Remove(dir)
+		return
+	}
+
+	s.cgroup, s.cgroupFD = dir, fd
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.UseCgroupFD = true
+	cmd.SysProcAttr.CgroupFD = fd
+}
+
+// started applies rlimits to the running process. os/exec cannot set them
+// before exec, so there is a brief window at startup where they do not apply.
+func (s *sandbox) started(pid int) {
+	set := func(resource int, value uint64) {
+		unix.Prlimit(pid, resource, &unix.Rlimit{Cur: value, Max: value}, nil)
+	}
+
+	if s.limits.CPUTime > 0 {
+		// Whole seconds only; the exact limit is checked after the run
+		set(unix.RLIMIT_CPU, uint64(s.limits.CPUTime.Seconds())+1)
+	}
+	if s.limits.Output > 0 {
+		set(unix.RLIMIT_FSIZE, uint64(s.limits.Output))
+	}
+	if s.cgroup == "" && !s.threaded {
+		if s.limits.Memory > 0 {
+			set(unix.RLIMIT_AS, uint64(s.limits.Memory+addressSpaceSlack))
+		}
+		if s.limits.NoFork {
+			// Counts every process of the user, so any fork fails
+			set(unix.RLIMIT_NPROC, 1)
+		}
+	}
+}
+
+// finish measures the finished process
+func (s *sandbox) finish(state *os.ProcessState) usage {
+	var u usage
+	if state == nil {
+		return u
+	}
+
+	u.CPUTime = state.UserTime() + state.SystemTime()
+	if rusage, ok := state.SysUsage().(*syscall.Rusage); ok {
+		u.Memory = rusage.Maxrss * 1024
+	}
+
+	if s.cgroup != "" {
+		if data, err := os.ReadFile(filepath.Join(s.cgroup, "memory.peak")); err == nil {
+			if peak, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64); err == nil && peak > u.Memory {
+				u.Memory = peak
+			}
+		}
+		u.OOMKilled = readCgroupCounter(filepath.Join(s.cgroup, "memory.events"), "oom_kill") > 0
+	}
+	return u
+}
+
+// cleanup removes the cgroup, if one was created
+func (s *sandbox) cleanup() {
+	if s.cgroupFD >= 0 {
+		unix.Close(s.cgroupFD)
+		s.cgroupFD = -1
+	}
+	if s.cgroup != "" {
+		os.Remove(s.cgroup)
+		s.cgroup = ""
+	}
+}
+
+// readCgroupCounter reads one "key value" line from a cgroup events file
+func readCgroupCounter(path, key string) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name, value, ok := strings.Cut(scanner.Text(), " ")
+		if ok && name == key {
+			n, _ := strconv.ParseInt(value, 10, 64)
+			return n
+		}
+	}
+	return 0
+}
@@ -0,0 +1,35 @@
+//go:build !linux
+
+package judge
+
+import (
+	"os"
+	"os/exec"
+)
+
+// SandboxMode reports how run limits are enforced on this system. Outside
+// Linux only the time limit is enforced; other limits are checked after
+// the run.
+func SandboxMode() string {
+	return "none"
+}
+
+// sandbox measures a process; it enforces nothing outside Linux
+type sandbox struct{}
+
+func newSandbox(limits Limits, lang *Language) *sandbox {
+	return &sandbox{}
+}
+
+func (s *sandbox) prepare(cmd *exec.Cmd) {}
+
+func (s *sandbox) started(pid int) {}
+
+func (s *sandbox) finish(state *os.ProcessState) usage {
+	if state == nil {
+		return usage{}
+	}
+	return usage{CPUTime: state.UserTime() + state.SystemTime()}
+}
+
+func (s *sandbox) cleanup() {}
@@ -0,0 +1,172 @@
+package judge
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultReferenceTimeLimit bounds generator and brute-force runs, which are
+// expected to be slow but must not hang a stress loop
+const DefaultReferenceTimeLimit = 10 * time.Second
+
+var reSavedTest = regexp.MustCompile(`^test_(\d+)\.in$`)
+
+// StressConfig describes a stress test: the generator is run as "gen <seed>"
+// and its output is fed to both the brute-force reference and the solution
+type StressConfig struct {
+	Generator  *Program
+	Brute      *Program
+	Solution   *Program
+	Iterations int
+	StartSeed  int64
+
+	// Options judges the solution; the brute-force output is the answer
+	Options Options
+
+	// ReferenceTimeLimit bounds generator and brute runs (default 10s)
+	ReferenceTimeLimit time.Duration
+
+	// OnProgress is called after every iteration, if set
+	OnProgress func(done int, elapsed time.Duration)
+}
+
+// StressFailure is the first case where the solution disagreed with the brute force
+type StressFailure struct {
+	Seed   int64
+	Input  []byte
+	Answer []byte
+	Result *TestResult
+}
+
+// StressReport summarizes a stress run
+type StressReport struct {
+	Iterations int
+	Elapsed    time.Duration
+	Failure    *StressFailure // nil if every iteration passed
+}
+
+// Throughput returns iterations per second
+func (r *StressReport) Throughput() float64 {
+	if r.Elapsed <= 0 {
+		return 0
+	}
+	return float64(r.Iterations) / r.Elapsed.Seconds()
+}
+
+// Stress runs generator, brute force and solution in a loop with increasing
+// seeds, stopping at the first mismatch. An error means the generator or brute
+// force itself failed, or the context was cancelled.
+func Stress(ctx context.Context, cfg StressConfig) (*StressReport, error) {
+	refLimit := cfg.ReferenceTimeLimit
+	if refLimit <= 0 {
+		refLimit = DefaultReferenceTimeLimit
+	}
+
+	report := &StressReport{}
+	start := time.Now()
+	defer func() { report.Elapsed = time.Since(start) }()
+
+	for i := 0; i < cfg.Iterations; i++ {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		seed := cfg.StartSeed + int64(i)
+
+		gen := cfg.Generator.Run(ctx, nil, refLimit, strconv.FormatInt(seed, 10))
+		if err := referenceError("generator", gen); err != nil {
+			return report, fmt.Errorf("seed %d: %w", seed, err)
+		}
+		input := gen.Stdout
+
+		brute := cfg.Brute.Run(ctx, input, refLimit)
+		if err := referenceError("brute force", brute); err != nil {
+			return report, fmt.Errorf("seed %d: %w", seed, err)
+		}
+		answer := brute.Stdout
+		if answer == nil {
+			answer = []byte{}
+		}
+
+		result := Evaluate(ctx, cfg.Solution, input, answer, cfg.Options)
+		report.Iterations++
+		if cfg.OnProgress != nil {
+			cfg.OnProgress(report.Iterations, time.Since(start))
+		}
+
+		if result.Verdict != VerdictOK {
+			if ctx.Err() != nil {
+				return report, ctx.Err()
+			}
+			report.Failure = &StressFailure{
+				Seed:   seed,
+				Input:  input,
+				Answer: answer,
+				Result: result,
+			}
+			return report, nil
+		}
+	}
+
+	return report, nil
+}
+
+// referenceError reports a failed generator or brute-force run
+func referenceError(name string, run *RunResult) error {
+	switch {
+	case run.TimedOut:
+		return fmt.Errorf("%s timed out", name)
+	case run.Err != nil:
+		return fmt.Errorf("run %s: %w", name, run.Err)
+	case run.ExitCode != 0:
+		stderr := strings.TrimSpace(string(run.Stderr))
+		if stderr != "" {
+			return fmt.Errorf("%s exited with code %d: %s", name, run.ExitCode, stderr)
+		}
+		return fmt.Errorf("%s exited with code %d", name, run.ExitCode)
+	}
+	return nil
+}
+
+// AddTest saves input and answer as the next numbered test (test_N.in/.out) in dir
+func AddTest(dir string, input, answer []byte) (*TestCase, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("create tests dir: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read tests dir: %w", err)
+	}
+
+	next := 1
+	for _, entry := range entries {
+		if m := reSavedTest.FindStringSubmatch(entry.Name()); m != nil {
+			if n, _ := strconv.Atoi(m[1]); n >= next {
+				next = n + 1
+			}
+		}
+	}
+
+	name := fmt.Sprintf("test_%d", next)
+	test := &TestCase{
+		Name:       name,
+		InputPath:  filepath.Join(dir, name+".in"),
+		AnswerPath: filepath.Join(dir, name+".out"),
+	}
+
+	if err := os.WriteFile(test.InputPath, input, 0644); err != nil {
+		return nil, fmt.Errorf("write test input: %w", err)
+	}
+	if err := os.WriteFile(test.AnswerPath, answer, 0644); err != nil {
+		return nil, fmt.Errorf("write test answer: %w", err)
+	}
+
+	return test, nil
+}
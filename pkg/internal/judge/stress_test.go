@@ -0,0 +1,109 @@
+package judge
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func compileScript(t *testing.T, dir, name, source string) *Program {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	prog, err := Compile(context.Background(), path)
+	if err != nil {
+		t.Fatalf("Compile(%s) error = %v", name, err)
+	}
+	t.Cleanup(prog.Cleanup)
+	return prog
+}
+
+func TestStress(t *testing.T) {
+	if _, err := exec.LookPath("python3"); err != nil {
+		t.Skip("python3 not available")
+	}
+
+	dir := t.TempDir()
+	gen := compileScript(t, dir, "gen.py", "import sys\nprint(sys.argv[1], 1)\n")
+	brute := compileScript(t, dir, "brute.py", "a, b = map(int, input().split())\nprint(a + b)\n")
+	good := compileScript(t, dir, "good.py", "print(sum(map(int, input().split())))\n")
+	bad := compileScript(t, dir, "bad.py", "a, b = map(int, input().split())\nprint(0 if a == 3 else a + b)\n")
+
+	ctx := context.Background()
+	progress := 0
+
+	report, err := Stress(ctx, StressConfig{
+		Generator:  gen,
+		Brute:      brute,
+		Solution:   good,
+		Iterations: 3,
+		StartSeed:  1,
+		OnProgress: func(done int, _ time.Duration) { progress = done },
+	})
+	if err != nil {
+		t.Fatalf("Stress() error = %v", err)
+	}
+	if report.Failure != nil || report.Iterations != 3 || progress != 3 {
+		t.Errorf("Stress() = %+v, progress %d; want 3 passing iterations", report, progress)
+	}
+
+	report, err = Stress(ctx, StressConfig{
+		Generator:  gen,
+		Brute:      brute,
+		Solution:   bad,
+		Iterations: 10,
+		StartSeed:  1,
+	})
+	if err != nil {
+		t.Fatalf("Stress() error = %v", err)
+	}
+	if report.Failure == nil {
+		t.Fatal("Stress() should find a failure")
+	}
+	if report.Failure.Seed != 3 || report.Iterations != 3 {
+		t.Errorf("failure seed = %d after %d iterations, want seed 3 after 3", report.Failure.Seed, report.Iterations)
+	}
+	if string(report.Failure.Input) != "3 1\n" || string(report.Failure.Answer) != "4\n" {
+		t.Errorf("failure case = %q -> %q", report.Failure.Input, report.Failure.Answer)
+	}
+	if report.Failure.Result.Verdict != VerdictWrongAnswer {
+		t.Errorf("failure verdict = %v, want WA", report.Failure.Result.Verdict)
+	}
+
+	crash := compileScript(t, dir, "crash.py", "raise SystemExit(1)\n")
+	if _, err := Stress(ctx, StressConfig{Generator: crash, Brute: brute, Solution: good, Iterations: 1}); err == nil {
+		t.Error("Stress() should fail when the generator fails")
+	}
+}
+
+func TestAddTest(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "tests")
+
+	first, err := AddTest(dir, []byte("1\n"), []byte("2\n"))
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+	if first.Name != "test_1" {
+		t.Errorf("first test = %v, want test_1", first.Name)
+	}
+
+	os.WriteFile(filepath.Join(dir, "test_7.in"), nil, 0644)
+
+	next, err := AddTest(dir, []byte("3\n"), []byte("4\n"))
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+	if next.Name != "test_8" {
+		t.Errorf("next test = %v, want test_8", next.Name)
+	}
+
+	data, _ := os.ReadFile(next.AnswerPath)
+	if string(data) != "4\n" {
+		t.Errorf("answer = %q, want 4", data)
+	}
+}
@@ -0,0 +1,141 @@
+package migrate
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+// Document is a YAML file being migrated. Migrations edit the node tree
+// rather than Go structs, so fields unknown to the current schema survive
+// and key order is preserved.
+type Document struct {
+	root *yaml.Node // mapping node
+}
+
+// ParseDocument parses a YAML mapping document
+func ParseDocument(data []byte) (*Document, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	if node.Kind != yaml.DocumentNode || len(node.Content) == 0 || node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("parse yaml: not a mapping document")
+	}
+	return &Document{root: node.Content[0]}, nil
+}
+
+// Marshal encodes the document back to YAML
+func (d *Document) Marshal() ([]byte, error) {
+	data, err := yaml.Marshal(d.root)
+	if err != nil {
+		return nil, fmt.Errorf("marshal yaml: %w", err)
+	}
+	return data, nil
+}
+
+// Header returns the document's _schema header
+func (d *Document) Header() (schema.SchemaHeader, error) {
+	var header schema.SchemaHeader
+	node := d.Get("_schema")
+	if node == nil {
+		return header, fmt.Errorf("missing _schema header")
+	}
+	if err := node.Decode(&header); err != nil {
+		return header, fmt.Errorf("decode _schema header: %w", err)
+	}
+	return header, nil
+}
+
+// Version returns the document's schema version
+func (d *Document) Version() (schema.Version, error) {
+	header, err := d.Header()
+	if err != nil {
+		return schema.Version{}, err
+	}
+	return schema.ParseVersion(header.Version)
+}
+
+// SetVersion updates the document's schema version
+func (d *Document) SetVersion(v schema.Version) error {
+	header := d.Get("_schema")
+	if header == nil || header.Kind != yaml.MappingNode {
+		return fmt.Errorf("missing _schema header")
+	}
+	setKey(header, "version", v.String())
+	return nil
+}
+
+// Get returns the value at a dotted path of keys, or nil
+func (d *Document) Get(path ...string) *yaml.Node {
+	node := d.root
+	for _, key := range path {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+		node = lookup(node, key)
+	}
+	return node
+}
+
+// Set sets a top-level key to a value, appending the key if it is new
+func (d *Document) Set(key string, value interface{}) error {
+	var node yaml.Node
+	if err := node.Encode(value); err != nil {
+		return fmt.Errorf("encode %s: %w", key, err)
+	}
+	for i := 0; i < len(d.root.Content)-1; i += 2 {
+		if d.root.Content[i].Value == key {
+			d.root.Content[i+1] = &node
+			return nil
+		}
+	}
+	d.root.Content = append(d.root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, &node)
+	return nil
+}
+
+// Delete removes a top-level key. Returns false if it was absent.
+func (d *Document) Delete(key string) bool {
+	for i := 0; i < len(d.root.Content)-1; i += 2 {
+		if d.root.Content[i].Value == key {
+			d.root.Content = append(d.root.Content[:i], d.root.Content[i+2:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Rename renames a top-level key in place. Returns false if it was absent.
+func (d *Document) Rename(oldKey, newKey string) bool {
+	for i := 0; i < len(d.root.Content)-1; i += 2 {
+		if d.root.Content[i].Value == oldKey {
+			d.root.Content[i].Value = newKey
+			return true
+		}
+	}
+	return false
+}
+
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(mapping.Content)-1; i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func setKey(mapping *yaml.Node, key, value string) {
+	if node := lookup(mapping, key); node != nil {
+		node.Kind = yaml.ScalarNode
+		node.Tag = "!!str"
+		node.Value = value
+		return
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
@@ -0,0 +1,78 @@
+package migrate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+const testDoc = `_schema:
+    version: 1.0.0
+    type: problem
+id: 1325A
+notes:
+    approach: greedy
+extra: kept
+`
+
+func TestDocument_RoundTrip(t *testing.T) {
+	doc, err := ParseDocument([]byte(testDoc))
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+
+	header, err := doc.Header()
+	if err != nil || header.Type != schema.TypeProblem || header.Version != "1.0.0" {
+		t.Fatalf("Header() = %+v, %v", header, err)
+	}
+
+	if err := doc.SetVersion(schema.Version{Major: 1, Minor: 1}); err != nil {
+		t.Fatalf("SetVersion() error = %v", err)
+	}
+	if !doc.Rename("notes", "userNotes") || doc.Rename("missing", "x") {
+		t.Error("Rename() result mismatch")
+	}
+	if got := doc.Get("userNotes", "approach"); got == nil || got.Value != "greedy" {
+		t.Errorf("Get(userNotes.approach) = %v", got)
+	}
+	if err := doc.Set("id", "1325B"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	doc.Set("added", []string{"a"})
+	if !doc.Delete("extra") || doc.Delete("extra") {
+		t.Error("Delete() result mismatch")
+	}
+
+	out, err := doc.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	got := string(out)
+
+	for _, want := range []string{"version: 1.1.0", "id: 1325B", "userNotes:", "added:"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("output missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "extra") {
+		t.Errorf("deleted key still present:\n%s", got)
+	}
+	// Key order is preserved
+	if strings.Index(got, "_schema") > strings.Index(got, "id:") {
+		t.Errorf("key order changed:\n%s", got)
+	}
+}
+
+func TestParseDocument_Invalid(t *testing.T) {
+	for _, input := range []string{"", "- a\n- b\n", "key: [unclosed"} {
+		if _, err := ParseDocument([]byte(input)); err == nil {
+			t.Errorf("ParseDocument(%q) should fail", input)
+		}
+	}
+
+	doc, _ := ParseDocument([]byte("name: x\n"))
+	if _, err := doc.Version(); err == nil {
+		t.Error("Version() should fail without _schema")
+	}
+}
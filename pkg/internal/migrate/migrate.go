@@ -0,0 +1,370 @@
+// Package migrate upgrades workspace files between schema versions
+package migrate
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/harshit-vibes/cf/pkg/internal/errors"
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+// BackupDir is the directory, relative to the workspace root, where files
+// are backed up before a migration rewrites them
+const BackupDir = ".cf-backup"
+
+// Transform rewrites one document in place for a migration step
+type Transform func(doc *Document) error
+
+// Step migrates files from one schema version to the next. Transforms are
+// keyed by schema type (schema.TypeProblem, ...); files of types without a
+// transform only get their version bumped.
+type Step struct {
+	From        schema.Version
+	To          schema.Version
+	Description string
+	Transforms  map[string]Transform
+}
+
+// Registry holds the known migration steps
+type Registry struct {
+	steps []Step
+}
+
+// DefaultRegistry holds the migrations shipped with this build. Steps are
+// added here whenever schema.CurrentVersion is bumped.
+var DefaultRegistry = &Registry{}
+
+// NewRegistry creates a registry from steps
+func NewRegistry(steps ...Step) (*Registry, error) {
+	r := &Registry{}
+	for _, step := range steps {
+		if err := r.Register(step); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register adds a step. Each version may have only one outgoing step.
+func (r *Registry) Register(step Step) error {
+	if step.From.Compare(step.To) >= 0 {
+		return fmt.Errorf("invalid step %s → %s: must move forward", step.From, step.To)
+	}
+	for _, s := range r.steps {
+		if s.From.Compare(step.From) == 0 {
+			return fmt.Errorf("duplicate step from %s", step.From)
+		}
+	}
+	r.steps = append(r.steps, step)
+	return nil
+}
+
+// Path returns the chain of steps leading from one version to another
+func (r *Registry) Path(from, to schema.Version) ([]Step, error) {
+	var path []Step
+	current := from
+
+	for current.Compare(to) < 0 {
+		step, ok := r.next(current)
+		if !ok || step.To.Compare(to) > 0 {
+			return nil, apperrors.New(apperrors.ErrSchemaIncompatible).
+				WithDetails(fmt.Sprintf("no migration path from %s to %s", from, to))
+		}
+		path = append(path, step)
+		current = step.To
+	}
+
+	return path, nil
+}
+
+// next finds the step leaving v. Patch versions share their minor's steps.
+func (r *Registry) next(v schema.Version) (Step, bool) {
+	for _, s := range r.steps {
+		if s.From.Major == v.Major && s.From.Minor == v.Minor {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// FileChange describes one file a migration rewrites
+type FileChange struct {
+	Path  string // relative to the workspace root
+	Type  string
+	From  schema.Version
+	To    schema.Version
+	Steps []Step
+
+	data []byte // migrated content
+}
+
+// Plan is the set of file changes needed to reach a target version
+type Plan struct {
+	Target  schema.Version
+	Changes []FileChange
+	Skipped []string // files without a readable schema header
+}
+
+// Options configure a migration run
+type Options struct {
+	Target   schema.Version // defaults to schema.CurrentVersion
+	Registry *Registry      // defaults to DefaultRegistry
+	DryRun   bool           // plan only, write nothing
+	NoBackup bool           // skip the backup copy
+	Now      func() time.Time
+}
+
+// Result summarizes a migration run
+type Result struct {
+	Plan      *Plan
+	BackupDir string // empty if nothing was backed up
+	Written   int
+}
+
+// Migrate migrates every versioned file in the workspace to opts.Target.
+// Files are backed up first, then replaced atomically; workspace.yaml is
+// written last so an interrupted run can simply be repeated.
+func Migrate(ws *workspace.Workspace, opts Options) (*Result, error) {
+	if opts.Target == (schema.Version{}) {
+		opts.Target = schema.CurrentVersion
+	}
+	if opts.Registry == nil {
+		opts.Registry = DefaultRegistry
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	plan, err := BuildPlan(ws, opts.Registry, opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Plan: plan}
+	if opts.DryRun || len(plan.Changes) == 0 {
+		return result, nil
+	}
+
+	if !opts.NoBackup {
+		dir, err := backup(ws.Root(), plan, opts.Now())
+		if err != nil {
+			return nil, err
+		}
+		result.BackupDir = dir
+	}
+
+	for _, change := range plan.Changes {
+		if err := writeAtomic(filepath.Join(ws.Root(), change.Path), change.data); err != nil {
+			return result, err
+		}
+		result.Written++
+	}
+
+	return result, nil
+}
+
+// BuildPlan reads every versioned file and migrates it in memory
+func BuildPlan(ws *workspace.Workspace, registry *Registry, target schema.Version) (*Plan, error) {
+	if !ws.Exists() {
+		return nil, apperrors.New(apperrors.ErrWorkspaceNotFound)
+	}
+	// Paths in the manifest decide where the other files live
+	if err := ws.Load(); err != nil {
+		return nil, err
+	}
+
+	plan := &Plan{Target: target}
+
+	files, err := collectFiles(ws)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, path := range files {
+		change, err := planFile(ws.Root(), path, registry, target)
+		if err != nil {
+			return nil, err
+		}
+		if change == nil {
+			continue
+		}
+		if change.Type == "" {
+			plan.Skipped = append(plan.Skipped, change.Path)
+			continue
+		}
+		plan.Changes = append(plan.Changes, *change)
+	}
+
+	return plan, nil
+}
+
+// planFile migrates one file in memory. Returns nil if it is up to date.
+func planFile(root, path string, registry *Registry, target schema.Version) (*FileChange, error) {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		rel = path
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+
+	doc, err := ParseDocument(data)
+	if err != nil {
+		return &FileChange{Path: rel}, nil
+	}
+	header, err := doc.Header()
+	if err != nil {
+		return &FileChange{Path: rel}, nil
+	}
+	version, err := schema.ParseVersion(header.Version)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	switch {
+	case !target.NeedsMigration(version):
+		return nil, nil
+	case version.Compare(schema.MinSupportedVersion) < 0:
+		return nil, apperrors.New(apperrors.ErrSchemaIncompatible).
+			WithDetails(fmt.Sprintf("%s is at %s, older than the oldest supported %s", rel, version, schema.MinSupportedVersion))
+	case version.Compare(target) > 0:
+		return nil, apperrors.New(apperrors.ErrSchemaIncompatible).
+			WithDetails(fmt.Sprintf("%s is at %s, newer than %s", rel, version, target)).
+			WithSuggestion("Update cf to the latest version")
+	}
+
+	steps, err := registry.Path(version, target)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, step := range steps {
+		if transform := step.Transforms[header.Type]; transform != nil {
+			if err := transform(doc); err != nil {
+				return nil, fmt.Errorf("failed to migrate %s to %s: %w", rel, step.To, err)
+			}
+		}
+	}
+	if err := doc.SetVersion(target); err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	out, err := doc.Marshal()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rel, err)
+	}
+
+	return &FileChange{
+		Path:  rel,
+		Type:  header.Type,
+		From:  version,
+		To:    target,
+		Steps: steps,
+		data:  out,
+	}, nil
+}
+
+// collectFiles lists versioned files, with the manifest last
+func collectFiles(ws *workspace.Workspace) ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(ws.ProblemsPath(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() && d.Name() == "problem.yaml" {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan problems: %w", err)
+	}
+
+	dirs := []struct{ path, what string }{
+		{ws.SubmissionsPath(), "submissions"},
+		{ws.ListsPath(), "lists"},
+		{ws.SessionsPath(), "sessions"},
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir.path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to scan %s: %w", dir.what, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".yaml") {
+				files = append(files, filepath.Join(dir.path, entry.Name()))
+			}
+		}
+	}
+
+	for _, path := range []string{ws.ProgressPath(), ws.SessionPath(), ws.DailyPath()} {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+
+	sort.Strings(files)
+	return append(files, ws.ManifestPath()), nil
+}
+
+// backup copies every file in the plan to a timestamped backup directory
+func backup(root string, plan *Plan, now time.Time) (string, error) {
+	dir := filepath.Join(root, BackupDir, now.Format("20060102-150405"))
+
+	for _, change := range plan.Changes {
+		src := filepath.Join(root, change.Path)
+		dst := filepath.Join(dir, change.Path)
+
+		data, err := os.ReadFile(src)
+		if err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", change.Path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			return "", fmt.Errorf("failed to create backup dir: %w", err)
+		}
+		if err := os.WriteFile(dst, data, 0644); err != nil {
+			return "", fmt.Errorf("failed to back up %s: %w", change.Path, err)
+		}
+	}
+
+	return dir, nil
+}
+
+// writeAtomic replaces a file via a temp file and rename
+func writeAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".migrate-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
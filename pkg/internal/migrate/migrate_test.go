@@ -0,0 +1,296 @@
+package migrate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"github.com/harshit-vibes/cf/pkg/internal/workspace"
+)
+
+var (
+	v100 = schema.Version{Major: 1, Minor: 0, Patch: 0}
+	v110 = schema.Version{Major: 1, Minor: 1, Patch: 0}
+	v200 = schema.Version{Major: 2, Minor: 0, Patch: 0}
+)
+
+// testRegistry renames problem notes in 1.1 and adds a workspace field in 2.0
+func testRegistry(t *testing.T) *Registry {
+	t.Helper()
+	r, err := NewRegistry(
+		Step{
+			From:        v100,
+			To:          v110,
+			Description: "rename notes to userNotes",
+			Transforms: map[string]Transform{
+				schema.TypeProblem: func(doc *Document) error {
+					doc.Rename("notes", "userNotes")
+					return nil
+				},
+			},
+		},
+		Step{
+			From:        v110,
+			To:          v200,
+			Description: "add workspace layout",
+			Transforms: map[string]Transform{
+				schema.TypeWorkspace: func(doc *Document) error {
+					return doc.Set("layout", "flat")
+				},
+			},
+		},
+	)
+	if err != nil {
+		t.Fatalf("NewRegistry() error = %v", err)
+	}
+	return r
+}
+
+func newTestWorkspace(t *testing.T) *workspace.Workspace {
+	t.Helper()
+	ws := workspace.New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	problem.Notes = v1.UserNotes{Approach: "gcd(1, x-1)"}
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	if err := ws.SaveSubmission(v1.NewSubmission(1, "1325A", 1325, "cpp17", 54)); err != nil {
+		t.Fatalf("SaveSubmission() error = %v", err)
+	}
+	if err := ws.SaveProgress(v1.NewProgress()); err != nil {
+		t.Fatalf("SaveProgress() error = %v", err)
+	}
+	return ws
+}
+
+func readVersion(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	doc, err := ParseDocument(data)
+	if err != nil {
+		t.Fatalf("ParseDocument() error = %v", err)
+	}
+	v, err := doc.Version()
+	if err != nil {
+		t.Fatalf("Version() error = %v", err)
+	}
+	return v.String()
+}
+
+func TestRegistry_Path(t *testing.T) {
+	r := testRegistry(t)
+
+	tests := []struct {
+		from, to  schema.Version
+		wantSteps int
+		wantErr   bool
+	}{
+		{v100, v200, 2, false},
+		{v100, v110, 1, false},
+		{schema.Version{Major: 1, Minor: 0, Patch: 3}, v200, 2, false},
+		{v110, v200, 1, false},
+		{v200, v200, 0, false},
+		{v200, schema.Version{Major: 3}, 0, true},
+	}
+
+	for _, tt := range tests {
+		steps, err := r.Path(tt.from, tt.to)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Path(%s, %s) error = %v, wantErr %v", tt.from, tt.to, err, tt.wantErr)
+			continue
+		}
+		if len(steps) != tt.wantSteps {
+			t.Errorf("Path(%s, %s) = %d steps, want %d", tt.from, tt.to, len(steps), tt.wantSteps)
+		}
+	}
+}
+
+func TestRegistry_Register(t *testing.T) {
+	r := testRegistry(t)
+
+	if err := r.Register(Step{From: v110, To: v100}); err == nil {
+		t.Error("Register() should reject backward steps")
+	}
+	if err := r.Register(Step{From: v100, To: v200}); err == nil {
+		t.Error("Register() should reject a second step from the same version")
+	}
+}
+
+func TestMigrate_DryRun(t *testing.T) {
+	ws := newTestWorkspace(t)
+	before, _ := os.ReadFile(ws.ManifestPath())
+
+	result, err := Migrate(ws, Options{Target: v200, Registry: testRegistry(t), DryRun: true})
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	if len(result.Plan.Changes) != 4 {
+		t.Errorf("Changes = %d, want 4 (workspace, problem, submission, progress)", len(result.Plan.Changes))
+	}
+	if last := result.Plan.Changes[len(result.Plan.Changes)-1]; last.Type != schema.TypeWorkspace {
+		t.Errorf("last change = %s, want workspace.yaml last", last.Path)
+	}
+	if result.Written != 0 || result.BackupDir != "" {
+		t.Errorf("dry run wrote %d files, backup %q", result.Written, result.BackupDir)
+	}
+
+	after, _ := os.ReadFile(ws.ManifestPath())
+	if string(before) != string(after) {
+		t.Error("dry run modified workspace.yaml")
+	}
+	if _, err := os.Stat(filepath.Join(ws.Root(), BackupDir)); !os.IsNotExist(err) {
+		t.Error("dry run created a backup")
+	}
+}
+
+func TestMigrate_Run(t *testing.T) {
+	ws := newTestWorkspace(t)
+	problemPath := filepath.Join(ws.ProblemPath("codeforces", 1325, "A"), "problem.yaml")
+	original, _ := os.ReadFile(problemPath)
+
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	result, err := Migrate(ws, Options{
+		Target:   v200,
+		Registry: testRegistry(t),
+		Now:      func() time.Time { return now },
+	})
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if result.Written != 4 {
+		t.Errorf("Written = %d, want 4", result.Written)
+	}
+
+	for _, path := range []string{ws.ManifestPath(), problemPath, ws.SubmissionPath(1), ws.ProgressPath()} {
+		if got := readVersion(t, path); got != "2.0.0" {
+			t.Errorf("%s version = %s, want 2.0.0", filepath.Base(path), got)
+		}
+	}
+
+	// Transforms ran on the right file types
+	data, _ := os.ReadFile(problemPath)
+	var problem map[string]interface{}
+	yaml.Unmarshal(data, &problem)
+	if _, ok := problem["userNotes"]; !ok {
+		t.Error("problem.yaml notes were not renamed")
+	}
+	if _, ok := problem["notes"]; ok {
+		t.Error("problem.yaml still has notes")
+	}
+	manifest, _ := os.ReadFile(ws.ManifestPath())
+	if !strings.Contains(string(manifest), "layout: flat") {
+		t.Error("workspace.yaml transform did not run")
+	}
+
+	// Originals are backed up
+	wantBackup := filepath.Join(ws.Root(), BackupDir, "20240301-120000")
+	if result.BackupDir != wantBackup {
+		t.Errorf("BackupDir = %s, want %s", result.BackupDir, wantBackup)
+	}
+	rel, _ := filepath.Rel(ws.Root(), problemPath)
+	backedUp, err := os.ReadFile(filepath.Join(result.BackupDir, rel))
+	if err != nil || string(backedUp) != string(original) {
+		t.Errorf("backup of problem.yaml does not match original (err = %v)", err)
+	}
+
+	// Migrating again is a no-op
+	result, err = Migrate(ws, Options{Target: v200, Registry: testRegistry(t)})
+	if err != nil {
+		t.Fatalf("second Migrate() error = %v", err)
+	}
+	if len(result.Plan.Changes) != 0 {
+		t.Errorf("second run changes = %d, want 0", len(result.Plan.Changes))
+	}
+}
+
+func TestMigrate_Lists(t *testing.T) {
+	ws := newTestWorkspace(t)
+	if err := ws.SaveList(v1.NewProblemList("graphs")); err != nil {
+		t.Fatalf("SaveList() error = %v", err)
+	}
+
+	if _, err := Migrate(ws, Options{Target: v200, Registry: testRegistry(t)}); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := readVersion(t, ws.ListPath("graphs")); got != "2.0.0" {
+		t.Errorf("graphs.yaml version = %s, want 2.0.0", got)
+	}
+}
+
+func TestMigrate_Sessions(t *testing.T) {
+	ws := newTestWorkspace(t)
+	ended := v1.NewSession(time.Now().Add(-2*time.Hour), time.Hour)
+	if err := ws.StartSession(ended); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	summary, err := ws.EndSession(ended, v1.SessionFinished, time.Now().Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("EndSession() error = %v", err)
+	}
+	if err := ws.StartSession(v1.NewSession(time.Now(), time.Hour)); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+
+	if _, err := Migrate(ws, Options{Target: v200, Registry: testRegistry(t)}); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	for _, path := range []string{ws.SessionPath(), summary} {
+		if got := readVersion(t, path); got != "2.0.0" {
+			t.Errorf("%s version = %s, want 2.0.0", filepath.Base(path), got)
+		}
+	}
+}
+
+func TestMigrate_Daily(t *testing.T) {
+	ws := newTestWorkspace(t)
+	if err := ws.SaveDaily(v1.NewDailyLog()); err != nil {
+		t.Fatalf("SaveDaily() error = %v", err)
+	}
+
+	if _, err := Migrate(ws, Options{Target: v200, Registry: testRegistry(t)}); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if got := readVersion(t, ws.DailyPath()); got != "2.0.0" {
+		t.Errorf("daily.yaml version = %s, want 2.0.0", got)
+	}
+}
+
+func TestMigrate_NewerFile(t *testing.T) {
+	ws := newTestWorkspace(t)
+	path := ws.SubmissionPath(1)
+	data, _ := os.ReadFile(path)
+	os.WriteFile(path, []byte(strings.Replace(string(data), "1.0.0", "3.0.0", 1)), 0644)
+
+	if _, err := Migrate(ws, Options{Target: v200, Registry: testRegistry(t)}); err == nil {
+		t.Error("Migrate() should refuse files newer than the target")
+	}
+}
+
+func TestMigrate_NoPath(t *testing.T) {
+	ws := newTestWorkspace(t)
+
+	empty, _ := NewRegistry()
+	if _, err := Migrate(ws, Options{Target: v200, Registry: empty}); err == nil {
+		t.Error("Migrate() should fail without a migration path")
+	}
+
+	// Nothing to do at the current version
+	result, err := Migrate(ws, Options{Registry: empty})
+	if err != nil || len(result.Plan.Changes) != 0 {
+		t.Errorf("Migrate() at current version = %+v, %v", result, err)
+	}
+}
@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"sort"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+// DailyLog records the daily challenges (stats/daily.yaml)
+type DailyLog struct {
+	Schema schema.SchemaHeader `yaml:"_schema" json:"_schema"`
+
+	Entries []DailyEntry `yaml:"entries" json:"entries"` // oldest first
+}
+
+// DailyEntry is one day's challenge
+type DailyEntry struct {
+	Date      string     `yaml:"date" json:"date"` // YYYY-MM-DD (UTC)
+	Seed      string     `yaml:"seed,omitempty" json:"seed,omitempty"`
+	ContestID int        `yaml:"contestId" json:"contestId"`
+	Index     string     `yaml:"index" json:"index"`
+	Name      string     `yaml:"name" json:"name"`
+	Rating    int        `yaml:"rating,omitempty" json:"rating,omitempty"`
+	Tags      []string   `yaml:"tags,omitempty" json:"tags,omitempty"`
+	SolvedAt  *time.Time `yaml:"solvedAt,omitempty" json:"solvedAt,omitempty"`
+}
+
+// NewDailyLog creates an empty daily log
+func NewDailyLog() *DailyLog {
+	return &DailyLog{
+		Schema:  schema.NewSchemaHeader(schema.TypeDaily),
+		Entries: []DailyEntry{},
+	}
+}
+
+// Find returns the entry for a date, or nil
+func (l *DailyLog) Find(date string) *DailyEntry {
+	for i := range l.Entries {
+		if l.Entries[i].Date == date {
+			return &l.Entries[i]
+		}
+	}
+	return nil
+}
+
+// Set adds or replaces the entry for entry.Date, keeping entries in date
+// order, and returns the stored entry
+func (l *DailyLog) Set(entry DailyEntry) *DailyEntry {
+	if existing := l.Find(entry.Date); existing != nil {
+		*existing = entry
+		return existing
+	}
+
+	l.Entries = append(l.Entries, entry)
+	sort.SliceStable(l.Entries, func(i, j int) bool {
+		return l.Entries[i].Date < l.Entries[j].Date
+	})
+	return l.Find(entry.Date)
+}
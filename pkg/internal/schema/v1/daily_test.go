@@ -0,0 +1,28 @@
+package v1
+
+import (
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+func TestDailyLog_Set(t *testing.T) {
+	log := NewDailyLog()
+	if log.Schema.Type != schema.TypeDaily {
+		t.Errorf("Schema.Type = %v, want %v", log.Schema.Type, schema.TypeDaily)
+	}
+
+	log.Set(DailyEntry{Date: "2024-03-12", ContestID: 1, Index: "A"})
+	log.Set(DailyEntry{Date: "2024-03-10", ContestID: 2, Index: "A"})
+	entry := log.Set(DailyEntry{Date: "2024-03-12", ContestID: 3, Index: "B"})
+
+	if len(log.Entries) != 2 || log.Entries[0].Date != "2024-03-10" {
+		t.Fatalf("Entries = %+v, want two in date order", log.Entries)
+	}
+	if entry.ContestID != 3 || log.Find("2024-03-12").ContestID != 3 {
+		t.Errorf("Set() did not replace the entry for the same date")
+	}
+	if log.Find("2024-03-11") != nil {
+		t.Error("Find() returned an entry for a missing date")
+	}
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
@@ -29,17 +30,38 @@ type Problem struct {
 	// Sample test cases
 	Samples []Sample `yaml:"samples" json:"samples"`
 
+	// Test registry: every test in tests/, including the samples
+	Tests []TestEntry `yaml:"tests,omitempty" json:"tests,omitempty"`
+
+	// Local judge output checker
+	Checker CheckerConfig `yaml:"checker,omitempty" json:"checker,omitempty"`
+
+	// Interactive problems are judged by an interactor instead of a checker
+	Interactive bool   `yaml:"interactive,omitempty" json:"interactive,omitempty"`
+	Interactor  string `yaml:"interactor,omitempty" json:"interactor,omitempty"` // source or executable, relative to the problem dir
+
 	// User practice data
 	Practice PracticeData `yaml:"practice" json:"practice"`
 
 	// User notes
 	Notes UserNotes `yaml:"notes,omitempty" json:"notes,omitempty"`
 
+	// Spaced-repetition schedule, set once the problem has been reviewed
+	Review *ReviewSchedule `yaml:"review,omitempty" json:"review,omitempty"`
+
 	// Fetch metadata
 	FetchedAt   time.Time `yaml:"fetchedAt" json:"fetchedAt"`
-	FetchMethod string    `yaml:"fetchMethod" json:"fetchMethod"` // "api", "web", "cache"
+	FetchMethod string    `yaml:"fetchMethod" json:"fetchMethod"` // see FetchMethod* constants
 }
 
+// Sources a contest's problem list was resolved from, recorded in
+// Problem.FetchMethod. Statements themselves are always scraped.
+const (
+	FetchMethodStandings  = "standings"  // contest.standings API
+	FetchMethodProblemset = "problemset" // problemset.problems API
+	FetchMethodWeb        = "web"        // contest page, or a single problem page
+)
+
 // ProblemMetadata holds platform-provided metadata
 type ProblemMetadata struct {
 	Rating      int      `yaml:"rating" json:"rating"`
@@ -62,6 +84,57 @@ type Sample struct {
 	Output string `yaml:"output" json:"output"`
 }
 
+// TestEntry registers a test stored as tests/<name>.in and <name>.out
+type TestEntry struct {
+	Name     string     `yaml:"name" json:"name"` // e.g., "sample_1", "test_3"
+	Source   TestSource `yaml:"source" json:"source"`
+	NoAnswer bool       `yaml:"noAnswer,omitempty" json:"noAnswer,omitempty"` // expected output unknown: run only, no compare
+	Note     string     `yaml:"note,omitempty" json:"note,omitempty"`
+	AddedAt  time.Time  `yaml:"addedAt,omitempty" json:"addedAt,omitempty"`
+}
+
+// TestSource records where a test came from
+type TestSource string
+
+const (
+	TestSourceSample TestSource = "sample" // from the statement, rewritten on every save
+	TestSourceCustom TestSource = "custom" // added with "cf test add"
+	TestSourceStress TestSource = "stress" // failing case saved by "cf stress"
+)
+
+// SampleTestName is the test name of a statement sample
+func SampleTestName(index int) string {
+	return fmt.Sprintf("sample_%d", index)
+}
+
+// FindTest returns the registry entry for a test, or nil
+func (p *Problem) FindTest(name string) *TestEntry {
+	for i := range p.Tests {
+		if p.Tests[i].Name == name {
+			return &p.Tests[i]
+		}
+	}
+	return nil
+}
+
+// CheckerConfig selects how the local judge compares output
+type CheckerConfig struct {
+	Type    CheckerType `yaml:"type,omitempty" json:"type,omitempty"`
+	Epsilon float64     `yaml:"epsilon,omitempty" json:"epsilon,omitempty"` // for "float"
+	Path    string      `yaml:"path,omitempty" json:"path,omitempty"`       // for "custom", relative to the problem dir
+}
+
+// CheckerType identifies a local judge checker
+type CheckerType string
+
+const (
+	CheckerLines  CheckerType = "lines"  // line by line, ignoring trailing whitespace (default)
+	CheckerTokens CheckerType = "tokens" // whitespace-separated tokens
+	CheckerFloat  CheckerType = "float"  // tokens, numbers compared with absolute/relative epsilon
+	CheckerYesNo  CheckerType = "yesno"  // tokens, case-insensitive
+	CheckerCustom CheckerType = "custom" // testlib-style checker run as: checker input output answer
+)
+
 // PracticeData holds user's practice progress
 type PracticeData struct {
 	Status         PracticeStatus `yaml:"status" json:"status"`
@@ -107,6 +180,14 @@ func NewProblem(contestID int, index, name string) *Problem {
 }
 
 func formatProblemID(contestID int, index string) string {
-	return string(rune('0'+contestID/1000)) + string(rune('0'+(contestID/100)%10)) +
-		string(rune('0'+(contestID/10)%10)) + string(rune('0'+contestID%10)) + index
+	return fmt.Sprintf("%04d%s", contestID, index)
+}
+
+// GymMinContestID is the smallest contest ID Codeforces assigns to gym
+// contests; regular rounds use smaller IDs
+const GymMinContestID = 100000
+
+// IsGymContest reports whether a contest ID belongs to the gym
+func IsGymContest(contestID int) bool {
+	return contestID >= GymMinContestID
 }
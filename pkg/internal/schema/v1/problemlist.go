@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+// ProblemList is a study plan: an ordered list of problems grouped into
+// sections (lists/<name>.yaml). A list is self-contained, so it can be
+// shared as a single file.
+type ProblemList struct {
+	Schema schema.SchemaHeader `yaml:"_schema" json:"_schema"`
+
+	Name        string    `yaml:"name" json:"name"` // file name, e.g. "graphs-101"
+	Title       string    `yaml:"title,omitempty" json:"title,omitempty"`
+	Description string    `yaml:"description,omitempty" json:"description,omitempty"`
+	Author      string    `yaml:"author,omitempty" json:"author,omitempty"`
+	CreatedAt   time.Time `yaml:"createdAt" json:"createdAt"`
+	UpdatedAt   time.Time `yaml:"updatedAt" json:"updatedAt"`
+
+	Sections []ListSection `yaml:"sections" json:"sections"`
+}
+
+// ListSection is a named group of problems within a list. Problems added
+// without a section go to the unnamed section.
+type ListSection struct {
+	Name     string        `yaml:"name,omitempty" json:"name,omitempty"`
+	Problems []ListProblem `yaml:"problems" json:"problems"`
+}
+
+// ListProblem references a Codeforces problem from a list
+type ListProblem struct {
+	ContestID int    `yaml:"contestId" json:"contestId"`
+	Index     string `yaml:"index" json:"index"`
+	GroupID   string `yaml:"groupId,omitempty" json:"groupId,omitempty"` // set for group contests
+	Name      string `yaml:"name,omitempty" json:"name,omitempty"`
+	Rating    int    `yaml:"rating,omitempty" json:"rating,omitempty"`
+	Note      string `yaml:"note,omitempty" json:"note,omitempty"`
+}
+
+// NewProblemList creates an empty problem list
+func NewProblemList(name string) *ProblemList {
+	now := time.Now()
+	return &ProblemList{
+		Schema:    schema.NewSchemaHeader(schema.TypeProblemList),
+		Name:      name,
+		CreatedAt: now,
+		UpdatedAt: now,
+		Sections:  []ListSection{},
+	}
+}
+
+// Label returns the problem's short ID, e.g. "1325A"
+func (p ListProblem) Label() string {
+	return fmt.Sprintf("%d%s", p.ContestID, p.Index)
+}
+
+// Section returns the section with the given name, appending it if missing
+func (l *ProblemList) Section(name string) *ListSection {
+	for i := range l.Sections {
+		if l.Sections[i].Name == name {
+			return &l.Sections[i]
+		}
+	}
+	l.Sections = append(l.Sections, ListSection{Name: name, Problems: []ListProblem{}})
+	return &l.Sections[len(l.Sections)-1]
+}
+
+// Find returns the problem with the given contest and index, or nil
+func (l *ProblemList) Find(contestID int, index string) *ListProblem {
+	for i := range l.Sections {
+		for j := range l.Sections[i].Problems {
+			if p := &l.Sections[i].Problems[j]; p.ContestID == contestID && p.Index == index {
+				return p
+			}
+		}
+	}
+	return nil
+}
+
+// Add appends a problem to a section. It returns false, leaving the list
+// unchanged, if the problem is already in the list.
+func (l *ProblemList) Add(section string, problem ListProblem) bool {
+	if l.Find(problem.ContestID, problem.Index) != nil {
+		return false
+	}
+	s := l.Section(section)
+	s.Problems = append(s.Problems, problem)
+	return true
+}
+
+// Remove removes a problem from the list, dropping its section if that
+// leaves it empty. It returns false if the problem is not in the list.
+func (l *ProblemList) Remove(contestID int, index string) bool {
+	for i := range l.Sections {
+		s := &l.Sections[i]
+		for j, p := range s.Problems {
+			if p.ContestID != contestID || p.Index != index {
+				continue
+			}
+			s.Problems = append(s.Problems[:j], s.Problems[j+1:]...)
+			if len(s.Problems) == 0 {
+				l.Sections = append(l.Sections[:i], l.Sections[i+1:]...)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// Problems returns every problem in list order
+func (l *ProblemList) Problems() []ListProblem {
+	var problems []ListProblem
+	for _, s := range l.Sections {
+		problems = append(problems, s.Problems...)
+	}
+	return problems
+}
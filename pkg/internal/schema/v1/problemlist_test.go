@@ -0,0 +1,43 @@
+package v1
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+func TestProblemList_AddRemove(t *testing.T) {
+	list := NewProblemList("graphs")
+	if list.Schema.Type != schema.TypeProblemList {
+		t.Errorf("Schema.Type = %v, want %v", list.Schema.Type, schema.TypeProblemList)
+	}
+
+	list.Add("BFS", ListProblem{ContestID: 1325, Index: "A"})
+	list.Add("", ListProblem{ContestID: 1000, Index: "B"})
+	list.Add("BFS", ListProblem{ContestID: 1324, Index: "D"})
+	if list.Add("DFS", ListProblem{ContestID: 1325, Index: "A"}) {
+		t.Error("Add() accepted a problem already in the list")
+	}
+
+	var got []string
+	for _, p := range list.Problems() {
+		got = append(got, p.Label())
+	}
+	if want := "1325A 1324D 1000B"; strings.Join(got, " ") != want {
+		t.Errorf("Problems() = %v, want %s", got, want)
+	}
+	if len(list.Sections) != 2 || list.Sections[0].Name != "BFS" {
+		t.Errorf("Sections = %+v, want BFS then the unnamed section", list.Sections)
+	}
+
+	if !list.Remove(1000, "B") || list.Remove(1000, "B") {
+		t.Error("Remove() should succeed once")
+	}
+	if len(list.Sections) != 1 {
+		t.Errorf("empty section was kept: %+v", list.Sections)
+	}
+	if list.Find(1324, "D") == nil || list.Find(1000, "B") != nil {
+		t.Error("Find() does not match the list contents")
+	}
+}
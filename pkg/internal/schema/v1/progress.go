@@ -49,8 +49,14 @@ func NewProgress() *Progress {
 	}
 }
 
-// AddSolved records a solved problem
+// AddSolved records a problem solved now
 func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSpent int) {
+	p.RecordSolved(problemID, rating, tags, timeSpent, time.Now())
+}
+
+// RecordSolved records a problem solved at the given time. Streaks assume
+// records arrive in chronological order.
+func (p *Progress) RecordSolved(problemID string, rating int, tags []string, timeSpent int, at time.Time) {
 	p.TotalSolved++
 	p.TotalTime += timeSpent
 
@@ -64,9 +70,8 @@ func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSp
 	}
 
 	// Update streak
-	now := time.Now()
 	if p.LastActivity != nil {
-		daysDiff := daysBetween(*p.LastActivity, now)
+		daysDiff := daysBetween(*p.LastActivity, at)
 		if daysDiff == 1 {
 			p.CurrentStreak++
 		} else if daysDiff > 1 {
@@ -80,21 +85,56 @@ func (p *Progress) AddSolved(problemID string, rating int, tags []string, timeSp
 		p.LongestStreak = p.CurrentStreak
 	}
 
-	p.LastActivity = &now
+	p.LastActivity = &at
 
 	// Update daily
-	p.updateDaily(problemID, true, timeSpent)
+	p.updateDaily(problemID, true, timeSpent, at)
 }
 
-// AddAttempted records an attempted problem
+// AddAttempted records a problem attempted now
 func (p *Progress) AddAttempted(problemID string, timeSpent int) {
+	p.RecordAttempted(problemID, timeSpent, time.Now())
+}
+
+// RecordAttempted records a problem attempted at the given time
+func (p *Progress) RecordAttempted(problemID string, timeSpent int, at time.Time) {
 	p.TotalAttempted++
 	p.TotalTime += timeSpent
-	p.updateDaily(problemID, false, timeSpent)
+	p.updateDaily(problemID, false, timeSpent, at)
+}
+
+// StreakAt returns the current streak as of now: a streak whose last solve
+// was before yesterday is broken
+func (p *Progress) StreakAt(now time.Time) int {
+	if p.LastActivity == nil || daysBetween(*p.LastActivity, now) > 1 {
+		return 0
+	}
+	return p.CurrentStreak
+}
+
+// Day returns the entry for a date (YYYY-MM-DD), or an empty entry
+func (p *Progress) Day(date string) DailyProgress {
+	for _, d := range p.Daily {
+		if d.Date == date {
+			return d
+		}
+	}
+	return DailyProgress{Date: date}
+}
+
+// SolvedSince returns the number of problems solved on or after date (YYYY-MM-DD)
+func (p *Progress) SolvedSince(date string) int {
+	solved := 0
+	for _, d := range p.Daily {
+		if d.Date >= date {
+			solved += d.Solved
+		}
+	}
+	return solved
 }
 
-func (p *Progress) updateDaily(problemID string, solved bool, timeSpent int) {
-	today := time.Now().Format("2006-01-02")
+func (p *Progress) updateDaily(problemID string, solved bool, timeSpent int, at time.Time) {
+	today := at.Format("2006-01-02")
 
 	// Find or create today's entry
 	var todayEntry *DailyProgress
@@ -454,3 +454,55 @@ func TestProgress_StreakReset(t *testing.T) {
 		t.Errorf("LongestStreak = %v, want 10 (unchanged)", p.LongestStreak)
 	}
 }
+
+func TestProgress_RecordSolved_Chronological(t *testing.T) {
+	p := NewProgress()
+	day := time.Date(2024, 3, 1, 20, 0, 0, 0, time.UTC)
+
+	p.RecordSolved("1A", 800, nil, 0, day)
+	p.RecordSolved("2A", 900, nil, 0, day.Add(2*time.Hour))
+	p.RecordSolved("3A", 1000, nil, 0, day.AddDate(0, 0, 1))
+	p.RecordAttempted("4A", 0, day.AddDate(0, 0, 3))
+	p.RecordSolved("5A", 1000, nil, 0, day.AddDate(0, 0, 5))
+
+	if p.CurrentStreak != 1 || p.LongestStreak != 2 {
+		t.Errorf("streak = %d/%d, want 1/2", p.CurrentStreak, p.LongestStreak)
+	}
+	if got := p.Day("2024-03-01"); got.Solved != 2 || len(got.Problems) != 2 {
+		t.Errorf("Day(2024-03-01) = %+v", got)
+	}
+	if got := p.Day("2024-03-04"); got.Attempted != 1 {
+		t.Errorf("Day(2024-03-04) = %+v", got)
+	}
+	if got := p.Day("2024-01-01"); got.Solved != 0 || got.Date != "2024-01-01" {
+		t.Errorf("Day() for missing date = %+v", got)
+	}
+	if got := p.SolvedSince("2024-03-02"); got != 2 {
+		t.Errorf("SolvedSince() = %d, want 2", got)
+	}
+}
+
+func TestProgress_StreakAt(t *testing.T) {
+	p := NewProgress()
+	last := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	p.RecordSolved("1A", 800, nil, 0, last.AddDate(0, 0, -1))
+	p.RecordSolved("2A", 800, nil, 0, last)
+
+	tests := []struct {
+		now  time.Time
+		want int
+	}{
+		{last, 2},
+		{last.AddDate(0, 0, 1), 2},
+		{last.AddDate(0, 0, 2), 0},
+	}
+	for _, tt := range tests {
+		if got := p.StreakAt(tt.now); got != tt.want {
+			t.Errorf("StreakAt(%v) = %d, want %d", tt.now, got, tt.want)
+		}
+	}
+
+	if got := NewProgress().StreakAt(last); got != 0 {
+		t.Errorf("StreakAt() with no activity = %d", got)
+	}
+}
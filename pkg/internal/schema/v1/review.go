@@ -0,0 +1,110 @@
+package v1
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// ReviewSchedule is a problem's spaced-repetition state, updated with the
+// SM-2 algorithm each time its approach is recalled. Problems enter the
+// review queue when UserNotes.Review is set.
+type ReviewSchedule struct {
+	Due          time.Time  `yaml:"due" json:"due"`
+	Ease         float64    `yaml:"ease" json:"ease"`
+	Interval     int        `yaml:"interval" json:"interval"`       // days
+	Repetitions  int        `yaml:"repetitions" json:"repetitions"` // successful recalls in a row
+	Lapses       int        `yaml:"lapses,omitempty" json:"lapses,omitempty"`
+	LastReviewed *time.Time `yaml:"lastReviewed,omitempty" json:"lastReviewed,omitempty"`
+	LastGrade    int        `yaml:"lastGrade,omitempty" json:"lastGrade,omitempty"`
+}
+
+// SM-2 parameters. Grades run from 0 (forgot completely) to 5 (perfect
+// recall); grades below ReviewPassGrade restart the intervals.
+const (
+	DefaultEase     = 2.5
+	MinEase         = 1.3
+	MaxReviewGrade  = 5
+	ReviewPassGrade = 3
+)
+
+// InitialEase returns the starting ease for a problem from the user's
+// perceived difficulty (UserNotes.Difficulty): harder problems come back
+// more often
+func InitialEase(difficulty string) float64 {
+	switch strings.ToLower(strings.TrimSpace(difficulty)) {
+	case "easy":
+		return 2.7
+	case "hard":
+		return 2.1
+	default:
+		return DefaultEase
+	}
+}
+
+// NewReviewSchedule creates a schedule that is due right away
+func NewReviewSchedule(difficulty string, at time.Time) *ReviewSchedule {
+	return &ReviewSchedule{
+		Due:  startOfDay(at),
+		Ease: InitialEase(difficulty),
+	}
+}
+
+// IsDue reports whether the review is due at now
+func (s *ReviewSchedule) IsDue(now time.Time) bool {
+	return !s.Due.After(now)
+}
+
+// Grade records a recall graded 0-5 at the given time and schedules the
+// next review. Reviews are due from the start of their day.
+func (s *ReviewSchedule) Grade(grade int, at time.Time) error {
+	if grade < 0 || grade > MaxReviewGrade {
+		return fmt.Errorf("invalid grade %d (expected 0-%d)", grade, MaxReviewGrade)
+	}
+
+	if grade >= ReviewPassGrade {
+		switch s.Repetitions {
+		case 0:
+			s.Interval = 1
+		case 1:
+			s.Interval = 6
+		default:
+			s.Interval = int(math.Round(float64(s.Interval) * s.Ease))
+		}
+		s.Repetitions++
+	} else {
+		s.Repetitions = 0
+		s.Interval = 1
+		s.Lapses++
+	}
+
+	q := float64(MaxReviewGrade - grade)
+	s.Ease = math.Max(MinEase, s.Ease+0.1-q*(0.08+q*0.02))
+	s.Ease = math.Round(s.Ease*100) / 100
+
+	s.LastGrade = grade
+	s.LastReviewed = &at
+	s.Due = startOfDay(at).AddDate(0, 0, s.Interval)
+	return nil
+}
+
+// ReviewDue reports whether the problem is marked for review and due at
+// now. Problems never reviewed are due right away.
+func (p *Problem) ReviewDue(now time.Time) bool {
+	return p.Notes.Review && (p.Review == nil || p.Review.IsDue(now))
+}
+
+// GradeReview records a graded recall, starting the schedule on the first
+// review
+func (p *Problem) GradeReview(grade int, at time.Time) error {
+	if p.Review == nil {
+		p.Review = NewReviewSchedule(p.Notes.Difficulty, at)
+	}
+	return p.Review.Grade(grade, at)
+}
+
+func startOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
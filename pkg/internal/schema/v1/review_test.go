@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewSchedule_Grade(t *testing.T) {
+	day := time.Date(2024, 3, 11, 21, 30, 0, 0, time.UTC)
+	s := NewReviewSchedule("", day)
+	if !s.IsDue(day) || s.Ease != DefaultEase {
+		t.Fatalf("NewReviewSchedule() = %+v, want due now with default ease", s)
+	}
+
+	steps := []struct {
+		grade    int
+		interval int
+		reps     int
+		ease     float64
+	}{
+		{4, 1, 1, 2.5},
+		{5, 6, 2, 2.6},
+		{4, 16, 3, 2.6}, // round(6 * 2.6)
+		{1, 1, 0, 2.06}, // lapse restarts the intervals
+		{3, 1, 1, 1.92},
+	}
+
+	at := day
+	for i, step := range steps {
+		if err := s.Grade(step.grade, at); err != nil {
+			t.Fatalf("step %d: Grade() error = %v", i, err)
+		}
+		if s.Interval != step.interval || s.Repetitions != step.reps || s.Ease != step.ease {
+			t.Errorf("step %d: interval %d reps %d ease %.2f, want %d %d %.2f",
+				i, s.Interval, s.Repetitions, s.Ease, step.interval, step.reps, step.ease)
+		}
+		want := time.Date(at.Year(), at.Month(), at.Day()+step.interval, 0, 0, 0, 0, time.UTC)
+		if !s.Due.Equal(want) {
+			t.Errorf("step %d: Due = %v, want %v", i, s.Due, want)
+		}
+		at = s.Due.Add(9 * time.Hour)
+	}
+	if s.Lapses != 1 || s.LastGrade != 3 {
+		t.Errorf("Lapses = %d, LastGrade = %d; want 1, 3", s.Lapses, s.LastGrade)
+	}
+
+	// Ease never drops below the minimum
+	for i := 0; i < 10; i++ {
+		s.Grade(0, at)
+	}
+	if s.Ease != MinEase {
+		t.Errorf("Ease = %.2f after repeated failures, want %.2f", s.Ease, MinEase)
+	}
+
+	if err := s.Grade(6, at); err == nil {
+		t.Error("Grade(6) should fail")
+	}
+}
+
+func TestProblem_ReviewDue(t *testing.T) {
+	now := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+	p := NewProblem(1325, "A", "Test")
+	if p.ReviewDue(now) {
+		t.Error("problem not marked for review is due")
+	}
+
+	p.Notes.Review = true
+	p.Notes.Difficulty = "hard"
+	if !p.ReviewDue(now) {
+		t.Error("new review should be due right away")
+	}
+
+	if err := p.GradeReview(5, now); err != nil {
+		t.Fatalf("GradeReview() error = %v", err)
+	}
+	if p.Review == nil || p.Review.Ease != 2.2 {
+		t.Fatalf("Review = %+v, want a schedule starting from the hard ease", p.Review)
+	}
+	if p.ReviewDue(now) || !p.ReviewDue(now.Add(12*time.Hour)) {
+		t.Error("review should be due from the start of the next day")
+	}
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+// Session represents a timed practice session. The active session lives in
+// stats/session.yaml; finished sessions are kept in stats/sessions/.
+type Session struct {
+	Schema schema.SchemaHeader `yaml:"_schema" json:"_schema"`
+
+	ID        string        `yaml:"id" json:"id"` // start time, e.g. 20240311-190000
+	Status    SessionStatus `yaml:"status" json:"status"`
+	StartedAt time.Time     `yaml:"startedAt" json:"startedAt"`
+	Duration  int           `yaml:"duration" json:"duration"` // seconds
+	EndedAt   *time.Time    `yaml:"endedAt,omitempty" json:"endedAt,omitempty"`
+
+	// Selection criteria
+	MinRating int      `yaml:"minRating,omitempty" json:"minRating,omitempty"`
+	MaxRating int      `yaml:"maxRating,omitempty" json:"maxRating,omitempty"`
+	Tags      []string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	Problems []SessionProblem `yaml:"problems" json:"problems"`
+}
+
+// SessionProblem is a problem in a practice session
+type SessionProblem struct {
+	ContestID    int        `yaml:"contestId" json:"contestId"`
+	Index        string     `yaml:"index" json:"index"`
+	Name         string     `yaml:"name" json:"name"`
+	Rating       int        `yaml:"rating,omitempty" json:"rating,omitempty"`
+	SolvedAt     *time.Time `yaml:"solvedAt,omitempty" json:"solvedAt,omitempty"`
+	SubmissionID *int64     `yaml:"submissionId,omitempty" json:"submissionId,omitempty"`
+	TimeSpent    int        `yaml:"timeSpent" json:"timeSpent"` // seconds, set when the session ends
+}
+
+// SessionStatus represents the state of a practice session
+type SessionStatus string
+
+const (
+	SessionActive   SessionStatus = "active"
+	SessionFinished SessionStatus = "finished" // time ran out or everything was solved
+	SessionStopped  SessionStatus = "stopped"  // ended early
+)
+
+// NewSession creates an active session starting at the given time
+func NewSession(startedAt time.Time, duration time.Duration) *Session {
+	return &Session{
+		Schema:    schema.NewSchemaHeader(schema.TypeSession),
+		ID:        startedAt.Format("20060102-150405"),
+		Status:    SessionActive,
+		StartedAt: startedAt,
+		Duration:  int(duration / time.Second),
+	}
+}
+
+// EndsAt returns when the session's time runs out
+func (s *Session) EndsAt() time.Time {
+	return s.StartedAt.Add(time.Duration(s.Duration) * time.Second)
+}
+
+// Remaining returns the time left at now, never negative
+func (s *Session) Remaining(now time.Time) time.Duration {
+	return max(s.EndsAt().Sub(now), 0)
+}
+
+// Expired reports whether the session's time has run out at now
+func (s *Session) Expired(now time.Time) bool {
+	return !now.Before(s.EndsAt())
+}
+
+// Solved returns the number of solved problems
+func (s *Session) Solved() int {
+	solved := 0
+	for _, p := range s.Problems {
+		if p.SolvedAt != nil {
+			solved++
+		}
+	}
+	return solved
+}
+
+// RecordSolve marks a problem solved by an accepted submission. Solves
+// outside the session's time window, of other problems or of problems
+// already solved are ignored. Returns true if the session changed.
+func (s *Session) RecordSolve(contestID int, index string, submissionID int64, at time.Time) bool {
+	if at.Before(s.StartedAt) || at.After(s.EndsAt()) {
+		return false
+	}
+	for i := range s.Problems {
+		p := &s.Problems[i]
+		if p.ContestID != contestID || p.Index != index {
+			continue
+		}
+		if p.SolvedAt != nil && !at.Before(*p.SolvedAt) {
+			return false
+		}
+		p.SolvedAt = &at
+		p.SubmissionID = &submissionID
+		return true
+	}
+	return false
+}
+
+// End closes the session at the given time, capped at the time limit, and
+// splits the elapsed time between its problems: each solve gets the time
+// since the previous solve, and the time after the last solve is shared by
+// the unsolved problems. Per-problem times add up to the session's length.
+func (s *Session) End(status SessionStatus, at time.Time) {
+	if at.After(s.EndsAt()) {
+		at = s.EndsAt()
+	}
+	if at.Before(s.StartedAt) {
+		at = s.StartedAt
+	}
+	s.Status = status
+	s.EndedAt = &at
+
+	var order []int
+	var unsolved []int
+	for i, p := range s.Problems {
+		if p.SolvedAt != nil {
+			order = append(order, i)
+		} else {
+			unsolved = append(unsolved, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return s.Problems[order[a]].SolvedAt.Before(*s.Problems[order[b]].SolvedAt)
+	})
+
+	last := s.StartedAt
+	for _, i := range order {
+		solvedAt := *s.Problems[i].SolvedAt
+		s.Problems[i].TimeSpent = int(solvedAt.Sub(last) / time.Second)
+		last = solvedAt
+	}
+
+	if len(unsolved) == 0 {
+		return
+	}
+	rest := max(int(at.Sub(last)/time.Second), 0)
+	for n, i := range unsolved {
+		share := rest / len(unsolved)
+		if n < rest%len(unsolved) {
+			share++
+		}
+		s.Problems[i].TimeSpent = share
+	}
+}
+
+// Elapsed returns how long the session has run at now, or ran if ended
+func (s *Session) Elapsed(now time.Time) time.Duration {
+	end := now
+	if s.EndedAt != nil {
+		end = *s.EndedAt
+	}
+	return min(max(end.Sub(s.StartedAt), 0), time.Duration(s.Duration)*time.Second)
+}
+
+// Label identifies a session problem, e.g. "1325A"
+func (p *SessionProblem) Label() string {
+	return fmt.Sprintf("%d%s", p.ContestID, p.Index)
+}
@@ -0,0 +1,101 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+)
+
+func newTestSession(start time.Time) *Session {
+	s := NewSession(start, 2*time.Hour)
+	s.Problems = []SessionProblem{
+		{ContestID: 1, Index: "A"},
+		{ContestID: 1, Index: "B"},
+		{ContestID: 2, Index: "C"},
+	}
+	return s
+}
+
+func TestNewSession(t *testing.T) {
+	start := time.Date(2024, 3, 11, 19, 0, 0, 0, time.UTC)
+	s := NewSession(start, 90*time.Minute)
+
+	if s.Schema.Type != schema.TypeSession || s.Status != SessionActive {
+		t.Errorf("NewSession() = %+v", s)
+	}
+	if s.ID != "20240311-190000" || s.Duration != 5400 {
+		t.Errorf("ID = %s, Duration = %d", s.ID, s.Duration)
+	}
+	if !s.EndsAt().Equal(start.Add(90*time.Minute)) || s.Remaining(start.Add(time.Hour)) != 30*time.Minute {
+		t.Errorf("EndsAt = %v, Remaining = %v", s.EndsAt(), s.Remaining(start.Add(time.Hour)))
+	}
+	if s.Expired(start.Add(89*time.Minute)) || !s.Expired(start.Add(90*time.Minute)) || s.Remaining(start.Add(2*time.Hour)) != 0 {
+		t.Error("session should expire after its duration")
+	}
+}
+
+func TestSession_RecordSolve(t *testing.T) {
+	start := time.Date(2024, 3, 11, 19, 0, 0, 0, time.UTC)
+	s := newTestSession(start)
+
+	tests := []struct {
+		name      string
+		contestID int
+		index     string
+		at        time.Time
+		want      bool
+	}{
+		{"before start", 1, "A", start.Add(-time.Minute), false},
+		{"in window", 1, "A", start.Add(30 * time.Minute), true},
+		{"later resubmit", 1, "A", start.Add(40 * time.Minute), false},
+		{"earlier solve found later", 1, "A", start.Add(20 * time.Minute), true},
+		{"other problem", 3, "A", start.Add(30 * time.Minute), false},
+		{"after time is up", 1, "B", start.Add(3 * time.Hour), false},
+	}
+
+	for _, tt := range tests {
+		if got := s.RecordSolve(tt.contestID, tt.index, 1, tt.at); got != tt.want {
+			t.Errorf("%s: RecordSolve() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+	if s.Solved() != 1 || !s.Problems[0].SolvedAt.Equal(start.Add(20*time.Minute)) {
+		t.Errorf("Solved() = %d, SolvedAt = %v", s.Solved(), s.Problems[0].SolvedAt)
+	}
+}
+
+func TestSession_End(t *testing.T) {
+	start := time.Date(2024, 3, 11, 19, 0, 0, 0, time.UTC)
+	s := newTestSession(start)
+	s.RecordSolve(2, "C", 1, start.Add(20*time.Minute))
+	s.RecordSolve(1, "A", 2, start.Add(50*time.Minute))
+
+	// Stopping after the time limit is capped at the limit
+	s.End(SessionStopped, start.Add(3*time.Hour))
+
+	if s.Status != SessionStopped || !s.EndedAt.Equal(s.EndsAt()) {
+		t.Errorf("Status = %s, EndedAt = %v", s.Status, s.EndedAt)
+	}
+	want := []int{30 * 60, 70 * 60, 20 * 60}
+	total := 0
+	for i, p := range s.Problems {
+		if p.TimeSpent != want[i] {
+			t.Errorf("%s TimeSpent = %d, want %d", p.Label(), p.TimeSpent, want[i])
+		}
+		total += p.TimeSpent
+	}
+	if total != s.Duration || s.Elapsed(start.Add(5*time.Hour)) != 2*time.Hour {
+		t.Errorf("total = %d, Elapsed = %v; want the whole session", total, s.Elapsed(start.Add(5*time.Hour)))
+	}
+}
+
+func TestSession_End_SplitsUnsolvedTime(t *testing.T) {
+	start := time.Date(2024, 3, 11, 19, 0, 0, 0, time.UTC)
+	s := newTestSession(start)
+	s.End(SessionStopped, start.Add(100*time.Second))
+
+	got := []int{s.Problems[0].TimeSpent, s.Problems[1].TimeSpent, s.Problems[2].TimeSpent}
+	if got[0] != 34 || got[1] != 33 || got[2] != 33 {
+		t.Errorf("TimeSpent = %v, want [34 33 33]", got)
+	}
+}
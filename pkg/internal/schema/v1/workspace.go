@@ -38,10 +38,11 @@ type CFConfig struct {
 
 // PracticeConfig holds practice session settings
 type PracticeConfig struct {
-	DifficultyMin int `yaml:"difficultyMin" json:"difficultyMin"`
-	DifficultyMax int `yaml:"difficultyMax" json:"difficultyMax"`
-	DailyGoal     int `yaml:"dailyGoal" json:"dailyGoal"`
-	WeeklyGoal    int `yaml:"weeklyGoal,omitempty" json:"weeklyGoal,omitempty"`
+	DifficultyMin int    `yaml:"difficultyMin" json:"difficultyMin"`
+	DifficultyMax int    `yaml:"difficultyMax" json:"difficultyMax"`
+	DailyGoal     int    `yaml:"dailyGoal" json:"dailyGoal"`
+	WeeklyGoal    int    `yaml:"weeklyGoal,omitempty" json:"weeklyGoal,omitempty"`
+	DailySeed     string `yaml:"dailySeed,omitempty" json:"dailySeed,omitempty"` // team seed for cf daily
 }
 
 // PathConfig holds relative paths within workspace
@@ -107,9 +107,12 @@ func NewSchemaHeader(schemaType string) SchemaHeader {
 
 // Schema types
 const (
-	TypeWorkspace  = "workspace"
-	TypeProblem    = "problem"
-	TypeSubmission = "submission"
-	TypeProgress   = "progress"
-	TypeConfig     = "config"
+	TypeWorkspace   = "workspace"
+	TypeProblem     = "problem"
+	TypeSubmission  = "submission"
+	TypeProgress    = "progress"
+	TypeSession     = "session"
+	TypeDaily       = "daily"
+	TypeProblemList = "problemList"
+	TypeConfig      = "config"
 )
@@ -256,7 +256,7 @@ func TestNewSchemaHeader(t *testing.T) {
 
 func TestSchemaTypes(t *testing.T) {
 	// Verify all schema types are defined
-	types := []string{TypeWorkspace, TypeProblem, TypeSubmission, TypeProgress}
+	types := []string{TypeWorkspace, TypeProblem, TypeSubmission, TypeProgress, TypeSession, TypeDaily, TypeProblemList}
 	for _, st := range types {
 		if st == "" {
 			t.Error("Schema type should not be empty")
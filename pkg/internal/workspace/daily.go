@@ -0,0 +1,53 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// DailyFile is the daily challenge log within the stats directory
+const DailyFile = "daily.yaml"
+
+// DailyPath returns the path of the daily challenge log
+func (w *Workspace) DailyPath() string {
+	return filepath.Join(w.StatsPath(), DailyFile)
+}
+
+// LoadDaily loads the daily challenge log, returning an empty log if none
+// exists
+func (w *Workspace) LoadDaily() (*v1.DailyLog, error) {
+	data, err := os.ReadFile(w.DailyPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return v1.NewDailyLog(), nil
+		}
+		return nil, fmt.Errorf("failed to read daily log: %w", err)
+	}
+
+	var log v1.DailyLog
+	if err := yaml.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to parse daily log: %w", err)
+	}
+	return &log, nil
+}
+
+// SaveDaily saves the daily challenge log
+func (w *Workspace) SaveDaily(log *v1.DailyLog) error {
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(log)
+	if err != nil {
+		return fmt.Errorf("failed to marshal daily log: %w", err)
+	}
+
+	if err := os.WriteFile(w.DailyPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write daily log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/internal/schema"
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ListsDir holds problem lists within the workspace root
+const ListsDir = "lists"
+
+// ErrListNotFound is returned when a problem list does not exist
+var ErrListNotFound = errors.New("problem list not found")
+
+var reListName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateListName checks that a list name can be used as a file name
+func ValidateListName(name string) error {
+	if !reListName.MatchString(name) || strings.HasSuffix(name, ".yaml") {
+		return fmt.Errorf("invalid list name %q (use letters, digits, '.', '-' and '_')", name)
+	}
+	return nil
+}
+
+// ListsPath returns the directory holding problem lists
+func (w *Workspace) ListsPath() string {
+	return filepath.Join(w.root, ListsDir)
+}
+
+// ListPath returns the path of a problem list
+func (w *Workspace) ListPath(name string) string {
+	return filepath.Join(w.ListsPath(), name+".yaml")
+}
+
+// ListExists checks if a problem list exists in the workspace
+func (w *Workspace) ListExists(name string) bool {
+	_, err := os.Stat(w.ListPath(name))
+	return err == nil
+}
+
+// LoadList loads a problem list by name, returning ErrListNotFound if it
+// does not exist
+func (w *Workspace) LoadList(name string) (*v1.ProblemList, error) {
+	if err := ValidateListName(name); err != nil {
+		return nil, err
+	}
+	list, err := ReadList(w.ListPath(name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrListNotFound, name)
+		}
+		return nil, err
+	}
+	list.Name = name
+	return list, nil
+}
+
+// SaveList writes a problem list to lists/<name>.yaml
+func (w *Workspace) SaveList(list *v1.ProblemList) error {
+	if err := ValidateListName(list.Name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(w.ListsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create lists dir: %w", err)
+	}
+
+	list.UpdatedAt = time.Now()
+	data, err := yaml.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("failed to marshal list: %w", err)
+	}
+	if err := os.WriteFile(w.ListPath(list.Name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write list: %w", err)
+	}
+	return nil
+}
+
+// ListLists returns the workspace's problem lists, sorted by name.
+// Unreadable files are skipped.
+func (w *Workspace) ListLists() ([]*v1.ProblemList, error) {
+	entries, err := os.ReadDir(w.ListsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read lists: %w", err)
+	}
+
+	var lists []*v1.ProblemList
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		list, err := ReadList(filepath.Join(w.ListsPath(), entry.Name()))
+		if err != nil {
+			continue
+		}
+		// The file name wins over the name inside, so a shared file can be
+		// dropped in under any name
+		list.Name = strings.TrimSuffix(entry.Name(), ".yaml")
+		lists = append(lists, list)
+	}
+
+	sort.Slice(lists, func(i, j int) bool {
+		return lists[i].Name < lists[j].Name
+	})
+	return lists, nil
+}
+
+// ReadList reads a problem list file from any path, such as a list shared
+// by a teammate. It fails if the file is not a problem list or was written
+// by an incompatible schema version.
+func ReadList(path string) (*v1.ProblemList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read list: %w", err)
+	}
+
+	var list v1.ProblemList
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse list: %w", err)
+	}
+	if list.Schema.Type != schema.TypeProblemList {
+		return nil, fmt.Errorf("%s is not a problem list", path)
+	}
+	version, err := schema.ParseVersion(list.Schema.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse list: %w", err)
+	}
+	if !schema.CurrentVersion.IsCompatible(version) {
+		return nil, fmt.Errorf("list %s uses schema %s, incompatible with %s", path, version, schema.CurrentVersion)
+	}
+	return &list, nil
+}
@@ -0,0 +1,69 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_Lists(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if _, err := ws.LoadList("graphs"); !errors.Is(err, ErrListNotFound) {
+		t.Fatalf("LoadList() error = %v, want ErrListNotFound", err)
+	}
+
+	list := v1.NewProblemList("graphs")
+	list.Add("BFS", v1.ListProblem{ContestID: 1325, Index: "A", Name: "EhAb AnD gCd"})
+	if err := ws.SaveList(list); err != nil {
+		t.Fatalf("SaveList() error = %v", err)
+	}
+
+	loaded, err := ws.LoadList("graphs")
+	if err != nil {
+		t.Fatalf("LoadList() error = %v", err)
+	}
+	if p := loaded.Find(1325, "A"); p == nil || p.Name != "EhAb AnD gCd" || loaded.Sections[0].Name != "BFS" {
+		t.Errorf("LoadList() = %+v", loaded)
+	}
+
+	// A shared file dropped in under another name is listed by file name
+	data, _ := os.ReadFile(ws.ListPath("graphs"))
+	os.WriteFile(ws.ListPath("team"), data, 0644)
+	os.WriteFile(filepath.Join(ws.ListsPath(), "notes.yaml"), []byte("todo: []\n"), 0644)
+
+	lists, err := ws.ListLists()
+	if err != nil {
+		t.Fatalf("ListLists() error = %v", err)
+	}
+	if len(lists) != 2 || lists[0].Name != "graphs" || lists[1].Name != "team" {
+		t.Errorf("ListLists() = %d lists, want graphs and team", len(lists))
+	}
+
+	if err := ws.SaveList(v1.NewProblemList("../escape")); err == nil {
+		t.Error("SaveList() accepted a name with a path")
+	}
+}
+
+func TestReadList_Incompatible(t *testing.T) {
+	dir := t.TempDir()
+
+	notList := filepath.Join(dir, "problem.yaml")
+	os.WriteFile(notList, []byte("_schema:\n  version: 1.0.0\n  type: problem\n"), 0644)
+	if _, err := ReadList(notList); err == nil {
+		t.Error("ReadList() accepted a problem file")
+	}
+
+	newer := filepath.Join(dir, "newer.yaml")
+	os.WriteFile(newer, []byte("_schema:\n  version: 2.0.0\n  type: problemList\nname: x\n"), 0644)
+	if _, err := ReadList(newer); err == nil || !strings.Contains(err.Error(), "incompatible") {
+		t.Errorf("ReadList() error = %v, want incompatible schema", err)
+	}
+}
@@ -0,0 +1,201 @@
+package workspace
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// PracticeUpdate records a problem whose practice data changed during a sync
+type PracticeUpdate struct {
+	Problem   *v1.Problem
+	OldStatus v1.PracticeStatus
+}
+
+// PracticeSyncResult summarizes a practice sync
+type PracticeSyncResult struct {
+	NewSubmissions int // submission records added or updated
+	Skipped        int // submissions for problems not in the workspace
+	Updated        []PracticeUpdate
+	Progress       *v1.Progress // rebuilt from the updated practice data
+}
+
+// SyncPractice records submissions for workspace problems that are not yet in
+// the workspace, then recomputes practice data for every workspace problem
+// from all submission records. Submissions for other problems are skipped.
+// Records already in the workspace keep their source file, hash and language.
+// The progress file is rebuilt afterwards.
+func (w *Workspace) SyncPractice(submissions []*v1.Submission) (*PracticeSyncResult, error) {
+	result := &PracticeSyncResult{}
+
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list problems: %w", err)
+	}
+
+	inWorkspace := make(map[string]bool, len(problems))
+	for _, problem := range problems {
+		inWorkspace[problemKey(problem.ContestID, problem.Index)] = true
+	}
+
+	for _, sub := range submissions {
+		if !inWorkspace[sub.ProblemID] {
+			result.Skipped++
+			continue
+		}
+
+		existing, err := w.LoadSubmission(sub.ID)
+		if err == nil {
+			if existing.Verdict == sub.Verdict && existing.TimeUsed == sub.TimeUsed {
+				continue
+			}
+			sub.SourceFile = existing.SourceFile
+			sub.SourceHash = existing.SourceHash
+			if existing.Language != "" {
+				sub.Language = existing.Language
+			}
+			if existing.LanguageID != 0 {
+				sub.LanguageID = existing.LanguageID
+			}
+		}
+
+		if err := w.SaveSubmission(sub); err != nil {
+			return nil, err
+		}
+		result.NewSubmissions++
+	}
+
+	records, err := w.ListSubmissions()
+	if err != nil {
+		return nil, err
+	}
+
+	byProblem := make(map[string][]*v1.Submission)
+	for _, sub := range records {
+		byProblem[sub.ProblemID] = append(byProblem[sub.ProblemID], sub)
+	}
+
+	for _, problem := range problems {
+		subs := byProblem[problemKey(problem.ContestID, problem.Index)]
+		practice, changed := ReconcilePractice(problem.Practice, subs)
+		if !changed {
+			continue
+		}
+
+		old := problem.Practice.Status
+		problem.Practice = practice
+		if err := w.SaveProblem(problem); err != nil {
+			return nil, err
+		}
+		result.Updated = append(result.Updated, PracticeUpdate{Problem: problem, OldStatus: old})
+	}
+
+	result.Progress, err = w.RebuildProgress()
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ReconcilePractice derives practice data from a problem's submissions.
+// Compilation errors and submissions still being judged are not attempts.
+// A status is never downgraded, so practice recorded by other means is kept.
+func ReconcilePractice(practice v1.PracticeData, submissions []*v1.Submission) (v1.PracticeData, bool) {
+	var judged []*v1.Submission
+	for _, s := range submissions {
+		if s.Verdict == v1.VerdictPending || s.Verdict == v1.VerdictTesting || s.Verdict == v1.VerdictCompilationError {
+			continue
+		}
+		judged = append(judged, s)
+	}
+	if len(judged) == 0 {
+		return practice, false
+	}
+
+	sort.SliceStable(judged, func(i, j int) bool {
+		return judged[i].SubmittedAt.Before(judged[j].SubmittedAt)
+	})
+
+	updated := practice
+	updated.AttemptCount = len(judged)
+	updated.FirstAttempt = timePtr(judged[0].SubmittedAt)
+	if statusRank(updated.Status) < statusRank(v1.StatusAttempted) {
+		updated.Status = v1.StatusAttempted
+	}
+
+	var best *v1.Submission
+	for _, s := range judged {
+		if !s.Verdict.IsAccepted() {
+			continue
+		}
+		if best == nil {
+			updated.SolvedAt = timePtr(s.SubmittedAt)
+			updated.Status = v1.StatusSolved
+			best = s
+		} else if parseMillis(s.TimeUsed) < parseMillis(best.TimeUsed) {
+			best = s
+		}
+	}
+	if best != nil {
+		updated.BestSubmission = &best.ID
+	}
+
+	return updated, !practiceEqual(practice, updated)
+}
+
+// problemKey formats a problem ID the way submission records store it
+func problemKey(contestID int, index string) string {
+	return fmt.Sprintf("%d%s", contestID, index)
+}
+
+func statusRank(s v1.PracticeStatus) int {
+	switch s {
+	case v1.StatusSolved:
+		return 2
+	case v1.StatusAttempted:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// parseMillis parses a "N ms" duration, returning a large value if unknown
+func parseMillis(s string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(strings.TrimSuffix(s, "ms")), 10, 64)
+	if err != nil {
+		return 1<<62 - 1
+	}
+	return n
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func practiceEqual(a, b v1.PracticeData) bool {
+	return a.Status == b.Status &&
+		a.AttemptCount == b.AttemptCount &&
+		a.TimeSpent == b.TimeSpent &&
+		timeEqual(a.FirstAttempt, b.FirstAttempt) &&
+		timeEqual(a.SolvedAt, b.SolvedAt) &&
+		int64Equal(a.BestSubmission, b.BestSubmission)
+}
+
+func timeEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func int64Equal(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
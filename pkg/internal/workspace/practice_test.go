@@ -0,0 +1,171 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func practiceSub(id int64, verdict v1.Verdict, at time.Time, timeUsed string) *v1.Submission {
+	sub := v1.NewSubmission(id, "1325A", 1325, "cpp17", 54)
+	sub.Verdict = verdict
+	sub.SubmittedAt = at
+	sub.TimeUsed = timeUsed
+	return sub
+}
+
+func TestReconcilePractice(t *testing.T) {
+	t0 := time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		practice     v1.PracticeData
+		subs         []*v1.Submission
+		wantStatus   v1.PracticeStatus
+		wantAttempts int
+		wantBest     int64
+		wantChanged  bool
+	}{
+		{
+			name:        "no submissions",
+			practice:    v1.PracticeData{Status: v1.StatusUnseen},
+			wantStatus:  v1.StatusUnseen,
+			wantChanged: false,
+		},
+		{
+			name:     "only compilation errors and pending",
+			practice: v1.PracticeData{Status: v1.StatusUnseen},
+			subs: []*v1.Submission{
+				practiceSub(1, v1.VerdictCompilationError, t0, ""),
+				practiceSub(2, v1.VerdictTesting, t0, ""),
+			},
+			wantStatus:  v1.StatusUnseen,
+			wantChanged: false,
+		},
+		{
+			name:     "attempted",
+			practice: v1.PracticeData{Status: v1.StatusUnseen},
+			subs: []*v1.Submission{
+				practiceSub(2, v1.VerdictWrongAnswer, t0.Add(time.Hour), "15 ms"),
+				practiceSub(1, v1.VerdictTimeLimitExceeded, t0, "2000 ms"),
+			},
+			wantStatus:   v1.StatusAttempted,
+			wantAttempts: 2,
+			wantChanged:  true,
+		},
+		{
+			name:     "solved picks fastest accepted",
+			practice: v1.PracticeData{Status: v1.StatusAttempted},
+			subs: []*v1.Submission{
+				practiceSub(1, v1.VerdictWrongAnswer, t0, "15 ms"),
+				practiceSub(2, v1.VerdictOK, t0.Add(time.Hour), "200 ms"),
+				practiceSub(3, v1.VerdictOK, t0.Add(2*time.Hour), "46 ms"),
+			},
+			wantStatus:   v1.StatusSolved,
+			wantAttempts: 3,
+			wantBest:     3,
+			wantChanged:  true,
+		},
+		{
+			name:     "solved status is never downgraded",
+			practice: v1.PracticeData{Status: v1.StatusSolved},
+			subs: []*v1.Submission{
+				practiceSub(1, v1.VerdictWrongAnswer, t0, "15 ms"),
+			},
+			wantStatus:   v1.StatusSolved,
+			wantAttempts: 1,
+			wantChanged:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, changed := ReconcilePractice(tt.practice, tt.subs)
+			if changed != tt.wantChanged {
+				t.Errorf("changed = %v, want %v", changed, tt.wantChanged)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %v, want %v", got.Status, tt.wantStatus)
+			}
+			if got.AttemptCount != tt.wantAttempts {
+				t.Errorf("AttemptCount = %d, want %d", got.AttemptCount, tt.wantAttempts)
+			}
+			if tt.wantBest != 0 && (got.BestSubmission == nil || *got.BestSubmission != tt.wantBest) {
+				t.Errorf("BestSubmission = %v, want %d", got.BestSubmission, tt.wantBest)
+			}
+			if tt.wantAttempts > 0 && !got.FirstAttempt.Equal(t0) {
+				t.Errorf("FirstAttempt = %v, want %v", got.FirstAttempt, t0)
+			}
+			if tt.wantBest != 0 && !got.SolvedAt.Equal(t0.Add(time.Hour)) {
+				t.Errorf("SolvedAt = %v, want first accepted time", got.SolvedAt)
+			}
+		})
+	}
+}
+
+func TestWorkspace_SyncPractice(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if err := ws.SaveProblem(&v1.Problem{ID: "1325A", Platform: "codeforces", ContestID: 1325, Index: "A", Name: "EhAb AnD gCd"}); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	if err := ws.SaveProblem(&v1.Problem{ID: "1325B", Platform: "codeforces", ContestID: 1325, Index: "B", Name: "CopyCopyCopyCopyCopy"}); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	// A submission made with cf submit keeps its source reference
+	local := practiceSub(10, v1.VerdictTesting, time.Now(), "")
+	local.SourceFile = "/tmp/main.cpp"
+	ws.SaveSubmission(local)
+
+	// The API names the language by its compiler and has no compiler ID
+	t0 := time.Now().Add(-time.Hour).Truncate(time.Second)
+	synced := practiceSub(10, v1.VerdictOK, t0.Add(time.Minute), "31 ms")
+	synced.Language, synced.LanguageID = "GNU C++17", 0
+	subs := []*v1.Submission{
+		synced,
+		practiceSub(9, v1.VerdictWrongAnswer, t0, "15 ms"),
+		v1.NewSubmission(8, "1000A", 1000, "cpp17", 0),
+	}
+	subs[2].Verdict = v1.VerdictOK
+
+	result, err := ws.SyncPractice(subs)
+	if err != nil {
+		t.Fatalf("SyncPractice() error = %v", err)
+	}
+	if result.NewSubmissions != 2 || result.Skipped != 1 {
+		t.Errorf("NewSubmissions = %d, Skipped = %d; want 2, 1", result.NewSubmissions, result.Skipped)
+	}
+	if _, err := ws.LoadSubmission(8); err == nil {
+		t.Error("submission for a problem outside the workspace was recorded")
+	}
+	if len(result.Updated) != 1 || result.Updated[0].Problem.Index != "A" {
+		t.Fatalf("Updated = %+v, want only 1325A", result.Updated)
+	}
+
+	problem, _ := ws.LoadProblem("codeforces", 1325, "A")
+	if problem.Practice.Status != v1.StatusSolved || problem.Practice.AttemptCount != 2 {
+		t.Errorf("practice = %+v", problem.Practice)
+	}
+
+	record, _ := ws.LoadSubmission(10)
+	if record.SourceFile != "/tmp/main.cpp" || record.Verdict != v1.VerdictOK {
+		t.Errorf("record 10 = %+v, want verdict updated and source kept", record)
+	}
+	if record.Language != "cpp17" || record.LanguageID != 54 {
+		t.Errorf("record 10 language = %s (%d), want the cf submit language cpp17 (54)", record.Language, record.LanguageID)
+	}
+
+	// A second sync with nothing new changes nothing
+	result, err = ws.SyncPractice(subs)
+	if err != nil {
+		t.Fatalf("SyncPractice() error = %v", err)
+	}
+	if result.NewSubmissions != 0 || len(result.Updated) != 0 {
+		t.Errorf("second sync = %+v, want no changes", result)
+	}
+}
@@ -10,9 +10,11 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// SaveProblem saves a problem to the workspace
+// SaveProblem saves a problem to the workspace. Sample files are rewritten
+// from problem.Samples and registered in problem.Tests.
 func (w *Workspace) SaveProblem(problem *v1.Problem) error {
 	problemDir := w.ProblemPath(problem.Platform, problem.ContestID, problem.Index)
+	registerSamples(problem)
 
 	// Create directory
 	if err := os.MkdirAll(problemDir, 0755); err != nil {
@@ -36,8 +38,9 @@ func (w *Workspace) SaveProblem(problem *v1.Problem) error {
 	}
 
 	for _, sample := range problem.Samples {
-		inputPath := filepath.Join(testsDir, fmt.Sprintf("sample_%d.in", sample.Index))
-		outputPath := filepath.Join(testsDir, fmt.Sprintf("sample_%d.out", sample.Index))
+		name := v1.SampleTestName(sample.Index)
+		inputPath := filepath.Join(testsDir, name+".in")
+		outputPath := filepath.Join(testsDir, name+".out")
 
 		if err := os.WriteFile(inputPath, []byte(sample.Input), 0644); err != nil {
 			return fmt.Errorf("failed to write sample input: %w", err)
@@ -0,0 +1,109 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// ProgressFile is the progress file name within the stats directory
+const ProgressFile = "progress.yaml"
+
+// ProgressPath returns the path of the progress file
+func (w *Workspace) ProgressPath() string {
+	return filepath.Join(w.StatsPath(), ProgressFile)
+}
+
+// LoadProgress loads the progress file, returning empty progress if none exists
+func (w *Workspace) LoadProgress() (*v1.Progress, error) {
+	data, err := os.ReadFile(w.ProgressPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return v1.NewProgress(), nil
+		}
+		return nil, fmt.Errorf("failed to read progress: %w", err)
+	}
+
+	var progress v1.Progress
+	if err := yaml.Unmarshal(data, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse progress: %w", err)
+	}
+
+	if progress.RatingDistribution == nil {
+		progress.RatingDistribution = make(map[string]int)
+	}
+	if progress.TagDistribution == nil {
+		progress.TagDistribution = make(map[string]int)
+	}
+
+	return &progress, nil
+}
+
+// SaveProgress saves the progress file
+func (w *Workspace) SaveProgress(progress *v1.Progress) error {
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to marshal progress: %w", err)
+	}
+
+	if err := os.WriteFile(w.ProgressPath(), data, 0644); err != nil {
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+
+	return nil
+}
+
+// RebuildProgress recomputes progress from the practice data of every
+// workspace problem and saves it. Solves count on the day they happened,
+// and problems that are only attempted count on their first attempt.
+func (w *Workspace) RebuildProgress() (*v1.Progress, error) {
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list problems: %w", err)
+	}
+
+	type event struct {
+		problem *v1.Problem
+		at      time.Time
+	}
+
+	var events []event
+	for _, p := range problems {
+		switch {
+		case p.Practice.Status == v1.StatusSolved && p.Practice.SolvedAt != nil:
+			events = append(events, event{p, *p.Practice.SolvedAt})
+		case p.Practice.Status != v1.StatusUnseen && p.Practice.FirstAttempt != nil:
+			events = append(events, event{p, *p.Practice.FirstAttempt})
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].at.Before(events[j].at)
+	})
+
+	progress := v1.NewProgress()
+	for _, e := range events {
+		p := e.problem
+		at := e.at.Local()
+		if p.Practice.Status == v1.StatusSolved {
+			progress.RecordSolved(p.ID, p.Metadata.Rating, p.Metadata.Tags, p.Practice.TimeSpent, at)
+		} else {
+			progress.RecordAttempted(p.ID, p.Practice.TimeSpent, at)
+		}
+	}
+
+	if err := w.SaveProgress(progress); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
@@ -0,0 +1,103 @@
+package workspace
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_LoadProgress_Missing(t *testing.T) {
+	ws := New(t.TempDir())
+
+	progress, err := ws.LoadProgress()
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if progress.TotalSolved != 0 || progress.RatingDistribution == nil {
+		t.Errorf("LoadProgress() = %+v, want empty progress", progress)
+	}
+}
+
+func TestWorkspace_SaveLoadProgress(t *testing.T) {
+	ws := New(t.TempDir())
+
+	progress := v1.NewProgress()
+	progress.AddSolved("1325A", 800, []string{"math"}, 60)
+	if err := ws.SaveProgress(progress); err != nil {
+		t.Fatalf("SaveProgress() error = %v", err)
+	}
+
+	if _, err := os.Stat(ws.ProgressPath()); err != nil {
+		t.Fatalf("progress file not written: %v", err)
+	}
+
+	loaded, err := ws.LoadProgress()
+	if err != nil {
+		t.Fatalf("LoadProgress() error = %v", err)
+	}
+	if loaded.TotalSolved != 1 || loaded.TagDistribution["math"] != 1 || loaded.CurrentStreak != 1 {
+		t.Errorf("loaded = %+v", loaded)
+	}
+}
+
+func TestWorkspace_RebuildProgress(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	day1 := time.Now().AddDate(0, 0, -1)
+	day2 := time.Now()
+
+	solved := func(id string, contestID int, rating int, at time.Time) *v1.Problem {
+		p := v1.NewProblem(contestID, "A", id)
+		p.ID = id
+		p.Metadata = v1.ProblemMetadata{Rating: rating, Tags: []string{"greedy"}}
+		p.Practice = v1.PracticeData{Status: v1.StatusSolved, FirstAttempt: &at, SolvedAt: &at}
+		return p
+	}
+
+	ws.SaveProblem(solved("1A", 1, 800, day2))
+	ws.SaveProblem(solved("2A", 2, 1500, day1))
+
+	attempted := v1.NewProblem(3, "A", "3A")
+	attempted.ID = "3A"
+	attempted.Practice = v1.PracticeData{Status: v1.StatusAttempted, FirstAttempt: &day2}
+	ws.SaveProblem(attempted)
+
+	ws.SaveProblem(v1.NewProblem(4, "A", "unseen"))
+
+	progress, err := ws.RebuildProgress()
+	if err != nil {
+		t.Fatalf("RebuildProgress() error = %v", err)
+	}
+
+	if progress.TotalSolved != 2 || progress.TotalAttempted != 1 {
+		t.Errorf("totals = %d solved, %d attempted", progress.TotalSolved, progress.TotalAttempted)
+	}
+	if progress.CurrentStreak != 2 || progress.LongestStreak != 2 {
+		t.Errorf("streak = %d/%d, want 2/2", progress.CurrentStreak, progress.LongestStreak)
+	}
+	if progress.RatingDistribution["800-999"] != 1 || progress.RatingDistribution["1400-1599"] != 1 {
+		t.Errorf("RatingDistribution = %v", progress.RatingDistribution)
+	}
+	if progress.TagDistribution["greedy"] != 2 {
+		t.Errorf("TagDistribution = %v", progress.TagDistribution)
+	}
+	if today := progress.Day(day2.Format("2006-01-02")); today.Solved != 1 || today.Attempted != 1 {
+		t.Errorf("today = %+v", today)
+	}
+
+	// Rebuilding is idempotent
+	again, _ := ws.RebuildProgress()
+	if again.TotalSolved != 2 || len(again.Daily) != len(progress.Daily) {
+		t.Errorf("second rebuild = %+v", again)
+	}
+
+	loaded, _ := ws.LoadProgress()
+	if loaded.TotalSolved != 2 {
+		t.Errorf("saved progress TotalSolved = %d", loaded.TotalSolved)
+	}
+}
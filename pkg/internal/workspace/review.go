@@ -0,0 +1,55 @@
+package workspace
+
+import (
+	"sort"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// ReviewProblems returns the problems marked for review, soonest due first.
+// Problems never reviewed come last, in ID order.
+func (w *Workspace) ReviewProblems() ([]*v1.Problem, error) {
+	problems, err := w.ListProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	var review []*v1.Problem
+	for _, p := range problems {
+		if p.Notes.Review {
+			review = append(review, p)
+		}
+	}
+
+	sort.SliceStable(review, func(i, j int) bool {
+		a, b := review[i].Review, review[j].Review
+		switch {
+		case a == nil && b == nil:
+			return review[i].ID < review[j].ID
+		case a == nil || b == nil:
+			return b == nil
+		case !a.Due.Equal(b.Due):
+			return a.Due.Before(b.Due)
+		default:
+			return review[i].ID < review[j].ID
+		}
+	})
+	return review, nil
+}
+
+// DueReviews returns the problems due for review at now, in queue order
+func (w *Workspace) DueReviews(now time.Time) ([]*v1.Problem, error) {
+	problems, err := w.ReviewProblems()
+	if err != nil {
+		return nil, err
+	}
+
+	var due []*v1.Problem
+	for _, p := range problems {
+		if p.ReviewDue(now) {
+			due = append(due, p)
+		}
+	}
+	return due, nil
+}
@@ -0,0 +1,61 @@
+package workspace
+
+import (
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_DueReviews(t *testing.T) {
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	now := time.Date(2024, 3, 11, 12, 0, 0, 0, time.UTC)
+
+	save := func(index string, review bool, due *time.Time) {
+		p := v1.NewProblem(1325, index, "Test "+index)
+		p.Notes.Review = review
+		if due != nil {
+			p.Review = &v1.ReviewSchedule{Due: *due, Ease: v1.DefaultEase}
+		}
+		if err := ws.SaveProblem(p); err != nil {
+			t.Fatalf("SaveProblem() error = %v", err)
+		}
+	}
+	day := func(d int) *time.Time {
+		t := now.AddDate(0, 0, d)
+		return &t
+	}
+
+	save("A", true, nil)     // new
+	save("B", true, day(2))  // not due yet
+	save("C", true, day(-3)) // overdue
+	save("D", false, day(-5))
+	save("E", true, day(-1))
+
+	all, err := ws.ReviewProblems()
+	if err != nil {
+		t.Fatalf("ReviewProblems() error = %v", err)
+	}
+	if got := indexes(all); got != "CEBA" {
+		t.Errorf("ReviewProblems() = %s, want CEBA", got)
+	}
+
+	due, err := ws.DueReviews(now)
+	if err != nil {
+		t.Fatalf("DueReviews() error = %v", err)
+	}
+	if got := indexes(due); got != "CEA" {
+		t.Errorf("DueReviews() = %s, want CEA", got)
+	}
+}
+
+func indexes(problems []*v1.Problem) string {
+	s := ""
+	for _, p := range problems {
+		s += p.Index
+	}
+	return s
+}
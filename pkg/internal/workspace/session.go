@@ -0,0 +1,210 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// SessionFile holds the active practice session within the stats directory
+	SessionFile = "session.yaml"
+	// SessionsDir holds finished session summaries within the stats directory
+	SessionsDir = "sessions"
+)
+
+var (
+	// ErrNoSession is returned when no practice session is active
+	ErrNoSession = errors.New("no active practice session")
+	// ErrSessionActive is returned when starting a session while one is active
+	ErrSessionActive = errors.New("a practice session is already active")
+)
+
+// SessionPath returns the path of the active session file
+func (w *Workspace) SessionPath() string {
+	return filepath.Join(w.StatsPath(), SessionFile)
+}
+
+// SessionsPath returns the directory of finished session summaries
+func (w *Workspace) SessionsPath() string {
+	return filepath.Join(w.StatsPath(), SessionsDir)
+}
+
+// LoadSession loads the active session, returning ErrNoSession if none is
+// active
+func (w *Workspace) LoadSession() (*v1.Session, error) {
+	session, err := readSession(w.SessionPath())
+	if os.IsNotExist(err) {
+		return nil, ErrNoSession
+	}
+	return session, err
+}
+
+// StartSession makes session the active session. It fails with
+// ErrSessionActive if another session is active.
+func (w *Workspace) StartSession(session *v1.Session) error {
+	if err := os.MkdirAll(w.StatsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create stats dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	f, err := os.OpenFile(w.SessionPath(), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		if os.IsExist(err) {
+			return ErrSessionActive
+		}
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return f.Close()
+}
+
+// SaveSession updates the active session. Since sessions can be ended from
+// another terminal, it returns ErrNoSession if session is no longer active.
+func (w *Workspace) SaveSession(session *v1.Session) error {
+	active, err := w.LoadSession()
+	if err != nil {
+		return err
+	}
+	if active.ID != session.ID {
+		return ErrNoSession
+	}
+	return writeSession(w.SessionPath(), session)
+}
+
+// EndSession ends the active session at the given time. Each problem's time
+// is added to its practice data, marking unseen problems attempted, the
+// summary is written to stats/sessions/<id>.yaml and progress is rebuilt.
+// Returns the summary path, or ErrNoSession if session is no longer active.
+func (w *Workspace) EndSession(session *v1.Session, status v1.SessionStatus, at time.Time) (string, error) {
+	active, err := w.LoadSession()
+	if err != nil {
+		return "", err
+	}
+	if active.ID != session.ID {
+		return "", ErrNoSession
+	}
+	session.End(status, at)
+
+	for _, sp := range session.Problems {
+		if sp.TimeSpent == 0 {
+			continue
+		}
+		problem, err := w.LoadProblem("codeforces", sp.ContestID, sp.Index)
+		if err != nil {
+			continue // removed from the workspace during the session
+		}
+		problem.Practice.TimeSpent += sp.TimeSpent
+		// Working on a problem counts as attempting it, so its time shows up
+		// in progress even without a submission
+		if statusRank(problem.Practice.Status) == 0 {
+			problem.Practice.Status = v1.StatusAttempted
+			problem.Practice.FirstAttempt = timePtr(session.StartedAt)
+		}
+		if err := w.SaveProblem(problem); err != nil {
+			return "", err
+		}
+	}
+
+	if err := os.MkdirAll(w.SessionsPath(), 0755); err != nil {
+		return "", fmt.Errorf("failed to create sessions dir: %w", err)
+	}
+	path := filepath.Join(w.SessionsPath(), session.ID+".yaml")
+	if err := writeSession(path, session); err != nil {
+		return "", err
+	}
+
+	if err := os.Remove(w.SessionPath()); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("failed to remove active session: %w", err)
+	}
+
+	if _, err := w.RebuildProgress(); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// ListSessions returns finished sessions, oldest first
+func (w *Workspace) ListSessions() ([]*v1.Session, error) {
+	entries, err := os.ReadDir(w.SessionsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read sessions: %w", err)
+	}
+
+	var sessions []*v1.Session
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+		session, err := readSession(filepath.Join(w.SessionsPath(), entry.Name()))
+		if err != nil {
+			continue // Skip unreadable summaries
+		}
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].StartedAt.Before(sessions[j].StartedAt)
+	})
+	return sessions, nil
+}
+
+func readSession(path string) (*v1.Session, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+
+	var session v1.Session
+	if err := yaml.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("failed to parse session: %w", err)
+	}
+	return &session, nil
+}
+
+// writeSession writes a session file atomically, so another terminal never
+// reads a partial file
+func writeSession(path string, session *v1.Session) error {
+	data, err := yaml.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".session-*.yaml")
+	if err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_SessionLifecycle(t *testing.T) {
+	ws, problem := newTestProblem(t)
+
+	if _, err := ws.LoadSession(); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("LoadSession() error = %v, want ErrNoSession", err)
+	}
+
+	start := time.Now().Add(-time.Hour)
+	session := v1.NewSession(start, 2*time.Hour)
+	session.Problems = []v1.SessionProblem{
+		{ContestID: problem.ContestID, Index: problem.Index},
+		{ContestID: 9999, Index: "Z"}, // not in the workspace
+	}
+	if err := ws.StartSession(session); err != nil {
+		t.Fatalf("StartSession() error = %v", err)
+	}
+	if err := ws.StartSession(v1.NewSession(time.Now(), time.Hour)); !errors.Is(err, ErrSessionActive) {
+		t.Errorf("second StartSession() error = %v, want ErrSessionActive", err)
+	}
+
+	// Another terminal sees the solve
+	session.RecordSolve(problem.ContestID, problem.Index, 42, start.Add(10*time.Minute))
+	if err := ws.SaveSession(session); err != nil {
+		t.Fatalf("SaveSession() error = %v", err)
+	}
+	loaded, err := ws.LoadSession()
+	if err != nil || loaded.Solved() != 1 {
+		t.Fatalf("LoadSession() = %+v, %v", loaded, err)
+	}
+
+	path, err := ws.EndSession(loaded, v1.SessionStopped, start.Add(30*time.Minute))
+	if err != nil {
+		t.Fatalf("EndSession() error = %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("summary not written: %v", err)
+	}
+	if _, err := ws.LoadSession(); !errors.Is(err, ErrNoSession) {
+		t.Errorf("session still active after EndSession(): %v", err)
+	}
+	if err := ws.SaveSession(session); !errors.Is(err, ErrNoSession) {
+		t.Errorf("SaveSession() after end error = %v, want ErrNoSession", err)
+	}
+
+	saved, _ := ws.LoadProblem("codeforces", problem.ContestID, problem.Index)
+	if saved.Practice.TimeSpent != 600 || saved.Practice.Status != v1.StatusAttempted {
+		t.Errorf("Practice = %+v, want 600s and attempted", saved.Practice)
+	}
+
+	progress, _ := ws.LoadProgress()
+	if progress.TotalTime != 600 {
+		t.Errorf("TotalTime = %d, want 600", progress.TotalTime)
+	}
+
+	sessions, err := ws.ListSessions()
+	if err != nil || len(sessions) != 1 || sessions[0].Status != v1.SessionStopped || sessions[0].Problems[1].TimeSpent != 1200 {
+		t.Errorf("ListSessions() = %+v, %v", sessions, err)
+	}
+}
@@ -0,0 +1,92 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+	"gopkg.in/yaml.v3"
+)
+
+// SubmissionPath returns the path of a submission record
+func (w *Workspace) SubmissionPath(id int64) string {
+	return filepath.Join(w.SubmissionsPath(), fmt.Sprintf("%d.yaml", id))
+}
+
+// SaveSubmission saves a submission record to the workspace
+func (w *Workspace) SaveSubmission(submission *v1.Submission) error {
+	if err := os.MkdirAll(w.SubmissionsPath(), 0755); err != nil {
+		return fmt.Errorf("failed to create submissions dir: %w", err)
+	}
+
+	data, err := yaml.Marshal(submission)
+	if err != nil {
+		return fmt.Errorf("failed to marshal submission: %w", err)
+	}
+
+	if err := os.WriteFile(w.SubmissionPath(submission.ID), data, 0644); err != nil {
+		return fmt.Errorf("failed to write submission: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSubmission loads a submission record from the workspace
+func (w *Workspace) LoadSubmission(id int64) (*v1.Submission, error) {
+	data, err := os.ReadFile(w.SubmissionPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read submission: %w", err)
+	}
+
+	var submission v1.Submission
+	if err := yaml.Unmarshal(data, &submission); err != nil {
+		return nil, fmt.Errorf("failed to parse submission: %w", err)
+	}
+
+	return &submission, nil
+}
+
+// SubmissionExists checks if a submission record exists in the workspace
+func (w *Workspace) SubmissionExists(id int64) bool {
+	_, err := os.Stat(w.SubmissionPath(id))
+	return err == nil
+}
+
+// ListSubmissions lists all submission records, newest first
+func (w *Workspace) ListSubmissions() ([]*v1.Submission, error) {
+	entries, err := os.ReadDir(w.SubmissionsPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read submissions dir: %w", err)
+	}
+
+	var submissions []*v1.Submission
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".yaml") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(w.SubmissionsPath(), entry.Name()))
+		if err != nil {
+			continue // Skip read errors
+		}
+
+		var submission v1.Submission
+		if err := yaml.Unmarshal(data, &submission); err != nil {
+			continue // Skip parse errors
+		}
+
+		submissions = append(submissions, &submission)
+	}
+
+	sort.Slice(submissions, func(i, j int) bool {
+		return submissions[i].ID > submissions[j].ID
+	})
+
+	return submissions, nil
+}
@@ -0,0 +1,174 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func TestWorkspace_SaveSubmission(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	sub := v1.NewSubmission(123456, "1325A", 1325, "cpp17", 54)
+	sub.Verdict = v1.VerdictOK
+
+	if err := ws.SaveSubmission(sub); err != nil {
+		t.Fatalf("SaveSubmission() error = %v", err)
+	}
+
+	if !ws.SubmissionExists(123456) {
+		t.Error("SubmissionExists() should return true after SaveSubmission()")
+	}
+
+	loaded, err := ws.LoadSubmission(123456)
+	if err != nil {
+		t.Fatalf("LoadSubmission() error = %v", err)
+	}
+	if loaded.ProblemID != "1325A" {
+		t.Errorf("ProblemID = %v, want 1325A", loaded.ProblemID)
+	}
+	if loaded.Verdict != v1.VerdictOK {
+		t.Errorf("Verdict = %v, want OK", loaded.Verdict)
+	}
+}
+
+func TestWorkspace_LoadSubmission_NotFound(t *testing.T) {
+	ws := New(t.TempDir())
+
+	if _, err := ws.LoadSubmission(1); err == nil {
+		t.Error("LoadSubmission() should error for missing submission")
+	}
+	if ws.SubmissionExists(1) {
+		t.Error("SubmissionExists() should return false for missing submission")
+	}
+}
+
+func TestWorkspace_ListSubmissions(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	for _, id := range []int64{10, 30, 20} {
+		if err := ws.SaveSubmission(v1.NewSubmission(id, "1A", 1, "cpp17", 54)); err != nil {
+			t.Fatalf("SaveSubmission() error = %v", err)
+		}
+	}
+
+	// Garbage files are skipped
+	os.WriteFile(filepath.Join(ws.SubmissionsPath(), "bad.yaml"), []byte(":\t:\t:"), 0644)
+
+	subs, err := ws.ListSubmissions()
+	if err != nil {
+		t.Fatalf("ListSubmissions() error = %v", err)
+	}
+	if len(subs) != 3 {
+		t.Fatalf("ListSubmissions() returned %d, want 3", len(subs))
+	}
+	if subs[0].ID != 30 || subs[2].ID != 10 {
+		t.Errorf("ListSubmissions() not sorted newest first: %d, %d, %d", subs[0].ID, subs[1].ID, subs[2].ID)
+	}
+}
+
+func TestWorkspace_ListSubmissions_NoDir(t *testing.T) {
+	ws := New(t.TempDir())
+
+	subs, err := ws.ListSubmissions()
+	if err != nil {
+		t.Errorf("ListSubmissions() error = %v", err)
+	}
+	if len(subs) != 0 {
+		t.Errorf("ListSubmissions() returned %d, want 0", len(subs))
+	}
+}
+
+func TestWorkspace_LocateProblem(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	problemDir := ws.ProblemPath("codeforces", 1325, "A")
+
+	tests := []struct {
+		name    string
+		path    string
+		want    *ProblemLocation
+		wantErr bool
+	}{
+		{
+			name: "solution file",
+			path: filepath.Join(problemDir, "solutions", "main.cpp"),
+			want: &ProblemLocation{Platform: "codeforces", ContestID: 1325, Index: "A"},
+		},
+		{
+			name: "problem dir",
+			path: problemDir,
+			want: &ProblemLocation{Platform: "codeforces", ContestID: 1325, Index: "A"},
+		},
+		{
+			name: "gym solution file",
+			path: filepath.Join(ws.ProblemPath("codeforces", 102001, "B"), "solutions", "main.cpp"),
+			want: &ProblemLocation{Platform: "codeforces", ContestID: 102001, Index: "B"},
+		},
+		{
+			name:    "contest dir",
+			path:    filepath.Dir(problemDir),
+			wantErr: true,
+		},
+		{
+			name:    "outside workspace",
+			path:    filepath.Join(tmpDir, "..", "elsewhere", "main.cpp"),
+			wantErr: true,
+		},
+		{
+			name:    "invalid contest id",
+			path:    filepath.Join(ws.ProblemsPath(), "codeforces", "contest", "abc", "A"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ws.LocateProblem(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("LocateProblem() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if *got != *tt.want {
+				t.Errorf("LocateProblem() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkspace_ProblemPath_Gym(t *testing.T) {
+	ws := New(t.TempDir())
+
+	tests := []struct {
+		contestID int
+		want      string
+	}{
+		{1325, filepath.Join("codeforces", "contest", "1325", "A")},
+		{102001, filepath.Join("codeforces", "gym", "102001", "A")},
+	}
+
+	for _, tt := range tests {
+		got, err := filepath.Rel(ws.ProblemsPath(), ws.ProblemPath("codeforces", tt.contestID, "A"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("ProblemPath(%d) = %s, want %s", tt.contestID, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,307 @@
+package workspace
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// DefaultTemplateName is used when no template name is given
+const DefaultTemplateName = "default"
+
+// ErrTemplateNotFound is returned when no matching template exists
+var ErrTemplateNotFound = errors.New("template not found")
+
+// Template is a solution template stored at templates/<language>/<name>.<ext>
+type Template struct {
+	Language string
+	Name     string
+	Path     string
+}
+
+// Ext returns the template's file extension, e.g. ".cpp"
+func (t *Template) Ext() string {
+	return filepath.Ext(t.Path)
+}
+
+// placeholderPattern matches {{.Field}} placeholders. Other braces, such as
+// C++ initializers like {{0, 1}, {1, 0}}, are left as they are.
+var placeholderPattern = regexp.MustCompile(`\{\{\s*\.(\w+)\s*\}\}`)
+
+// TemplateData holds the values available to templates, e.g. {{.Name}}
+type TemplateData struct {
+	ID          string
+	Name        string
+	URL         string
+	ContestID   int
+	Index       string
+	TimeLimit   string
+	MemoryLimit string
+	Rating      int
+	Tags        []string
+	Handle      string
+	Date        string
+}
+
+// NewTemplateData builds template values for a problem
+func NewTemplateData(problem *v1.Problem, handle string, now time.Time) *TemplateData {
+	return &TemplateData{
+		ID:          problem.ID,
+		Name:        problem.Name,
+		URL:         problem.URL,
+		ContestID:   problem.ContestID,
+		Index:       problem.Index,
+		TimeLimit:   problem.Limits.TimeLimit,
+		MemoryLimit: problem.Limits.MemoryLimit,
+		Rating:      problem.Metadata.Rating,
+		Tags:        problem.Metadata.Tags,
+		Handle:      handle,
+		Date:        now.Format("2006-01-02"),
+	}
+}
+
+// values maps placeholder names to their expanded text
+func (d *TemplateData) values() map[string]string {
+	return map[string]string{
+		"ID":          d.ID,
+		"Name":        d.Name,
+		"URL":         d.URL,
+		"ContestID":   strconv.Itoa(d.ContestID),
+		"Index":       d.Index,
+		"TimeLimit":   d.TimeLimit,
+		"MemoryLimit": d.MemoryLimit,
+		"Rating":      strconv.Itoa(d.Rating),
+		"Tags":        strings.Join(d.Tags, ", "),
+		"Handle":      d.Handle,
+		"Date":        d.Date,
+	}
+}
+
+// expandPlaceholders replaces {{.Field}} placeholders in content, failing on
+// fields that TemplateData does not have
+func expandPlaceholders(content []byte, data *TemplateData) ([]byte, error) {
+	values := data.values()
+	unknown := ""
+	out := placeholderPattern.ReplaceAllFunc(content, func(m []byte) []byte {
+		name := string(placeholderPattern.FindSubmatch(m)[1])
+		value, ok := values[name]
+		if !ok {
+			if unknown == "" {
+				unknown = name
+			}
+			return m
+		}
+		return []byte(value)
+	})
+	if unknown != "" {
+		return nil, fmt.Errorf("unknown placeholder {{.%s}}", unknown)
+	}
+	return out, nil
+}
+
+// ListTemplates returns all templates, sorted by language and name
+func (w *Workspace) ListTemplates() ([]*Template, error) {
+	langDirs, err := os.ReadDir(w.TemplatesPath())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates dir: %w", err)
+	}
+
+	var templates []*Template
+	for _, langDir := range langDirs {
+		if !langDir.IsDir() {
+			continue
+		}
+		langTemplates, err := w.languageTemplates(langDir.Name())
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, langTemplates...)
+	}
+
+	return templates, nil
+}
+
+// languageTemplates returns the templates for one language, sorted by name
+func (w *Workspace) languageTemplates(language string) ([]*Template, error) {
+	dir := filepath.Join(w.TemplatesPath(), language)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read templates dir: %w", err)
+	}
+
+	var templates []*Template
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		templates = append(templates, &Template{
+			Language: language,
+			Name:     strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			Path:     filepath.Join(dir, entry.Name()),
+		})
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// FindTemplate returns the named template for a language. With an empty
+// name it returns the "default" template, or the only template if there
+// is just one.
+func (w *Workspace) FindTemplate(language, name string) (*Template, error) {
+	templates, err := w.languageTemplates(language)
+	if err != nil {
+		return nil, err
+	}
+
+	want := name
+	if want == "" {
+		want = DefaultTemplateName
+	}
+	for _, t := range templates {
+		if t.Name == want {
+			return t, nil
+		}
+	}
+
+	if name == "" && len(templates) == 1 {
+		return templates[0], nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s", ErrTemplateNotFound, language, want)
+}
+
+// AddTemplate copies a source file into templates/<language>/<name><ext>,
+// replacing any template with the same name
+func (w *Workspace) AddTemplate(language, name, sourcePath string) (*Template, error) {
+	if language == "" || strings.ContainsAny(language, `/\`) {
+		return nil, fmt.Errorf("invalid language: %q", language)
+	}
+	if name == "" {
+		name = DefaultTemplateName
+	}
+	if strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name: %q", name)
+	}
+
+	content, err := os.ReadFile(sourcePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	// Validate placeholders up front so fetch never fails on a bad template
+	if _, err := expandPlaceholders(content, &TemplateData{}); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	if existing, err := w.FindTemplate(language, name); err == nil {
+		if err := os.Remove(existing.Path); err != nil {
+			return nil, fmt.Errorf("failed to replace template: %w", err)
+		}
+	}
+
+	dir := filepath.Join(w.TemplatesPath(), language)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create templates dir: %w", err)
+	}
+
+	tmpl := &Template{
+		Language: language,
+		Name:     name,
+		Path:     filepath.Join(dir, name+filepath.Ext(sourcePath)),
+	}
+	if err := os.WriteFile(tmpl.Path, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed to write template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// RemoveTemplate deletes a template
+func (w *Workspace) RemoveTemplate(language, name string) error {
+	if name == "" {
+		name = DefaultTemplateName
+	}
+
+	tmpl, err := w.FindTemplate(language, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(tmpl.Path); err != nil {
+		return fmt.Errorf("failed to remove template: %w", err)
+	}
+
+	// Drop the language dir once its last template is gone
+	os.Remove(filepath.Dir(tmpl.Path))
+	return nil
+}
+
+// RenderTemplate expands placeholders in a template
+func RenderTemplate(tmpl *Template, data *TemplateData) ([]byte, error) {
+	content, err := os.ReadFile(tmpl.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	rendered, err := expandPlaceholders(content, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render template %s/%s: %w", tmpl.Language, tmpl.Name, err)
+	}
+
+	return rendered, nil
+}
+
+// CreateSolution renders a template into the problem's solutions/main<ext>.
+// It returns the solution path, or "" if the language has no matching template
+// or the solution already exists.
+func (w *Workspace) CreateSolution(problem *v1.Problem, language, name string) (string, error) {
+	tmpl, err := w.FindTemplate(language, name)
+	if err != nil {
+		if errors.Is(err, ErrTemplateNotFound) && name == "" {
+			return "", nil
+		}
+		return "", err
+	}
+
+	solutionsDir := filepath.Join(w.ProblemPath(problem.Platform, problem.ContestID, problem.Index), "solutions")
+	solutionPath := filepath.Join(solutionsDir, "main"+tmpl.Ext())
+	if _, err := os.Stat(solutionPath); err == nil {
+		return "", nil
+	}
+
+	handle := ""
+	if w.manifest != nil {
+		handle = w.manifest.Codeforces.Handle
+	}
+
+	content, err := RenderTemplate(tmpl, NewTemplateData(problem, handle, time.Now()))
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(solutionsDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create solutions dir: %w", err)
+	}
+	if err := os.WriteFile(solutionPath, content, 0644); err != nil {
+		return "", fmt.Errorf("failed to write solution: %w", err)
+	}
+
+	return solutionPath, nil
+}
@@ -0,0 +1,187 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func writeFile(t *testing.T, path, content string) string {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestWorkspace_AddTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	src := writeFile(t, filepath.Join(tmpDir, "tmpl.cpp"), "// {{.Name}}\n")
+
+	tmpl, err := ws.AddTemplate("cpp", "", src)
+	if err != nil {
+		t.Fatalf("AddTemplate() error = %v", err)
+	}
+	if tmpl.Name != DefaultTemplateName || tmpl.Ext() != ".cpp" {
+		t.Errorf("AddTemplate() = %+v", tmpl)
+	}
+	if _, err := os.Stat(filepath.Join(ws.TemplatesPath(), "cpp", "default.cpp")); err != nil {
+		t.Errorf("template file not written: %v", err)
+	}
+
+	// Replacing with a different extension drops the old file
+	src2 := writeFile(t, filepath.Join(tmpDir, "tmpl.cc"), "// v2\n")
+	if _, err := ws.AddTemplate("cpp", "default", src2); err != nil {
+		t.Fatalf("AddTemplate() replace error = %v", err)
+	}
+	templates, _ := ws.ListTemplates()
+	if len(templates) != 1 || templates[0].Ext() != ".cc" {
+		t.Errorf("ListTemplates() after replace = %+v", templates)
+	}
+
+	bad := writeFile(t, filepath.Join(tmpDir, "bad.cpp"), "// {{.Nmae}}\n")
+	if _, err := ws.AddTemplate("cpp", "bad", bad); err == nil {
+		t.Error("AddTemplate() should reject invalid placeholders")
+	}
+	if _, err := ws.AddTemplate("cpp", "../x", src); err == nil {
+		t.Error("AddTemplate() should reject names with path separators")
+	}
+}
+
+func TestWorkspace_FindTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	src := writeFile(t, filepath.Join(tmpDir, "a.py"), "pass\n")
+	if _, err := ws.AddTemplate("python", "fast", src); err != nil {
+		t.Fatalf("AddTemplate() error = %v", err)
+	}
+
+	// Single template is used when no default exists
+	tmpl, err := ws.FindTemplate("python", "")
+	if err != nil || tmpl.Name != "fast" {
+		t.Errorf("FindTemplate(python, \"\") = %+v, %v", tmpl, err)
+	}
+
+	if _, err := ws.AddTemplate("python", "slow", src); err != nil {
+		t.Fatalf("AddTemplate() error = %v", err)
+	}
+	if _, err := ws.FindTemplate("python", ""); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("FindTemplate() with two templates and no default error = %v", err)
+	}
+	if _, err := ws.FindTemplate("rust", "default"); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("FindTemplate(rust) error = %v", err)
+	}
+}
+
+func TestWorkspace_RemoveTemplate(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+
+	src := writeFile(t, filepath.Join(tmpDir, "a.cpp"), "x\n")
+	ws.AddTemplate("cpp", "default", src)
+
+	if err := ws.RemoveTemplate("cpp", ""); err != nil {
+		t.Fatalf("RemoveTemplate() error = %v", err)
+	}
+	if templates, _ := ws.ListTemplates(); len(templates) != 0 {
+		t.Errorf("ListTemplates() after remove = %d, want 0", len(templates))
+	}
+	if err := ws.RemoveTemplate("cpp", ""); err == nil {
+		t.Error("RemoveTemplate() should fail for missing template")
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	path := writeFile(t, filepath.Join(t.TempDir(), "default.cpp"),
+		"// {{.ID}} {{.Name}} by {{.Handle}} on {{.Date}}\n// {{.URL}} TL={{.TimeLimit}}\n")
+
+	problem := v1.NewProblem(1325, "A", "EhAb AnD gCd")
+	problem.URL = "https://codeforces.com/contest/1325/problem/A"
+	problem.Limits.TimeLimit = "1 second"
+
+	data := NewTemplateData(problem, "tourist", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	got, err := RenderTemplate(&Template{Language: "cpp", Name: "default", Path: path}, data)
+	if err != nil {
+		t.Fatalf("RenderTemplate() error = %v", err)
+	}
+
+	want := "// 1325A EhAb AnD gCd by tourist on 2024-03-01\n// https://codeforces.com/contest/1325/problem/A TL=1 second\n"
+	if string(got) != want {
+		t.Errorf("RenderTemplate() = %q, want %q", got, want)
+	}
+
+	// Only {{.Field}} placeholders are expanded; brace initializers are kept
+	braces := writeFile(t, filepath.Join(t.TempDir(), "braces.cpp"),
+		"vector<vector<int>> d = {{0,1},{1,0}};\nint a[2][2] = {{1,2},{3,4}};\nmap<int, int> m{{1, 2}}; // {{ .Index }}\n")
+	got, err = RenderTemplate(&Template{Path: braces}, data)
+	if err != nil {
+		t.Fatalf("RenderTemplate() with brace initializers error = %v", err)
+	}
+	want = "vector<vector<int>> d = {{0,1},{1,0}};\nint a[2][2] = {{1,2},{3,4}};\nmap<int, int> m{{1, 2}}; // A\n"
+	if string(got) != want {
+		t.Errorf("RenderTemplate() = %q, want %q", got, want)
+	}
+
+	unknown := writeFile(t, filepath.Join(t.TempDir(), "x.cpp"), "{{.Nope}}")
+	if _, err := RenderTemplate(&Template{Path: unknown}, data); err == nil {
+		t.Error("RenderTemplate() should fail on unknown placeholder")
+	}
+}
+
+func TestWorkspace_CreateSolution(t *testing.T) {
+	tmpDir := t.TempDir()
+	ws := New(tmpDir)
+	if err := ws.Init("Test", "tourist"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "Test")
+
+	// No template: nothing created, no error
+	path, err := ws.CreateSolution(problem, "cpp", "")
+	if err != nil || path != "" {
+		t.Fatalf("CreateSolution() without template = %q, %v", path, err)
+	}
+
+	src := writeFile(t, filepath.Join(tmpDir, "t.cpp"), "// {{.Name}} - {{.Handle}}\n")
+	ws.AddTemplate("cpp", "", src)
+
+	path, err = ws.CreateSolution(problem, "cpp", "")
+	if err != nil {
+		t.Fatalf("CreateSolution() error = %v", err)
+	}
+	if filepath.Base(path) != "main.cpp" {
+		t.Errorf("CreateSolution() path = %v, want main.cpp", path)
+	}
+	data, _ := os.ReadFile(path)
+	if strings.TrimSpace(string(data)) != "// Test - tourist" {
+		t.Errorf("solution content = %q", data)
+	}
+
+	// Existing solution is never overwritten
+	os.WriteFile(path, []byte("mine"), 0644)
+	again, err := ws.CreateSolution(problem, "cpp", "")
+	if err != nil || again != "" {
+		t.Errorf("CreateSolution() second call = %q, %v", again, err)
+	}
+	data, _ = os.ReadFile(path)
+	if string(data) != "mine" {
+		t.Error("CreateSolution() overwrote an existing solution")
+	}
+
+	// An explicitly named template must exist
+	if _, err := ws.CreateSolution(problem, "cpp", "missing"); err == nil {
+		t.Error("CreateSolution() should fail for a missing named template")
+	}
+}
@@ -0,0 +1,193 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+// reNumberedTest matches custom and stress tests, which share test_N names
+var reNumberedTest = regexp.MustCompile(`^test_(\d+)(?:\.in|\.out)?$`)
+
+var reTestName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateTestName checks that a test name is a plain file name inside the
+// tests directory
+func ValidateTestName(name string) error {
+	if !reTestName.MatchString(name) || strings.Contains(name, "..") {
+		return fmt.Errorf("invalid test name %q (use letters, digits, '.', '-' and '_')", name)
+	}
+	return nil
+}
+
+// TestsPath returns the tests directory of a problem
+func (w *Workspace) TestsPath(problem *v1.Problem) string {
+	return filepath.Join(w.ProblemPath(problem.Platform, problem.ContestID, problem.Index), "tests")
+}
+
+// AddTest saves input and answer as the next numbered test (test_N) and
+// registers it in problem.yaml. A nil answer registers a run-only test.
+// Name and AddedAt are filled in on entry; Source and Note are kept.
+func (w *Workspace) AddTest(problem *v1.Problem, entry v1.TestEntry, input, answer []byte) (*v1.TestEntry, error) {
+	dir := w.TestsPath(problem)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create tests dir: %w", err)
+	}
+
+	next, err := nextTestNumber(problem, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.Name = fmt.Sprintf("test_%d", next)
+	entry.AddedAt = time.Now()
+	if err := writeTestFiles(dir, &entry, input, answer); err != nil {
+		return nil, err
+	}
+
+	problem.Tests = append(problem.Tests, entry)
+	if err := w.SaveProblem(problem); err != nil {
+		return nil, err
+	}
+	return problem.FindTest(entry.Name), nil
+}
+
+// UpdateTest rewrites a registered test. A nil answer makes it run-only and
+// removes its expected output. Samples cannot be changed: they are rewritten
+// from the statement on every save. Unregistered test files are registered
+// as custom tests.
+func (w *Workspace) UpdateTest(problem *v1.Problem, name string, input, answer []byte) error {
+	if err := ValidateTestName(name); err != nil {
+		return err
+	}
+
+	dir := w.TestsPath(problem)
+	entry := problem.FindTest(name)
+	if entry == nil {
+		if _, err := os.Stat(filepath.Join(dir, name+".in")); err != nil {
+			return fmt.Errorf("test %s not found", name)
+		}
+		problem.Tests = append(problem.Tests, v1.TestEntry{Name: name, Source: v1.TestSourceCustom, AddedAt: time.Now()})
+		entry = problem.FindTest(name)
+	}
+	if entry.Source == v1.TestSourceSample {
+		return fmt.Errorf("test %s is a sample from the statement; add a custom test instead", name)
+	}
+
+	if err := writeTestFiles(dir, entry, input, answer); err != nil {
+		return err
+	}
+	return w.SaveProblem(problem)
+}
+
+// RemoveTest deletes a test's files and its registry entry. Unregistered
+// test files are removed as well.
+func (w *Workspace) RemoveTest(problem *v1.Problem, name string) error {
+	if err := ValidateTestName(name); err != nil {
+		return err
+	}
+
+	entry := problem.FindTest(name)
+	if entry != nil && entry.Source == v1.TestSourceSample {
+		return fmt.Errorf("test %s is a sample from the statement and cannot be removed", name)
+	}
+
+	dir := w.TestsPath(problem)
+	inputPath := filepath.Join(dir, name+".in")
+	if entry == nil {
+		if _, err := os.Stat(inputPath); err != nil {
+			return fmt.Errorf("test %s not found", name)
+		}
+	}
+
+	for _, path := range []string{inputPath, filepath.Join(dir, name+".out")} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove test file: %w", err)
+		}
+	}
+
+	tests := problem.Tests[:0]
+	for _, entry := range problem.Tests {
+		if entry.Name != name {
+			tests = append(tests, entry)
+		}
+	}
+	problem.Tests = tests
+	return w.SaveProblem(problem)
+}
+
+// registerSamples makes the registry's sample entries match problem.Samples.
+// Samples come first; other entries keep their order.
+func registerSamples(problem *v1.Problem) {
+	var tests []v1.TestEntry
+	for _, sample := range problem.Samples {
+		name := v1.SampleTestName(sample.Index)
+		entry := v1.TestEntry{Name: name, Source: v1.TestSourceSample, AddedAt: problem.FetchedAt}
+		if existing := problem.FindTest(name); existing != nil && existing.Source == v1.TestSourceSample {
+			entry = *existing
+		}
+		tests = append(tests, entry)
+	}
+	for _, entry := range problem.Tests {
+		if entry.Source != v1.TestSourceSample {
+			tests = append(tests, entry)
+		}
+	}
+	problem.Tests = tests
+}
+
+// nextTestNumber returns the smallest N above every test_N in the registry
+// or the tests directory
+func nextTestNumber(problem *v1.Problem, dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read tests dir: %w", err)
+	}
+
+	names := make([]string, 0, len(entries)+len(problem.Tests))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	for _, entry := range problem.Tests {
+		names = append(names, entry.Name)
+	}
+
+	next := 1
+	for _, name := range names {
+		if m := reNumberedTest.FindStringSubmatch(name); m != nil {
+			if n, _ := strconv.Atoi(m[1]); n >= next {
+				next = n + 1
+			}
+		}
+	}
+	return next, nil
+}
+
+// writeTestFiles writes a test's input and answer and updates its NoAnswer
+// flag; a nil answer removes the answer file
+func writeTestFiles(dir string, entry *v1.TestEntry, input, answer []byte) error {
+	inputPath := filepath.Join(dir, entry.Name+".in")
+	answerPath := filepath.Join(dir, entry.Name+".out")
+
+	if err := os.WriteFile(inputPath, input, 0644); err != nil {
+		return fmt.Errorf("failed to write test input: %w", err)
+	}
+
+	entry.NoAnswer = answer == nil
+	if entry.NoAnswer {
+		if err := os.Remove(answerPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove test answer: %w", err)
+		}
+		return nil
+	}
+	if err := os.WriteFile(answerPath, answer, 0644); err != nil {
+		return fmt.Errorf("failed to write test answer: %w", err)
+	}
+	return nil
+}
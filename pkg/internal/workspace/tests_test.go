@@ -0,0 +1,178 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
+)
+
+func newTestProblem(t *testing.T) (*Workspace, *v1.Problem) {
+	t.Helper()
+	ws := New(t.TempDir())
+	if err := ws.Init("Test", "user"); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	problem := v1.NewProblem(1325, "A", "Test")
+	problem.Samples = []v1.Sample{
+		{Index: 1, Input: "1\n", Output: "2\n"},
+		{Index: 2, Input: "2\n", Output: "3\n"},
+	}
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+	return ws, problem
+}
+
+func TestWorkspace_SaveProblem_RegistersSamples(t *testing.T) {
+	ws, problem := newTestProblem(t)
+
+	loaded, err := ws.LoadProblem("codeforces", 1325, "A")
+	if err != nil {
+		t.Fatalf("LoadProblem() error = %v", err)
+	}
+	if len(loaded.Tests) != 2 || loaded.Tests[0].Name != "sample_1" || loaded.Tests[1].Source != v1.TestSourceSample {
+		t.Fatalf("Tests = %+v, want two samples", loaded.Tests)
+	}
+
+	// Custom tests survive a save with fewer samples; stale samples go
+	if _, err := ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceCustom}, []byte("5\n"), nil); err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+	problem.Samples = problem.Samples[:1]
+	if err := ws.SaveProblem(problem); err != nil {
+		t.Fatalf("SaveProblem() error = %v", err)
+	}
+
+	var names []string
+	for _, entry := range problem.Tests {
+		names = append(names, entry.Name)
+	}
+	if len(names) != 2 || names[0] != "sample_1" || names[1] != "test_1" {
+		t.Errorf("Tests = %v, want [sample_1 test_1]", names)
+	}
+}
+
+func TestWorkspace_AddTest(t *testing.T) {
+	ws, problem := newTestProblem(t)
+	dir := ws.TestsPath(problem)
+
+	// An unregistered test_4 from an older stress run is not overwritten
+	os.WriteFile(filepath.Join(dir, "test_4.in"), []byte("x"), 0644)
+
+	entry, err := ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceStress, Note: "seed 7"}, []byte("3\n"), []byte("4\n"))
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+	if entry.Name != "test_5" || entry.NoAnswer || entry.Note != "seed 7" || entry.AddedAt.IsZero() {
+		t.Errorf("entry = %+v", entry)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "test_5.out")); string(data) != "4\n" {
+		t.Errorf("test_5.out = %q", data)
+	}
+
+	entry, err = ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceCustom}, []byte("9\n"), nil)
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+	if entry.Name != "test_6" || !entry.NoAnswer {
+		t.Errorf("run-only entry = %+v", entry)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "test_6.out")); !os.IsNotExist(err) {
+		t.Error("AddTest() wrote an answer for a run-only test")
+	}
+
+	loaded, _ := ws.LoadProblem("codeforces", 1325, "A")
+	if loaded.FindTest("test_6") == nil || !loaded.FindTest("test_6").NoAnswer {
+		t.Error("run-only test not registered in problem.yaml")
+	}
+}
+
+func TestWorkspace_UpdateTest(t *testing.T) {
+	ws, problem := newTestProblem(t)
+	dir := ws.TestsPath(problem)
+
+	entry, err := ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceCustom}, []byte("1\n"), []byte("1\n"))
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+
+	if err := ws.UpdateTest(problem, entry.Name, []byte("2\n"), nil); err != nil {
+		t.Fatalf("UpdateTest() error = %v", err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, entry.Name+".in")); string(data) != "2\n" {
+		t.Errorf("input = %q", data)
+	}
+	if _, err := os.Stat(filepath.Join(dir, entry.Name+".out")); !os.IsNotExist(err) {
+		t.Error("UpdateTest() kept the answer of a run-only test")
+	}
+	if !problem.FindTest(entry.Name).NoAnswer {
+		t.Error("UpdateTest() did not mark the test run-only")
+	}
+
+	if err := ws.UpdateTest(problem, "sample_1", []byte("x"), nil); err == nil {
+		t.Error("UpdateTest() should refuse to change a sample")
+	}
+	if err := ws.UpdateTest(problem, "test_9", []byte("x"), nil); err == nil {
+		t.Error("UpdateTest() should fail for a missing test")
+	}
+	if err := ws.UpdateTest(problem, "../../x", []byte("x"), nil); err == nil {
+		t.Error("UpdateTest() should reject a name outside the tests dir")
+	}
+}
+
+func TestWorkspace_RemoveTest(t *testing.T) {
+	ws, problem := newTestProblem(t)
+	dir := ws.TestsPath(problem)
+
+	entry, err := ws.AddTest(problem, v1.TestEntry{Source: v1.TestSourceCustom}, []byte("1\n"), []byte("1\n"))
+	if err != nil {
+		t.Fatalf("AddTest() error = %v", err)
+	}
+
+	if err := ws.RemoveTest(problem, entry.Name); err != nil {
+		t.Fatalf("RemoveTest() error = %v", err)
+	}
+	if problem.FindTest(entry.Name) != nil {
+		t.Error("RemoveTest() kept the registry entry")
+	}
+	for _, ext := range []string{".in", ".out"} {
+		if _, err := os.Stat(filepath.Join(dir, entry.Name+ext)); !os.IsNotExist(err) {
+			t.Errorf("RemoveTest() kept %s%s", entry.Name, ext)
+		}
+	}
+
+	if err := ws.RemoveTest(problem, "sample_1"); err == nil {
+		t.Error("RemoveTest() should refuse to remove a sample")
+	}
+	if err := ws.RemoveTest(problem, entry.Name); err == nil {
+		t.Error("RemoveTest() should fail for a missing test")
+	}
+
+	// Names must not reach outside the tests directory
+	outside := filepath.Join(filepath.Dir(dir), "secret.in")
+	if err := os.WriteFile(outside, []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ws.RemoveTest(problem, "../secret"); err == nil {
+		t.Error("RemoveTest() should reject a name with a path separator")
+	}
+	if _, err := os.Stat(outside); err != nil {
+		t.Errorf("RemoveTest() removed a file outside the tests dir: %v", err)
+	}
+}
+
+func TestValidateTestName(t *testing.T) {
+	for _, name := range []string{"test_1", "sample_2", "big-n", "max.case"} {
+		if err := ValidateTestName(name); err != nil {
+			t.Errorf("ValidateTestName(%q) error = %v", name, err)
+		}
+	}
+	for _, name := range []string{"", "..", "a..b", "../x", "a/b", `a\b`, ".hidden", "/abs"} {
+		if err := ValidateTestName(name); err == nil {
+			t.Errorf("ValidateTestName(%q) should fail", name)
+		}
+	}
+}
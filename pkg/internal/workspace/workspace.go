@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/harshit-vibes/cf/pkg/internal/schema"
 	v1 "github.com/harshit-vibes/cf/pkg/internal/schema/v1"
@@ -152,17 +154,64 @@ func (w *Workspace) ProblemsPath() string {
 	return filepath.Join(w.root, ProblemsDir)
 }
 
-// ProblemPath returns the path for a specific problem
+// ProblemPath returns the path for a specific problem. Gym problems live
+// under <platform>/gym/<id>, everything else under <platform>/contest/<id>.
 func (w *Workspace) ProblemPath(platform string, contestID int, index string) string {
+	kind := "contest"
+	if v1.IsGymContest(contestID) {
+		kind = "gym"
+	}
 	return filepath.Join(
 		w.ProblemsPath(),
 		platform,
-		"contest",
+		kind,
 		fmt.Sprintf("%d", contestID),
 		index,
 	)
 }
 
+// ProblemLocation identifies a problem by its place in the workspace layout
+type ProblemLocation struct {
+	Platform  string
+	ContestID int
+	Index     string
+}
+
+// LocateProblem finds the problem that contains path, which may be the
+// problem directory itself or any file below it (e.g. solutions/main.cpp)
+func (w *Workspace) LocateProblem(path string) (*ProblemLocation, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve path: %w", err)
+	}
+	absProblems, err := filepath.Abs(w.ProblemsPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve problems path: %w", err)
+	}
+
+	rel, err := filepath.Rel(absProblems, absPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil, fmt.Errorf("%s is not inside the workspace problems directory", path)
+	}
+
+	// Expected layout: <platform>/{contest,gym}/<id>/<index>/...
+	parts := strings.Split(filepath.ToSlash(rel), "/")
+	if len(parts) < 4 || (parts[1] != "contest" && parts[1] != "gym") {
+		return nil, fmt.Errorf("%s is not inside a problem directory", path)
+	}
+
+	contestID, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("invalid contest ID in path: %s", parts[2])
+	}
+
+	return &ProblemLocation{
+		Platform:  parts[0],
+		ContestID: contestID,
+		Index:     parts[3],
+	}, nil
+}
+
 // TemplatesPath returns the templates directory path
 func (w *Workspace) TemplatesPath() string {
 	if w.manifest != nil && w.manifest.Paths.Templates != "" {
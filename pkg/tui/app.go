@@ -14,6 +14,7 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 	"github.com/harshit-vibes/cf/pkg/internal/config"
 	"github.com/harshit-vibes/cf/pkg/tui/styles"
 	"github.com/harshit-vibes/cf/pkg/tui/views"
@@ -44,18 +45,21 @@ type App struct {
 	settings    views.SettingsModel
 
 	// Data
-	client *cfapi.Client
-	handle string
-	user   *cfapi.User
+	client  *cfapi.Client
+	handle  string
+	user    *cfapi.User
+	verdict *cfweb.SubmissionResult // latest state of the tracked submission
+	tracked int64                   // submission being tracked, 0 if none
 }
 
-// New creates a new App instance
-func New() *App {
+// New creates a new App instance. Options configure the API client, e.g.
+// its disk cache and submission store.
+func New(opts ...cfapi.ClientOption) *App {
 	// Get handle from config
 	handle := config.GetCFHandle()
 
 	// Create API client
-	client := cfapi.NewClient()
+	client := cfapi.NewClient(opts...)
 
 	// Create spinner
 	s := spinner.New()
@@ -176,6 +180,21 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		a.dashboard.SetSubmissions(msg.Submissions)
 		a.loading = false
 
+		// Follow the latest submission while it is being judged. A cached
+		// list may still show one we already saw judged.
+		if len(msg.Submissions) > 0 && a.tracked == 0 {
+			latest := msg.Submissions[0]
+			seen := a.verdict != nil && a.verdict.SubmissionID == latest.ID
+			if (latest.Verdict == "" || latest.Verdict == cfapi.VerdictTesting) && !seen {
+				tracker := cfweb.NewVerdictTracker(nil, latest.ID, latest.ContestID)
+				tracker.SetAPIFallback(a.client, a.handle)
+				cmds = append(cmds, a.TrackSubmission(tracker))
+			}
+		}
+
+	case VerdictMsg:
+		cmds = append(cmds, a.updateVerdict(msg))
+
 	case RatingLoadedMsg:
 		a.profile.SetRatingHistory(msg.RatingChanges)
 		a.loading = false
@@ -250,6 +269,8 @@ func (a *App) renderHeader() string {
 		status = a.spinner.View() + " " + a.statusMsg
 	} else if a.err != nil {
 		status = styles.ErrorStyle.Render("Error: " + a.err.Error())
+	} else if a.verdict != nil {
+		status = styles.SubtitleStyle.Render(fmt.Sprintf("#%d %s", a.verdict.SubmissionID, a.verdict.Progress()))
 	} else if a.handle != "" {
 		status = styles.SubtitleStyle.Render("@" + a.handle)
 	}
@@ -345,11 +366,16 @@ func (a *App) loadSubmissions() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
-		subs, err := a.client.GetUserSubmissions(ctx, a.handle, 1, 100)
+		subs, err := a.client.GetAllSubmissions(ctx, a.handle)
 		if err != nil {
 			return ErrorMsg{Err: err}
 		}
 
+		// Views only show recent activity
+		if len(subs) > 100 {
+			subs = subs[:100]
+		}
+
 		return SubmissionsLoadedMsg{Submissions: subs}
 	}
 }
@@ -399,6 +425,95 @@ func (a *App) loadRating() tea.Cmd {
 	}
 }
 
+// TrackSubmission follows a submission until it is judged, showing its
+// progress in the header
+func (a *App) TrackSubmission(tracker *cfweb.VerdictTracker) tea.Cmd {
+	a.tracked = tracker.SubmissionID()
+	return WatchVerdict(tracker)
+}
+
+// WatchVerdict returns a command that polls a tracked submission once and
+// reports a VerdictMsg
+func WatchVerdict(tracker *cfweb.VerdictTracker) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		result, err := tracker.Poll(ctx)
+		return VerdictMsg{Tracker: tracker, Result: result, Err: err}
+	}
+}
+
+// updateVerdict records a poll result and schedules the next poll, after
+// the tracker's adaptive delay, until the submission is judged
+func (a *App) updateVerdict(msg VerdictMsg) tea.Cmd {
+	if msg.Tracker.SubmissionID() != a.tracked {
+		return nil
+	}
+
+	next := tea.Tick(msg.Tracker.Delay(), func(time.Time) tea.Msg {
+		return WatchVerdict(msg.Tracker)()
+	})
+
+	if msg.Err != nil {
+		if cfweb.IsTransient(msg.Err) {
+			return next
+		}
+		a.err = msg.Err
+		a.tracked = 0
+		return nil
+	}
+
+	a.verdict = msg.Result
+	if msg.Result.Pending() {
+		return next
+	}
+
+	// Judged: refresh the lists so they show the final verdict
+	a.tracked = 0
+	return a.reloadSubmissions(msg.Result)
+}
+
+// reloadSubmissions fetches the submission lists past the client's cache.
+// The API can lag behind the judge, so the judged result replaces a
+// submission the API still reports as being tested.
+func (a *App) reloadSubmissions(judged *cfweb.SubmissionResult) tea.Cmd {
+	a.client.InvalidateSubmissions(a.handle)
+	load := a.loadSubmissions()
+	return func() tea.Msg {
+		msg := load()
+		if loaded, ok := msg.(SubmissionsLoadedMsg); ok {
+			loaded.Submissions = applyVerdict(loaded.Submissions, judged)
+			return loaded
+		}
+		return msg
+	}
+}
+
+// applyVerdict returns subs with the judged result set on its submission if
+// that is still pending. subs may be shared with the cache, so it is copied
+// before being changed.
+func applyVerdict(subs []cfapi.Submission, judged *cfweb.SubmissionResult) []cfapi.Submission {
+	for i := range subs {
+		if subs[i].ID != judged.SubmissionID {
+			continue
+		}
+		if !subs[i].IsPending() {
+			return subs
+		}
+
+		patched := append([]cfapi.Submission(nil), subs...)
+		patched[i].Verdict = judged.Verdict
+		patched[i].TimeConsumedMillis = judged.Time.Milliseconds()
+		patched[i].MemoryConsumedBytes = judged.Memory
+		if judged.PassedTests > 0 {
+			patched[i].PassedTestCount = judged.PassedTests
+		}
+		return patched
+	}
+	return subs
+}
+
 func (a *App) refreshCurrentView() tea.Cmd {
 	switch a.currentView {
 	case ViewDashboard:
@@ -415,8 +530,8 @@ func (a *App) refreshCurrentView() tea.Cmd {
 }
 
 // Run starts the TUI application
-func Run() error {
-	app := New()
+func Run(opts ...cfapi.ClientOption) error {
+	app := New(opts...)
 	p := tea.NewProgram(app, tea.WithAltScreen())
 	_, err := p.Run()
 	return err
@@ -0,0 +1,86 @@
+package tui
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
+)
+
+// statusTransport serves user.status with a fixed submission list
+type statusTransport struct {
+	submissions []cfapi.Submission
+}
+
+func (s *statusTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, _ := json.Marshal(cfapi.Response[[]cfapi.Submission]{Status: "OK", Result: s.submissions})
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(string(body))),
+		Header:     make(http.Header),
+	}, nil
+}
+
+func TestApp_UpdateVerdict_ReloadsJudgedSubmission(t *testing.T) {
+	problem := cfapi.Problem{ContestID: 1325, Index: "A"}
+	judged := &cfweb.SubmissionResult{
+		SubmissionID: 2,
+		ContestID:    1325,
+		Verdict:      cfapi.VerdictWrongAnswer,
+		Time:         31 * time.Millisecond,
+		Status:       cfweb.StatusJudged,
+	}
+
+	tests := []struct {
+		name string
+		api  string // verdict the API reports once judging is done
+		want string
+	}{
+		{"api caught up", cfapi.VerdictTimeLimitExceeded, cfapi.VerdictTimeLimitExceeded},
+		{"api lags behind the judge", cfapi.VerdictTesting, cfapi.VerdictWrongAnswer},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := &statusTransport{submissions: []cfapi.Submission{
+				{ID: 2, Problem: problem, Verdict: cfapi.VerdictTesting},
+				{ID: 1, Problem: problem, Verdict: cfapi.VerdictOK},
+			}}
+			store, err := cfapi.NewSubmissionStore(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewSubmissionStore() error = %v", err)
+			}
+			a := New(cfapi.WithHTTPClient(&http.Client{Transport: transport}), cfapi.WithSubmissionStore(store))
+			a.handle = "tourist"
+
+			// The first load caches the submission while it is being tested
+			if msg, ok := a.loadSubmissions()().(SubmissionsLoadedMsg); !ok || msg.Submissions[0].Verdict != cfapi.VerdictTesting {
+				t.Fatalf("loadSubmissions() = %+v, want the pending submission", msg)
+			}
+
+			transport.submissions[0].Verdict = tt.api
+			a.tracked = judged.SubmissionID
+			tracker := cfweb.NewVerdictTracker(nil, judged.SubmissionID, judged.ContestID)
+
+			cmd := a.updateVerdict(VerdictMsg{Tracker: tracker, Result: judged})
+			if cmd == nil {
+				t.Fatal("updateVerdict() returned no command for a judged submission")
+			}
+			msg, ok := cmd().(SubmissionsLoadedMsg)
+			if !ok {
+				t.Fatalf("updateVerdict() command = %T, want SubmissionsLoadedMsg", msg)
+			}
+			if got := msg.Submissions[0].Verdict; got != tt.want {
+				t.Errorf("reloaded verdict = %s, want %s", got, tt.want)
+			}
+			if a.tracked != 0 {
+				t.Errorf("tracked = %d after the verdict, want 0", a.tracked)
+			}
+		})
+	}
+}
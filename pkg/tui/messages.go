@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/harshit-vibes/cf/pkg/external/cfapi"
+	"github.com/harshit-vibes/cf/pkg/external/cfweb"
 )
 
 // View represents different views/tabs in the application
@@ -112,6 +113,13 @@ type StatsLoadedMsg struct {
 	Streak           int
 }
 
+// VerdictMsg reports one poll of a tracked submission
+type VerdictMsg struct {
+	Tracker *cfweb.VerdictTracker
+	Result  *cfweb.SubmissionResult // nil if the poll failed
+	Err     error
+}
+
 // WindowSizeMsg is sent when the window is resized
 type WindowSizeMsg struct {
 	Width  int
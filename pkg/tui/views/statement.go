@@ -0,0 +1,343 @@
+package views
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/harshit-vibes/cf/pkg/tui/styles"
+)
+
+var (
+	reListItem = regexp.MustCompile(`^(\s*)([-*]|\d+\.) (.*)$`)
+	reLink     = regexp.MustCompile(`^(!?)\[([^\]]*)\]\(([^)]*)\)`)
+)
+
+// Statement styles
+var (
+	statementH1Style = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.ColorPrimary)
+
+	statementH2Style = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.ColorTextPrimary).
+				BorderStyle(lipgloss.NormalBorder()).
+				BorderBottom(true).
+				BorderForeground(styles.ColorSubtle)
+
+	statementH3Style = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(styles.ColorSecondary)
+
+	statementRuleStyle = lipgloss.NewStyle().
+				Foreground(styles.ColorSubtle)
+
+	statementCodeStyle = lipgloss.NewStyle().
+				Border(lipgloss.RoundedBorder()).
+				BorderForeground(styles.ColorSubtle).
+				Padding(0, 1)
+
+	statementInlineCodeStyle = lipgloss.NewStyle().
+					Foreground(styles.ColorWarning)
+
+	statementMathStyle = lipgloss.NewStyle().
+				Foreground(styles.ColorAccent)
+
+	statementLinkStyle = lipgloss.NewStyle().
+				Foreground(styles.ColorMuted).
+				Underline(true)
+
+	statementBoldStyle   = lipgloss.NewStyle().Bold(true)
+	statementItalicStyle = lipgloss.NewStyle().Italic(true)
+)
+
+// minSampleWidth is the narrowest terminal that shows sample input and
+// output side by side
+const minSampleWidth = 60
+
+// mdBlock is a block-level element of a statement document
+type mdBlock struct {
+	kind   string // heading, rule, code, item, para
+	level  int    // heading level, or list item indent
+	marker string // list item marker
+	text   string
+	lines  []string // code lines
+}
+
+// parseBlocks splits a Markdown document into blocks
+func parseBlocks(md string) []mdBlock {
+	var blocks []mdBlock
+	var para []string
+
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, mdBlock{kind: "para", text: strings.Join(para, " ")})
+			para = nil
+		}
+	}
+
+	lines := strings.Split(strings.ReplaceAll(md, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, lines[i])
+			}
+			blocks = append(blocks, mdBlock{kind: "code", lines: code})
+		case trimmed == "---" || trimmed == "***":
+			flush()
+			blocks = append(blocks, mdBlock{kind: "rule"})
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			blocks = append(blocks, mdBlock{kind: "heading", level: level, text: strings.TrimSpace(trimmed[level:])})
+		case reListItem.MatchString(line):
+			flush()
+			m := reListItem.FindStringSubmatch(line)
+			blocks = append(blocks, mdBlock{kind: "item", level: len(m[1]), marker: m[2], text: m[3]})
+		default:
+			para = append(para, trimmed)
+		}
+	}
+	flush()
+
+	return blocks
+}
+
+// RenderStatement renders a statement.md document for a terminal of the
+// given width. Sample inputs and outputs are shown side by side when the
+// terminal is wide enough.
+func RenderStatement(md string, width int) string {
+	if width < 20 {
+		width = 20
+	}
+	textStyle := lipgloss.NewStyle().Width(width)
+
+	blocks := parseBlocks(md)
+	var parts []string
+
+	for i := 0; i < len(blocks); i++ {
+		b := blocks[i]
+
+		switch b.kind {
+		case "heading":
+			text := renderInline(b.text)
+			switch b.level {
+			case 1:
+				parts = append(parts, statementH1Style.Width(width).Render(text))
+			case 2:
+				parts = append(parts, statementH2Style.Width(width).Render(text))
+			default:
+				parts = append(parts, statementH3Style.Width(width).Render(text))
+			}
+		case "rule":
+			parts = append(parts, statementRuleStyle.Render(strings.Repeat("─", width)))
+		case "code":
+			parts = append(parts, renderCode("", b.lines, width))
+		case "item":
+			// Consecutive list items render as one block
+			var items []string
+			for ; i < len(blocks) && blocks[i].kind == "item"; i++ {
+				item := blocks[i]
+				bullet := "•"
+				if item.marker != "-" && item.marker != "*" {
+					bullet = item.marker
+				}
+				indent := item.level + 2
+				body := lipgloss.NewStyle().Width(width - indent - len(bullet) - 1).Render(renderInline(item.text))
+				prefix := strings.Repeat(" ", indent) + bullet + " "
+				items = append(items, lipgloss.JoinHorizontal(lipgloss.Top, prefix, body))
+			}
+			i--
+			parts = append(parts, strings.Join(items, "\n"))
+		case "para":
+			if input, output, ok := samplePair(blocks, i); ok {
+				parts = append(parts, renderSample(input, output, width))
+				i += 3
+				continue
+			}
+			parts = append(parts, textStyle.Render(renderInline(b.text)))
+		}
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// samplePair matches the "**Input:**" code "**Output:**" code sequence
+// statement.md uses for examples
+func samplePair(blocks []mdBlock, i int) ([]string, []string, bool) {
+	if i+3 >= len(blocks) {
+		return nil, nil, false
+	}
+	if !isLabel(blocks[i], "Input") || blocks[i+1].kind != "code" ||
+		!isLabel(blocks[i+2], "Output") || blocks[i+3].kind != "code" {
+		return nil, nil, false
+	}
+	return blocks[i+1].lines, blocks[i+3].lines, true
+}
+
+func isLabel(b mdBlock, label string) bool {
+	return b.kind == "para" && strings.Trim(b.text, "*: ") == label
+}
+
+// renderSample renders a sample's input and output boxes
+func renderSample(input, output []string, width int) string {
+	if width < minSampleWidth {
+		return renderCode("Input", input, width) + "\n" + renderCode("Output", output, width)
+	}
+	// Pad the shorter box so both have the same height
+	for len(input) < len(output) {
+		input = append(input, "")
+	}
+	for len(output) < len(input) {
+		output = append(output, "")
+	}
+
+	half := width / 2
+	left := renderCode("Input", input, half)
+	right := renderCode("Output", output, width-half)
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+}
+
+// renderCode renders a code block in a box of the given outer width
+func renderCode(title string, lines []string, width int) string {
+	body := strings.Join(lines, "\n")
+	if title != "" {
+		body = styles.LabelStyle.Render(title) + "\n" + body
+	}
+	// Width covers the padding; the border adds two columns
+	return statementCodeStyle.Width(max(width-2, 1)).Render(body)
+}
+
+// renderInline styles inline Markdown: bold, italic, code, math and links
+func renderInline(text string) string {
+	var sb strings.Builder
+
+	for i := 0; i < len(text); {
+		rest := text[i:]
+
+		switch {
+		case strings.HasPrefix(rest, "**"):
+			if end := strings.Index(rest[2:], "**"); end > 0 {
+				sb.WriteString(statementBoldStyle.Render(rest[2 : 2+end]))
+				i += end + 4
+				continue
+			}
+		case rest[0] == '*':
+			if end := strings.IndexByte(rest[1:], '*'); end > 0 {
+				sb.WriteString(statementItalicStyle.Render(rest[1 : 1+end]))
+				i += end + 2
+				continue
+			}
+		case rest[0] == '`':
+			if end := strings.IndexByte(rest[1:], '`'); end > 0 {
+				sb.WriteString(statementInlineCodeStyle.Render(rest[1 : 1+end]))
+				i += end + 2
+				continue
+			}
+		case strings.HasPrefix(rest, "$$"):
+			if end := strings.Index(rest[2:], "$$"); end > 0 {
+				sb.WriteString(statementMathStyle.Render(rest[:end+4]))
+				i += end + 4
+				continue
+			}
+		case rest[0] == '$':
+			if end := strings.IndexByte(rest[1:], '$'); end > 0 {
+				sb.WriteString(statementMathStyle.Render(rest[:end+2]))
+				i += end + 2
+				continue
+			}
+		case rest[0] == '[' || strings.HasPrefix(rest, "!["):
+			if m := reLink.FindStringSubmatch(rest); m != nil {
+				label := m[2]
+				if m[1] == "!" {
+					label = "[image]"
+					if m[2] != "" {
+						label = "[image: " + m[2] + "]"
+					}
+				}
+				sb.WriteString(label + " " + statementLinkStyle.Render(m[3]))
+				i += len(m[0])
+				continue
+			}
+		}
+
+		sb.WriteByte(text[i])
+		i++
+	}
+
+	return sb.String()
+}
+
+// StatementModel pages a rendered problem statement
+type StatementModel struct {
+	title    string
+	markdown string
+	viewport viewport.Model
+	ready    bool
+}
+
+// NewStatementModel creates a pager for a statement.md document
+func NewStatementModel(title, markdown string) StatementModel {
+	return StatementModel{title: title, markdown: markdown}
+}
+
+// Init initializes the model
+func (m StatementModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles messages
+func (m StatementModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "esc", "ctrl+c":
+			return m, tea.Quit
+		case "g", "home":
+			m.viewport.GotoTop()
+			return m, nil
+		case "G", "end":
+			m.viewport.GotoBottom()
+			return m, nil
+		}
+	case tea.WindowSizeMsg:
+		height := msg.Height - 2 // header and footer
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, height)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = height
+		}
+		m.viewport.SetContent(RenderStatement(m.markdown, msg.Width-2))
+	}
+
+	var cmd tea.Cmd
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// View renders the pager
+func (m StatementModel) View() string {
+	if !m.ready {
+		return "Loading..."
+	}
+
+	header := styles.LogoStyle.Render(m.title)
+	footer := styles.HelpStyle.Render(fmt.Sprintf("↑/↓ scroll • g/G top/bottom • q quit • %3.0f%%", m.viewport.ScrollPercent()*100))
+
+	return header + "\n" + m.viewport.View() + "\n" + footer
+}
@@ -0,0 +1,108 @@
+package views
+
+import (
+	"strings"
+	"testing"
+)
+
+const testStatement = `# A. Sum
+
+**Rating:** 800 | **Time:** 1 second | **Memory:** 256 megabytes
+
+---
+
+## Statement
+
+Given $n$ numbers, print their **sum**.
+
+- first
+- second
+
+## Examples
+
+### Example 1
+
+**Input:**
+` + "```" + `
+3
+1 2 3
+` + "```" + `
+
+**Output:**
+` + "```" + `
+6
+` + "```" + `
+`
+
+func TestParseBlocks(t *testing.T) {
+	blocks := parseBlocks(testStatement)
+
+	var kinds []string
+	for _, b := range blocks {
+		kinds = append(kinds, b.kind)
+	}
+	want := "heading para rule heading para item item heading heading para code para code"
+	if got := strings.Join(kinds, " "); got != want {
+		t.Errorf("parseBlocks() kinds = %q, want %q", got, want)
+	}
+}
+
+func TestRenderStatement(t *testing.T) {
+	tests := []struct {
+		name       string
+		width      int
+		sideBySide bool
+	}{
+		{"wide terminal", 80, true},
+		{"narrow terminal", 40, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RenderStatement(testStatement, tt.width)
+
+			for _, want := range []string{"A. Sum", "Statement", "$n$", "• first", "1 2 3"} {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderStatement() missing %q:\n%s", want, got)
+				}
+			}
+			if strings.Contains(got, "**") || strings.Contains(got, "```") {
+				t.Errorf("RenderStatement() left Markdown syntax:\n%s", got)
+			}
+
+			sideBySide := false
+			for _, line := range strings.Split(got, "\n") {
+				if strings.Contains(line, "Input") && strings.Contains(line, "Output") {
+					sideBySide = true
+				}
+			}
+			if sideBySide != tt.sideBySide {
+				t.Errorf("samples side by side = %v, want %v:\n%s", sideBySide, tt.sideBySide, got)
+			}
+		})
+	}
+}
+
+func TestRenderInline(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"plain text", "plain text"},
+		{"a **bold** word", "a bold word"},
+		{"an *italic* word", "an italic word"},
+		{"print `YES`", "print YES"},
+		{"math $a_i \\le 10^9$ kept", "math $a_i \\le 10^9$ kept"},
+		{"see [editorial](https://codeforces.com/blog)", "see editorial https://codeforces.com/blog"},
+		{"![](pic.png)", "[image] pic.png"},
+		{"unclosed **bold", "unclosed **bold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := renderInline(tt.in); got != tt.want {
+				t.Errorf("renderInline(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}